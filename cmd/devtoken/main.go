@@ -0,0 +1,54 @@
+// Command devtoken mints HS256 bearer tokens for local development and
+// load tests. It signs with AUTH_JWT_HS256_SECRET, the same secret the API
+// verifies with.
+//
+//	go run ./cmd/devtoken -email reader@example.com -roles patron
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func main() {
+	subject := flag.String("sub", "dev-user", "subject (sub claim)")
+	email := flag.String("email", "dev@example.com", "email claim, used as the borrower")
+	roles := flag.String("roles", "patron", "comma-separated roles")
+	issuer := flag.String("iss", os.Getenv("AUTH_JWT_ISSUER"), "issuer (iss claim)")
+	audience := flag.String("aud", os.Getenv("AUTH_JWT_AUDIENCE"), "audience (aud claim)")
+	ttl := flag.Duration("ttl", 24*time.Hour, "token lifetime")
+	flag.Parse()
+
+	secret := os.Getenv("AUTH_JWT_HS256_SECRET")
+	if secret == "" {
+		fmt.Fprintln(os.Stderr, "AUTH_JWT_HS256_SECRET is not set")
+		os.Exit(1)
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub":   *subject,
+		"email": *email,
+		"roles": strings.Split(*roles, ","),
+		"iat":   now.Unix(),
+		"exp":   now.Add(*ttl).Unix(),
+	}
+	if *issuer != "" {
+		claims["iss"] = *issuer
+	}
+	if *audience != "" {
+		claims["aud"] = *audience
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to sign token:", err)
+		os.Exit(1)
+	}
+	fmt.Println(token)
+}
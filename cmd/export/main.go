@@ -0,0 +1,81 @@
+// Command export streams the catalog to a file or stdout as CSV, NDJSON or
+// MARCXML, reading straight from the database. Point it at a replica to
+// keep the load off the primary.
+//
+//	go run ./cmd/export -format marcxml -out catalog.xml
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"library-system/internal/application/exports"
+	"library-system/internal/config"
+	catalogRepo "library-system/internal/infrastructure/adapters/catalog"
+)
+
+func main() {
+	dbURL := flag.String("db", defaultDatabaseURL(), "database URL (default $DATABASE_URL, else the local replica)")
+	formatName := flag.String("format", "csv", "csv, ndjson or marcxml")
+	outPath := flag.String("out", "-", "output file, - for stdout")
+	flag.Parse()
+
+	if err := run(*dbURL, *formatName, *outPath); err != nil {
+		fmt.Fprintln(os.Stderr, "export failed:", err)
+		os.Exit(1)
+	}
+}
+
+func run(dbURL, formatName, outPath string) error {
+	format, err := exports.ParseFormat(formatName)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	pool, err := pgxpool.New(ctx, dbURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer pool.Close()
+	repo := catalogRepo.NewBookRepository(pool, func(context.Context) *pgxpool.Pool { return pool })
+
+	var out io.WriteCloser = os.Stdout
+	if outPath != "-" {
+		if out, err = os.Create(outPath); err != nil {
+			return err
+		}
+	}
+	defer out.Close()
+	buf := bufio.NewWriter(out)
+
+	count, err := exports.NewExporter(repo).Export(ctx, format, buf)
+	if err != nil {
+		return err
+	}
+	if err := buf.Flush(); err != nil {
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "exported %d books\n", count)
+	return nil
+}
+
+func defaultDatabaseURL() string {
+	if u := os.Getenv("DATABASE_URL"); u != "" {
+		return u
+	}
+	return config.Default().Database.ReplicaURLs[0]
+}
@@ -0,0 +1,69 @@
+// Command webhookstub is a local webhook receiver for trying out and
+// testing subscriptions. It checks each delivery's signature and prints its
+// payload, and can be told to fail so retries and dead letters show up.
+//
+//	go run ./cmd/webhookstub -secret whsec_... -fail 0.5
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"library-system/internal/infrastructure/webhooks"
+)
+
+func main() {
+	addr := flag.String("addr", ":9090", "listen address")
+	secret := flag.String("secret", os.Getenv("WEBHOOK_SECRET"), "signing secret returned when the webhook was created")
+	tolerance := flag.Duration("tolerance", 5*time.Minute, "oldest accepted X-Webhook-Timestamp")
+	failRate := flag.Float64("fail", 0, "fraction of deliveries to answer with 503")
+	flag.Parse()
+
+	if *secret == "" {
+		fmt.Fprintln(os.Stderr, "-secret or WEBHOOK_SECRET is required")
+		os.Exit(1)
+	}
+
+	http.HandleFunc("POST /", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+		id := r.Header.Get(webhooks.HeaderID)
+		timestamp := r.Header.Get(webhooks.HeaderTimestamp)
+
+		if !webhooks.Verify(*secret, timestamp, body, r.Header.Get(webhooks.HeaderSignature)) {
+			log.Printf("delivery %s: bad signature", id)
+			http.Error(w, "bad signature", http.StatusUnauthorized)
+			return
+		}
+		// Rejecting old timestamps stops a captured delivery being replayed
+		sent, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil || time.Since(time.Unix(sent, 0)) > *tolerance {
+			log.Printf("delivery %s: stale timestamp %q", id, timestamp)
+			http.Error(w, "stale timestamp", http.StatusUnauthorized)
+			return
+		}
+		if rand.Float64() < *failRate {
+			log.Printf("delivery %s: failing on purpose", id)
+			http.Error(w, "failing on purpose", http.StatusServiceUnavailable)
+			return
+		}
+
+		log.Printf("delivery %s: %s %s", id, r.Header.Get(webhooks.HeaderEvent), body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	log.Printf("listening on %s", *addr)
+	if err := http.ListenAndServe(*addr, nil); err != nil {
+		log.Fatal(err)
+	}
+}
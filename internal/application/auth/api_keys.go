@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"library-system/internal/domain/access"
+)
+
+// scopeRoles maps API key scopes to the roles the policy understands.
+// catalog:read grants no role: any authenticated caller may browse.
+var scopeRoles = map[access.Scope]string{
+	access.ScopeLending: RoleLendingDesk,
+	access.ScopeAdmin:   RoleAdmin,
+}
+
+// APIKeyAuthenticator resolves X-API-Key tokens to principals
+type APIKeyAuthenticator struct {
+	repo access.APIKeyRepository
+}
+
+// NewAPIKeyAuthenticator creates an authenticator backed by repo
+func NewAPIKeyAuthenticator(repo access.APIKeyRepository) *APIKeyAuthenticator {
+	return &APIKeyAuthenticator{repo: repo}
+}
+
+// Authenticate verifies token and returns the principal for its key. Any
+// unknown, mismatched, expired or revoked key yields ErrUnauthenticated;
+// other errors come from the repository.
+func (a *APIKeyAuthenticator) Authenticate(ctx context.Context, token string) (Principal, error) {
+	prefix, secret, err := access.ParseToken(token)
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+
+	key, err := a.repo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		return Principal{}, err
+	}
+	now := time.Now()
+	if key == nil || !key.Matches(secret) {
+		return Principal{}, fmt.Errorf("%w: invalid API key", ErrUnauthenticated)
+	}
+	if !key.ActiveAt(now) {
+		return Principal{}, fmt.Errorf("%w: API key is revoked or expired", ErrUnauthenticated)
+	}
+
+	// Tracking is best effort; it must not fail an authenticated request
+	if err := a.repo.TouchLastUsed(ctx, key.ID(), now); err != nil {
+		slog.WarnContext(ctx, "failed to record API key use", "key_id", key.ID().String(), "error", err)
+	}
+
+	roles := make([]string, 0, len(key.Scopes()))
+	for _, scope := range key.Scopes() {
+		if role, ok := scopeRoles[scope]; ok {
+			roles = append(roles, role)
+		}
+	}
+	return Principal{
+		Subject: "apikey:" + key.ID().String(),
+		Roles:   roles,
+	}, nil
+}
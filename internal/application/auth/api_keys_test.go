@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"library-system/internal/domain/access"
+)
+
+type fakeKeyRepo struct {
+	access.APIKeyRepository // unused methods panic
+	key                     *access.APIKey
+	touched                 int
+}
+
+func (f *fakeKeyRepo) GetByPrefix(ctx context.Context, prefix string) (*access.APIKey, error) {
+	if f.key != nil && f.key.Prefix() == prefix {
+		return f.key, nil
+	}
+	return nil, nil
+}
+
+func (f *fakeKeyRepo) TouchLastUsed(ctx context.Context, id access.APIKeyID, at time.Time) error {
+	f.touched++
+	return nil
+}
+
+func TestAPIKeyAuthenticator_ValidKey(t *testing.T) {
+	key, token, _ := access.IssueAPIKey("kiosk-1", []access.Scope{access.ScopeCatalogRead, access.ScopeLending}, time.Now(), nil)
+	repo := &fakeKeyRepo{key: key}
+
+	p, err := NewAPIKeyAuthenticator(repo).Authenticate(context.Background(), token)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if p.Subject != "apikey:"+key.ID().String() || !p.HasRole(RoleLendingDesk) || len(p.Roles) != 1 {
+		t.Errorf("unexpected principal %+v", p)
+	}
+	if repo.touched != 1 {
+		t.Error("expected last use to be recorded")
+	}
+}
+
+func TestAPIKeyAuthenticator_RejectsBadKeys(t *testing.T) {
+	now := time.Now()
+	key, token, _ := access.IssueAPIKey("kiosk-1", []access.Scope{access.ScopeLending}, now, nil)
+	revoked, revokedToken, _ := access.IssueAPIKey("kiosk-2", []access.Scope{access.ScopeLending}, now, nil)
+	_ = revoked.Revoke(now)
+
+	tests := map[string]struct {
+		key   *access.APIKey
+		token string
+	}{
+		"malformed":    {key, "not-a-key"},
+		"wrong secret": {key, token + "x"},
+		"unknown":      {nil, token},
+		"revoked":      {revoked, revokedToken},
+	}
+	for name, tt := range tests {
+		repo := &fakeKeyRepo{key: tt.key}
+		_, err := NewAPIKeyAuthenticator(repo).Authenticate(context.Background(), tt.token)
+
+		if !errors.Is(err, ErrUnauthenticated) {
+			t.Errorf("%s: expected ErrUnauthenticated, got %v", name, err)
+		}
+		if repo.touched != 0 {
+			t.Errorf("%s: expected no use to be recorded", name)
+		}
+	}
+}
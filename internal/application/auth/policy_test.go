@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func as(roles ...string) context.Context {
+	return WithPrincipal(context.Background(), Principal{Subject: "user-1", Email: "reader@example.com", Roles: roles})
+}
+
+func TestRolePolicy_Authorize(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		action Action
+		owner  string
+		want   error
+	}{
+		{"anonymous", context.Background(), ActionBorrowBook, "", ErrUnauthenticated},
+		{"librarian adds", as(RoleLibrarian), ActionAddBook, "", nil},
+		{"librarian returns for someone", as(RoleLibrarian), ActionReturnBook, "other@example.com", nil},
+		{"patron borrows", as(RolePatron), ActionBorrowBook, "", nil},
+		{"patron returns own loan", as(RolePatron), ActionReturnBook, "Reader@Example.com", nil},
+		{"patron returns for someone", as(RolePatron), ActionReturnBook, "other@example.com", ErrForbidden},
+		{"patron views own loans", as(RolePatron), ActionViewLoans, "reader@example.com", nil},
+		{"patron views other loans", as(RolePatron), ActionViewLoans, "other@example.com", ErrForbidden},
+		{"patron adds", as(RolePatron), ActionAddBook, "", ErrForbidden},
+		{"patron removes", as(RolePatron), ActionRemoveBook, "", ErrForbidden},
+		{"patron borrows for someone", as(RolePatron), ActionBorrowBook, "other@example.com", ErrForbidden},
+		{"no roles", as(), ActionBorrowBook, "", ErrForbidden},
+		{"kiosk borrows for someone", as(RoleLendingDesk), ActionBorrowBook, "other@example.com", nil},
+		{"kiosk returns for someone", as(RoleLendingDesk), ActionReturnBook, "other@example.com", nil},
+		{"kiosk adds", as(RoleLendingDesk), ActionAddBook, "", ErrForbidden},
+		{"librarian manages keys", as(RoleLibrarian), ActionManageAPIKeys, "", ErrForbidden},
+		{"admin manages keys", as(RoleAdmin), ActionManageAPIKeys, "", nil},
+		{"librarian manages webhooks", as(RoleLibrarian), ActionManageWebhooks, "", ErrForbidden},
+		{"admin manages webhooks", as(RoleAdmin), ActionManageWebhooks, "", nil},
+		{"librarian exports", as(RoleLibrarian), ActionExportCatalog, "", nil},
+		{"kiosk exports", as(RoleLendingDesk), ActionExportCatalog, "", ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewRolePolicy().Authorize(tt.ctx, tt.action, tt.owner)
+
+			if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestPrincipal_OwnsRequiresEmail(t *testing.T) {
+	if (Principal{}).Owns("") {
+		t.Error("expected a principal without email to own nothing")
+	}
+}
@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrUnauthenticated is returned when an operation needs a caller identity
+// and none was established.
+var ErrUnauthenticated = errors.New("authentication required")
+
+// Principal is the authenticated caller of a command or query.
+type Principal struct {
+	Subject string   // Stable identifier from the token's sub claim
+	Email   string   // Used as the borrower identity
+	Roles   []string // Granted roles, e.g. "librarian" or "patron"
+}
+
+// HasRole reports whether the principal was granted role.
+func (p Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a context carrying the authenticated caller.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFrom returns the authenticated caller carried by ctx, if any.
+func PrincipalFrom(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
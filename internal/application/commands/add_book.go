@@ -2,6 +2,10 @@ package commands
 
 import (
 	"context"
+	"log/slog"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/ports"
 	"library-system/internal/domain/catalog"
 )
 
@@ -21,16 +25,22 @@ type AddBookResult struct {
 
 // AddBookHandler handles the AddBookCommand
 type AddBookHandler struct {
-	repo catalog.BookRepository
+	repo  catalog.BookRepository
+	uow   ports.UnitOfWork
+	authz auth.Authorizer
 }
 
 // NewAddBookHandler creates a new handler
-func NewAddBookHandler(repo catalog.BookRepository) *AddBookHandler {
-	return &AddBookHandler{repo: repo}
+func NewAddBookHandler(repo catalog.BookRepository, uow ports.UnitOfWork, authz auth.Authorizer) *AddBookHandler {
+	return &AddBookHandler{repo: repo, uow: uow, authz: authz}
 }
 
 // Handle executes the command
 func (h *AddBookHandler) Handle(ctx context.Context, cmd AddBookCommand) (AddBookResult, error) {
+	if err := h.authz.Authorize(ctx, auth.ActionAddBook, ""); err != nil {
+		return AddBookResult{}, err
+	}
+
 	// Create value object
 	title, err := catalog.NewTitle(cmd.Title)
 	if err != nil {
@@ -46,9 +56,12 @@ func (h *AddBookHandler) Handle(ctx context.Context, cmd AddBookCommand) (AddBoo
 	book := catalog.NewBook(catalog.GenerateBookID(), title, author)
 
 	// Persist
-	if err := h.repo.Add(ctx, book); err != nil {
+	if err := h.uow.Do(ctx, func(ctx context.Context) error {
+		return h.repo.Add(ctx, book)
+	}); err != nil {
 		return AddBookResult{}, err
 	}
+	slog.InfoContext(ctx, "book added", "book_id", book.ID().String())
 
 	// Return result
 	return AddBookResult{
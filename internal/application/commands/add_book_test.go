@@ -2,11 +2,29 @@ package commands
 
 import (
 	"context"
+	"strings"
+	"errors"
 	"testing"
+	"time"
 
+	"library-system/internal/application/auth"
 	"library-system/internal/domain/catalog"
 )
 
+// asLibrarian returns a context authenticated as a librarian
+func asLibrarian() context.Context {
+	return auth.WithPrincipal(context.Background(), auth.Principal{
+		Subject: "librarian-1", Email: "librarian@example.com", Roles: []string{auth.RoleLibrarian},
+	})
+}
+
+// asPatron returns a context authenticated as the patron with email
+func asPatron(email string) context.Context {
+	return auth.WithPrincipal(context.Background(), auth.Principal{
+		Subject: "patron-" + email, Email: email, Roles: []string{auth.RolePatron},
+	})
+}
+
 // MockBookRepository is a test double for catalog.BookRepository
 type MockBookRepository struct {
 	books     map[string]*catalog.Book
@@ -28,6 +46,15 @@ func (m *MockBookRepository) Add(ctx context.Context, book *catalog.Book) error
 	return nil
 }
 
+func (m *MockBookRepository) AddAll(ctx context.Context, books []*catalog.Book) error {
+	for _, book := range books {
+		if err := m.Add(ctx, book); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (m *MockBookRepository) GetByID(ctx context.Context, id catalog.BookID) (*catalog.Book, error) {
 	if m.getError != nil {
 		return nil, m.getError
@@ -39,6 +66,19 @@ func (m *MockBookRepository) GetByID(ctx context.Context, id catalog.BookID) (*c
 	return book, nil
 }
 
+func (m *MockBookRepository) GetByIDs(ctx context.Context, ids []catalog.BookID) ([]*catalog.Book, error) {
+	if m.getError != nil {
+		return nil, m.getError
+	}
+	var books []*catalog.Book
+	for _, id := range ids {
+		if book, exists := m.books[id.String()]; exists {
+			books = append(books, book)
+		}
+	}
+	return books, nil
+}
+
 func (m *MockBookRepository) List(ctx context.Context, limit, offset int) ([]*catalog.Book, error) {
 	books := make([]*catalog.Book, 0, len(m.books))
 	for _, book := range m.books {
@@ -55,6 +95,44 @@ func (m *MockBookRepository) List(ctx context.Context, limit, offset int) ([]*ca
 	return books[offset:end], nil
 }
 
+func (m *MockBookRepository) ListBorrowedBy(ctx context.Context, borrowerEmail string) ([]*catalog.Book, error) {
+	var books []*catalog.Book
+	for _, book := range m.books {
+		if book.IsBorrowed() && strings.EqualFold(book.BorrowerEmail(), borrowerEmail) {
+			books = append(books, book)
+		}
+	}
+	return books, nil
+}
+
+func (m *MockBookRepository) ListBorrowedByAny(ctx context.Context, borrowerEmails []string) ([]*catalog.Book, error) {
+	var books []*catalog.Book
+	for _, email := range borrowerEmails {
+		borrowed, _ := m.ListBorrowedBy(ctx, email)
+		books = append(books, borrowed...)
+	}
+	return books, nil
+}
+
+func (m *MockBookRepository) ListDueBefore(ctx context.Context, before time.Time) ([]*catalog.Book, error) {
+	var books []*catalog.Book
+	for _, book := range m.books {
+		if book.IsBorrowed() && book.ReturnDueDate() != nil && book.ReturnDueDate().Before(before) {
+			books = append(books, book)
+		}
+	}
+	return books, nil
+}
+
+func (m *MockBookRepository) Each(ctx context.Context, fn func(*catalog.Book) error) error {
+	for _, book := range m.books {
+		if err := fn(book); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (m *MockBookRepository) Count(ctx context.Context) (int, error) {
 	return len(m.books), nil
 }
@@ -69,12 +147,28 @@ func (m *MockBookRepository) Remove(ctx context.Context, id catalog.BookID) erro
 	return nil
 }
 
+// MockUnitOfWork runs work inline and records whether it committed
+type MockUnitOfWork struct {
+	commits   int
+	rollbacks int
+}
+
+func (u *MockUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		u.rollbacks++
+		return err
+	}
+	u.commits++
+	return nil
+}
+
 // --- Tests ---
 
 func TestAddBookHandler_Success(t *testing.T) {
 	repo := NewMockBookRepository()
-	handler := NewAddBookHandler(repo)
-	ctx := context.Background()
+	uow := &MockUnitOfWork{}
+	handler := NewAddBookHandler(repo, uow, auth.NewRolePolicy())
+	ctx := asLibrarian()
 
 	result, err := handler.Handle(ctx, AddBookCommand{
 		Title:  "Clean Code",
@@ -96,12 +190,15 @@ func TestAddBookHandler_Success(t *testing.T) {
 	if len(repo.books) != 1 {
 		t.Errorf("expected 1 book in repo, got %d", len(repo.books))
 	}
+	if uow.commits != 1 {
+		t.Errorf("expected 1 commit, got %d", uow.commits)
+	}
 }
 
 func TestAddBookHandler_EmptyTitle(t *testing.T) {
 	repo := NewMockBookRepository()
-	handler := NewAddBookHandler(repo)
-	ctx := context.Background()
+	handler := NewAddBookHandler(repo, &MockUnitOfWork{}, auth.NewRolePolicy())
+	ctx := asLibrarian()
 
 	_, err := handler.Handle(ctx, AddBookCommand{
 		Title:  "",
@@ -115,8 +212,8 @@ func TestAddBookHandler_EmptyTitle(t *testing.T) {
 
 func TestAddBookHandler_EmptyAuthor(t *testing.T) {
 	repo := NewMockBookRepository()
-	handler := NewAddBookHandler(repo)
-	ctx := context.Background()
+	handler := NewAddBookHandler(repo, &MockUnitOfWork{}, auth.NewRolePolicy())
+	ctx := asLibrarian()
 
 	_, err := handler.Handle(ctx, AddBookCommand{
 		Title:  "Clean Code",
@@ -127,3 +224,21 @@ func TestAddBookHandler_EmptyAuthor(t *testing.T) {
 		t.Error("expected error for empty author")
 	}
 }
+
+func TestAddBookHandler_PatronForbidden(t *testing.T) {
+	repo := NewMockBookRepository()
+	uow := &MockUnitOfWork{}
+	handler := NewAddBookHandler(repo, uow, auth.NewRolePolicy())
+
+	_, err := handler.Handle(asPatron("john@example.com"), AddBookCommand{
+		Title:  "Clean Code",
+		Author: "Robert Martin",
+	})
+
+	if !errors.Is(err, auth.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+	if len(repo.books) != 0 || uow.commits != 0 {
+		t.Error("expected nothing to be persisted")
+	}
+}
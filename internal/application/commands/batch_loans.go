@@ -0,0 +1,203 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/ports"
+	"library-system/internal/domain/catalog"
+	"library-system/internal/domain/shared"
+)
+
+// MaxBatchLoans caps the books in one batch, bounding how many rows a
+// single transaction locks
+const MaxBatchLoans = 50
+
+// LoanAction is what a batch does to each book
+type LoanAction string
+
+const (
+	LoanActionBorrow LoanAction = "borrow"
+	LoanActionReturn LoanAction = "return"
+)
+
+// BatchMode decides what happens to the rest of a batch when an item fails
+type BatchMode string
+
+const (
+	// BatchModeAllOrNothing applies the batch only if every item succeeds
+	BatchModeAllOrNothing BatchMode = "all_or_nothing"
+	// BatchModeBestEffort applies the items that succeed and reports the rest
+	BatchModeBestEffort BatchMode = "best_effort"
+)
+
+// errBatchRejected rolls back an all-or-nothing batch with failed items
+var errBatchRejected = errors.New("batch rejected")
+
+// BatchLoansCommand represents intent to borrow or return several books in
+// one transaction, e.g. a checkout desk scanning a patron's pile. Borrowing
+// is for the caller, or for OnBehalfOf where the policy allows it; returns
+// are authorized per loan, as with ReturnBookCommand.
+type BatchLoansCommand struct {
+	Action     LoanAction
+	BookIDs    []string
+	OnBehalfOf string
+	Mode       BatchMode
+}
+
+// BatchLoanItem is the outcome for one book, in request order. Error is
+// empty for items that succeeded (or would have, in a rejected batch).
+type BatchLoanItem struct {
+	BookID        string
+	Title         string     `json:",omitempty"`
+	ReturnDueDate *time.Time `json:",omitempty"`
+	Error         string     `json:",omitempty"`
+}
+
+// BatchLoansResult is returned after processing a batch. Committed reports
+// whether any changes were kept: false when an all-or-nothing batch had a
+// failed item.
+type BatchLoansResult struct {
+	Committed bool
+	Succeeded int
+	Failed    int
+	Items     []BatchLoanItem
+}
+
+// BatchLoansHandler handles the BatchLoansCommand
+type BatchLoansHandler struct {
+	repo   catalog.BookRepository
+	uow    ports.UnitOfWork
+	policy catalog.LoanPolicy
+	authz  auth.Authorizer
+}
+
+// NewBatchLoansHandler creates a new handler
+func NewBatchLoansHandler(repo catalog.BookRepository, uow ports.UnitOfWork, policy catalog.LoanPolicy, authz auth.Authorizer) *BatchLoansHandler {
+	return &BatchLoansHandler{repo: repo, uow: uow, policy: policy, authz: authz}
+}
+
+// Handle executes the command. Books that fail a business rule (not
+// found, already borrowed, not the caller's loan, ...) are reported per
+// item; any other error aborts the whole batch.
+func (h *BatchLoansHandler) Handle(ctx context.Context, cmd BatchLoansCommand) (BatchLoansResult, error) {
+	if err := validateBatch(cmd); err != nil {
+		return BatchLoansResult{}, err
+	}
+	principal, ok := auth.PrincipalFrom(ctx)
+	if !ok {
+		return BatchLoansResult{}, auth.ErrUnauthenticated
+	}
+	var borrower string
+	if cmd.Action == LoanActionBorrow {
+		if err := h.authz.Authorize(ctx, auth.ActionBorrowBook, cmd.OnBehalfOf); err != nil {
+			return BatchLoansResult{}, err
+		}
+		// Would fail every item alike, so fail the batch instead
+		var err error
+		if borrower, err = borrowerFor(principal, cmd.OnBehalfOf); err != nil {
+			return BatchLoansResult{}, err
+		}
+	}
+
+	// Lock books in ID order so concurrent batches cannot deadlock
+	order := make([]int, len(cmd.BookIDs))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int {
+		return strings.Compare(cmd.BookIDs[a], cmd.BookIDs[b])
+	})
+
+	var result BatchLoansResult
+	now := time.Now()
+	err := h.uow.Do(ctx, func(ctx context.Context) error {
+		result = BatchLoansResult{Items: make([]BatchLoanItem, len(cmd.BookIDs))}
+		seen := make(map[catalog.BookID]bool, len(cmd.BookIDs))
+		for _, i := range order {
+			item := &result.Items[i]
+			item.BookID = cmd.BookIDs[i]
+
+			book, err := h.apply(ctx, cmd.Action, item.BookID, borrower, now, seen)
+			if err != nil {
+				if !isItemError(err) {
+					return err
+				}
+				item.Error = err.Error()
+				result.Failed++
+				continue
+			}
+			item.Title = book.Title().String()
+			item.ReturnDueDate = book.ReturnDueDate()
+			result.Succeeded++
+		}
+		if result.Failed > 0 && cmd.Mode == BatchModeAllOrNothing {
+			return errBatchRejected
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBatchRejected) {
+		return BatchLoansResult{}, err
+	}
+	result.Committed = err == nil
+
+	slog.InfoContext(ctx, "batch loans processed",
+		"action", string(cmd.Action),
+		"mode", string(cmd.Mode),
+		"subject", principal.Subject,
+		"succeeded", result.Succeeded,
+		"failed", result.Failed,
+		"committed", result.Committed,
+	)
+	return result, nil
+}
+
+// apply borrows or returns one book with the same rules as the single-book
+// commands
+func (h *BatchLoansHandler) apply(ctx context.Context, action LoanAction, rawID, borrower string, now time.Time, seen map[catalog.BookID]bool) (*catalog.Book, error) {
+	id, err := catalog.ParseBookID(rawID)
+	if err != nil {
+		return nil, err
+	}
+	if seen[id] {
+		return nil, shared.ValidationError{Field: "BookIDs", Message: "book appears more than once in the batch"}
+	}
+	seen[id] = true
+
+	if action == LoanActionBorrow {
+		return borrowBook(ctx, h.repo, h.policy, id, borrower, now)
+	}
+	return returnBook(ctx, h.repo, h.authz, id)
+}
+
+func validateBatch(cmd BatchLoansCommand) error {
+	switch {
+	case cmd.Action != LoanActionBorrow && cmd.Action != LoanActionReturn:
+		return shared.ValidationError{Field: "Action", Message: fmt.Sprintf("action must be %s or %s", LoanActionBorrow, LoanActionReturn)}
+	case cmd.Mode != BatchModeAllOrNothing && cmd.Mode != BatchModeBestEffort:
+		return shared.ValidationError{Field: "Mode", Message: fmt.Sprintf("mode must be %s or %s", BatchModeAllOrNothing, BatchModeBestEffort)}
+	case len(cmd.BookIDs) == 0:
+		return shared.ValidationError{Field: "BookIDs", Message: "at least one book ID is required"}
+	case len(cmd.BookIDs) > MaxBatchLoans:
+		return shared.ValidationError{Field: "BookIDs", Message: fmt.Sprintf("at most %d books per batch", MaxBatchLoans)}
+	}
+	return nil
+}
+
+// isItemError reports whether err is a business outcome for one book
+// rather than a failure of the batch as a whole
+func isItemError(err error) bool {
+	return errors.Is(err, catalog.ErrBookNotFound) ||
+		errors.Is(err, catalog.ErrBookAlreadyBorrowed) ||
+		errors.Is(err, catalog.ErrBookNotBorrowed) ||
+		errors.Is(err, catalog.ErrBookIDEmpty) ||
+		errors.Is(err, catalog.ErrBookIDInvalidFormat) ||
+		errors.Is(err, auth.ErrForbidden) ||
+		errors.Is(err, shared.ErrValidation)
+}
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/domain/catalog"
+	"library-system/internal/domain/shared"
+)
+
+// addBooks stores n available books and returns their IDs
+func addBooks(repo *MockBookRepository, n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		id := catalog.GenerateBookID()
+		title, _ := catalog.NewTitle("Book")
+		author, _ := catalog.NewAuthor("Author")
+		_ = repo.Add(context.Background(), catalog.NewBook(id, title, author))
+		ids[i] = id.String()
+	}
+	return ids
+}
+
+func newBatchHandler(repo *MockBookRepository, uow *MockUnitOfWork) *BatchLoansHandler {
+	return NewBatchLoansHandler(repo, uow, catalog.DefaultLoanPolicy(), auth.NewRolePolicy())
+}
+
+func TestBatchLoansHandler_BorrowsAllInOneTransaction(t *testing.T) {
+	repo := NewMockBookRepository()
+	ids := addBooks(repo, 3)
+	uow := &MockUnitOfWork{}
+
+	result, err := newBatchHandler(repo, uow).Handle(asPatron("john@example.com"), BatchLoansCommand{
+		Action: LoanActionBorrow, BookIDs: ids, Mode: BatchModeAllOrNothing,
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !result.Committed || result.Succeeded != 3 || uow.commits != 1 {
+		t.Errorf("expected 3 loans in one commit, got %+v after %d commits", result, uow.commits)
+	}
+	for i, item := range result.Items {
+		if item.BookID != ids[i] || item.ReturnDueDate == nil {
+			t.Errorf("expected item %d to be %s with a due date, got %+v", i, ids[i], item)
+		}
+	}
+}
+
+func TestBatchLoansHandler_AllOrNothingRollsBackOnFailure(t *testing.T) {
+	repo := NewMockBookRepository()
+	ids := addBooks(repo, 2)
+	taken, _ := catalog.ParseBookID(ids[1])
+	book, _ := repo.GetByID(context.Background(), taken)
+	_ = book.Borrow("someone@example.com", time.Now())
+	uow := &MockUnitOfWork{}
+
+	result, err := newBatchHandler(repo, uow).Handle(asPatron("john@example.com"), BatchLoansCommand{
+		Action: LoanActionBorrow, BookIDs: append(ids, "not-a-uuid"), Mode: BatchModeAllOrNothing,
+	})
+
+	if err != nil {
+		t.Fatalf("expected per-item failures, got %v", err)
+	}
+	if result.Committed || uow.rollbacks != 1 || uow.commits != 0 {
+		t.Errorf("expected batch to be rolled back, got %+v", result)
+	}
+	if result.Items[0].Error != "" || result.Items[1].Error != catalog.ErrBookAlreadyBorrowed.Error() || result.Items[2].Error == "" {
+		t.Errorf("expected items 2 and 3 to fail, got %+v", result.Items)
+	}
+}
+
+func TestBatchLoansHandler_BestEffortKeepsSuccesses(t *testing.T) {
+	repo := NewMockBookRepository()
+	ids := addBooks(repo, 1)
+	uow := &MockUnitOfWork{}
+
+	result, err := newBatchHandler(repo, uow).Handle(asPatron("john@example.com"), BatchLoansCommand{
+		Action: LoanActionBorrow, BookIDs: []string{ids[0], ids[0]}, Mode: BatchModeBestEffort,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Committed || result.Succeeded != 1 || result.Failed != 1 || uow.commits != 1 {
+		t.Errorf("expected one success committed and the duplicate rejected, got %+v", result)
+	}
+}
+
+func TestBatchLoansHandler_ReturnsAreAuthorizedPerLoan(t *testing.T) {
+	repo := NewMockBookRepository()
+	ids := addBooks(repo, 2)
+	for i, email := range []string{"john@example.com", "jane@example.com"} {
+		id, _ := catalog.ParseBookID(ids[i])
+		book, _ := repo.GetByID(context.Background(), id)
+		_ = book.Borrow(email, time.Now())
+	}
+
+	result, err := newBatchHandler(repo, &MockUnitOfWork{}).Handle(asPatron("john@example.com"), BatchLoansCommand{
+		Action: LoanActionReturn, BookIDs: ids, Mode: BatchModeBestEffort,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Succeeded != 1 || result.Items[1].Error == "" {
+		t.Errorf("expected only the patron's own loan to be returned, got %+v", result)
+	}
+}
+
+func TestBatchLoansHandler_Validation(t *testing.T) {
+	handler := newBatchHandler(NewMockBookRepository(), &MockUnitOfWork{})
+	tooMany := make([]string, MaxBatchLoans+1)
+
+	for name, cmd := range map[string]BatchLoansCommand{
+		"no books":     {Action: LoanActionBorrow, Mode: BatchModeBestEffort},
+		"too many":     {Action: LoanActionBorrow, BookIDs: tooMany, Mode: BatchModeBestEffort},
+		"bad action":   {Action: "renew", BookIDs: []string{"x"}, Mode: BatchModeBestEffort},
+		"missing mode": {Action: LoanActionReturn, BookIDs: []string{"x"}},
+	} {
+		if _, err := handler.Handle(asLibrarian(), cmd); !errors.Is(err, shared.ErrValidation) {
+			t.Errorf("%s: expected validation error, got %v", name, err)
+		}
+	}
+}
@@ -2,14 +2,23 @@ package commands
 
 import (
 	"context"
-	"library-system/internal/domain/catalog"
+	"log/slog"
+	"net/mail"
 	"time"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/ports"
+	"library-system/internal/domain/catalog"
+	"library-system/internal/domain/shared"
 )
 
-// BorrowBookCommand represents intent to borrow a book
+// BorrowBookCommand represents intent to borrow a book. The borrower is
+// the authenticated principal carried by the context unless OnBehalfOf
+// names someone else, which the authorization policy must allow (e.g. a
+// lending desk checking out for a patron).
 type BorrowBookCommand struct {
-	BookID        string
-	BorrowerEmail string
+	BookID     string
+	OnBehalfOf string
 }
 
 // BorrowBookResult is returned after borrowing a book.
@@ -22,46 +31,102 @@ type BorrowBookResult struct {
 
 // BorrowBookHandler handles the BorrowBookCommand
 type BorrowBookHandler struct {
-	repo catalog.BookRepository
+	repo   catalog.BookRepository
+	uow    ports.UnitOfWork
+	policy catalog.LoanPolicy
+	authz  auth.Authorizer
 }
 
 // NewBorrowBookHandler creates a new handler
-func NewBorrowBookHandler(repo catalog.BookRepository) *BorrowBookHandler {
-	return &BorrowBookHandler{repo: repo}
+func NewBorrowBookHandler(repo catalog.BookRepository, uow ports.UnitOfWork, policy catalog.LoanPolicy, authz auth.Authorizer) *BorrowBookHandler {
+	return &BorrowBookHandler{repo: repo, uow: uow, policy: policy, authz: authz}
 }
 
 // Handle executes the command
 func (h *BorrowBookHandler) Handle(ctx context.Context, cmd BorrowBookCommand) (BorrowBookResult, error) {
-	// Parse BookID
-	bookID, err := catalog.ParseBookID(cmd.BookID)
-	if err != nil {
+	if err := h.authz.Authorize(ctx, auth.ActionBorrowBook, cmd.OnBehalfOf); err != nil {
 		return BorrowBookResult{}, err
 	}
-
-	// Get book from repository
-	book, err := h.repo.GetByID(ctx, bookID)
+	principal, ok := auth.PrincipalFrom(ctx)
+	if !ok {
+		return BorrowBookResult{}, auth.ErrUnauthenticated
+	}
+	borrower, err := borrowerFor(principal, cmd.OnBehalfOf)
 	if err != nil {
 		return BorrowBookResult{}, err
 	}
-	if book == nil {
-		return BorrowBookResult{}, catalog.ErrBookNotFound
-	}
 
-	// Execute domain logic
-	borrowedAt := time.Now()
-	if err := book.Borrow(cmd.BorrowerEmail, borrowedAt); err != nil {
+	// Parse BookID
+	bookID, err := catalog.ParseBookID(cmd.BookID)
+	if err != nil {
 		return BorrowBookResult{}, err
 	}
 
-	// Persist changes
-	if err := h.repo.Update(ctx, book); err != nil {
+	// Load, borrow and persist atomically
+	var book *catalog.Book
+	borrowedAt := time.Now()
+	err = h.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		book, err = borrowBook(ctx, h.repo, h.policy, bookID, borrower, borrowedAt)
+		return err
+	})
+	if err != nil {
 		return BorrowBookResult{}, err
 	}
+	slog.InfoContext(ctx, "book borrowed",
+		"book_id", book.ID().String(),
+		"subject", principal.Subject,
+		"return_due_date", book.ReturnDueDate(),
+	)
 
 	return BorrowBookResult{
 		BookID:        book.ID().String(),
 		Title:         book.Title().String(),
 		BorrowedAt:    borrowedAt,
-		ReturnDueDate: borrowedAt.AddDate(0, 0, 14),
+		ReturnDueDate: *book.ReturnDueDate(),
 	}, nil
 }
+
+// borrowerFor picks who a loan is for: onBehalfOf if given, otherwise the
+// principal. API keys act for a service rather than a person and carry no
+// email, so their callers must always name the borrower. onBehalfOf is
+// checked here rather than by each transport, so HTTP, gRPC and GraphQL
+// callers can't record a loan for something that isn't an email address.
+func borrowerFor(principal auth.Principal, onBehalfOf string) (string, error) {
+	if onBehalfOf != "" {
+		if addr, err := mail.ParseAddress(onBehalfOf); err != nil || addr.Address != onBehalfOf {
+			return "", shared.ValidationError{
+				Field:   "OnBehalfOf",
+				Message: "on_behalf_of must be a plain email address, e.g. jane@example.com",
+			}
+		}
+		return onBehalfOf, nil
+	}
+	if principal.Email == "" {
+		return "", shared.ValidationError{
+			Field:   "OnBehalfOf",
+			Message: "on_behalf_of is required when borrowing with an API key",
+		}
+	}
+	return principal.Email, nil
+}
+
+// borrowBook loads a book, lends it to borrower and persists it. It must
+// run inside a unit of work so the book stays locked until committed.
+func borrowBook(ctx context.Context, repo catalog.BookRepository, policy catalog.LoanPolicy, id catalog.BookID, borrower string, at time.Time) (*catalog.Book, error) {
+	book, err := repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if book == nil {
+		return nil, catalog.ErrBookNotFound
+	}
+
+	// Execute domain logic
+	if err := book.BorrowWithPolicy(borrower, at, policy); err != nil {
+		return nil, err
+	}
+
+	// Persist changes
+	return book, repo.Update(ctx, book)
+}
@@ -2,10 +2,14 @@ package commands
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	"library-system/internal/application/auth"
 	"library-system/internal/domain/catalog"
+	"library-system/internal/domain/shared"
 )
 
 func TestBorrowBookHandler_Success(t *testing.T) {
@@ -18,12 +22,12 @@ func TestBorrowBookHandler_Success(t *testing.T) {
 	book := catalog.NewBook(id, title, author)
 	_ = repo.Add(context.Background(), book)
 
-	handler := NewBorrowBookHandler(repo)
-	ctx := context.Background()
+	uow := &MockUnitOfWork{}
+	handler := NewBorrowBookHandler(repo, uow, catalog.DefaultLoanPolicy(), auth.NewRolePolicy())
+	ctx := asPatron("john@example.com")
 
 	result, err := handler.Handle(ctx, BorrowBookCommand{
-		BookID:        id.String(),
-		BorrowerEmail: "john@example.com",
+		BookID: id.String(),
 	})
 
 	if err != nil {
@@ -35,19 +39,24 @@ func TestBorrowBookHandler_Success(t *testing.T) {
 	if result.BorrowedAt.IsZero() {
 		t.Error("expected BorrowedAt to be set")
 	}
+	if updated, _ := repo.GetByID(ctx, id); updated.BorrowerEmail() != "john@example.com" {
+		t.Errorf("expected loan to be recorded for the caller, got %q", updated.BorrowerEmail())
+	}
 	if result.ReturnDueDate.Before(time.Now()) {
 		t.Error("expected ReturnDueDate to be in the future")
 	}
+	if uow.commits != 1 {
+		t.Errorf("expected 1 commit, got %d", uow.commits)
+	}
 }
 
 func TestBorrowBookHandler_BookNotFound(t *testing.T) {
 	repo := NewMockBookRepository()
-	handler := NewBorrowBookHandler(repo)
-	ctx := context.Background()
+	handler := NewBorrowBookHandler(repo, &MockUnitOfWork{}, catalog.DefaultLoanPolicy(), auth.NewRolePolicy())
+	ctx := asPatron("john@example.com")
 
 	_, err := handler.Handle(ctx, BorrowBookCommand{
-		BookID:        "550e8400-e29b-41d4-a716-446655440000",
-		BorrowerEmail: "john@example.com",
+		BookID: "550e8400-e29b-41d4-a716-446655440000",
 	})
 
 	if err != catalog.ErrBookNotFound {
@@ -57,12 +66,11 @@ func TestBorrowBookHandler_BookNotFound(t *testing.T) {
 
 func TestBorrowBookHandler_InvalidBookID(t *testing.T) {
 	repo := NewMockBookRepository()
-	handler := NewBorrowBookHandler(repo)
-	ctx := context.Background()
+	handler := NewBorrowBookHandler(repo, &MockUnitOfWork{}, catalog.DefaultLoanPolicy(), auth.NewRolePolicy())
+	ctx := asPatron("john@example.com")
 
 	_, err := handler.Handle(ctx, BorrowBookCommand{
-		BookID:        "invalid-id",
-		BorrowerEmail: "john@example.com",
+		BookID: "invalid-id",
 	})
 
 	if err != catalog.ErrBookIDInvalidFormat {
@@ -81,15 +89,132 @@ func TestBorrowBookHandler_AlreadyBorrowed(t *testing.T) {
 	_ = book.Borrow("first@example.com", time.Now())
 	_ = repo.Add(context.Background(), book)
 
-	handler := NewBorrowBookHandler(repo)
-	ctx := context.Background()
+	uow := &MockUnitOfWork{}
+	handler := NewBorrowBookHandler(repo, uow, catalog.DefaultLoanPolicy(), auth.NewRolePolicy())
+	ctx := asPatron("second@example.com")
 
 	_, err := handler.Handle(ctx, BorrowBookCommand{
-		BookID:        id.String(),
-		BorrowerEmail: "second@example.com",
+		BookID: id.String(),
 	})
 
 	if err != catalog.ErrBookAlreadyBorrowed {
 		t.Errorf("expected ErrBookAlreadyBorrowed, got %v", err)
 	}
+	if uow.rollbacks != 1 || uow.commits != 0 {
+		t.Errorf("expected work to be rolled back, got %d commits and %d rollbacks", uow.commits, uow.rollbacks)
+	}
+}
+
+func TestBorrowBookHandler_RequiresPrincipal(t *testing.T) {
+	repo := NewMockBookRepository()
+	id := catalog.GenerateBookID()
+	title, _ := catalog.NewTitle("Clean Code")
+	author, _ := catalog.NewAuthor("Robert Martin")
+	_ = repo.Add(context.Background(), catalog.NewBook(id, title, author))
+
+	uow := &MockUnitOfWork{}
+	handler := NewBorrowBookHandler(repo, uow, catalog.DefaultLoanPolicy(), auth.NewRolePolicy())
+
+	_, err := handler.Handle(context.Background(), BorrowBookCommand{
+		BookID: id.String(),
+	})
+
+	if err != auth.ErrUnauthenticated {
+		t.Errorf("expected ErrUnauthenticated, got %v", err)
+	}
+	if uow.commits != 0 {
+		t.Error("expected no work to be committed")
+	}
+}
+
+func TestBorrowBookHandler_LendingDeskBorrowsOnBehalf(t *testing.T) {
+	repo := NewMockBookRepository()
+	id := catalog.GenerateBookID()
+	title, _ := catalog.NewTitle("Clean Code")
+	author, _ := catalog.NewAuthor("Robert Martin")
+	_ = repo.Add(context.Background(), catalog.NewBook(id, title, author))
+
+	handler := NewBorrowBookHandler(repo, &MockUnitOfWork{}, catalog.DefaultLoanPolicy(), auth.NewRolePolicy())
+	ctx := auth.WithPrincipal(context.Background(), auth.Principal{
+		Subject: "apikey:kiosk-1", Roles: []string{auth.RoleLendingDesk},
+	})
+
+	_, err := handler.Handle(ctx, BorrowBookCommand{
+		BookID:     id.String(),
+		OnBehalfOf: "jane@example.com",
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if book, _ := repo.GetByID(ctx, id); book.BorrowerEmail() != "jane@example.com" {
+		t.Errorf("expected loan for jane@example.com, got %q", book.BorrowerEmail())
+	}
+}
+
+func TestBorrowBookHandler_PatronCannotBorrowOnBehalf(t *testing.T) {
+	repo := NewMockBookRepository()
+	handler := NewBorrowBookHandler(repo, &MockUnitOfWork{}, catalog.DefaultLoanPolicy(), auth.NewRolePolicy())
+
+	_, err := handler.Handle(asPatron("john@example.com"), BorrowBookCommand{
+		BookID:     "550e8400-e29b-41d4-a716-446655440000",
+		OnBehalfOf: "jane@example.com",
+	})
+
+	if !errors.Is(err, auth.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestBorrowBookHandler_APIKeyMustNameBorrower(t *testing.T) {
+	repo := NewMockBookRepository()
+	id := catalog.GenerateBookID()
+	title, _ := catalog.NewTitle("Clean Code")
+	author, _ := catalog.NewAuthor("Robert Martin")
+	_ = repo.Add(context.Background(), catalog.NewBook(id, title, author))
+
+	uow := &MockUnitOfWork{}
+	handler := NewBorrowBookHandler(repo, uow, catalog.DefaultLoanPolicy(), auth.NewRolePolicy())
+	ctx := auth.WithPrincipal(context.Background(), auth.Principal{
+		Subject: "apikey:kiosk-1", Roles: []string{auth.RoleLendingDesk},
+	})
+
+	_, err := handler.Handle(ctx, BorrowBookCommand{BookID: id.String()})
+
+	var validation shared.ValidationError
+	if !errors.As(err, &validation) || validation.Field != "OnBehalfOf" {
+		t.Fatalf("expected an OnBehalfOf validation error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "on_behalf_of") {
+		t.Errorf("expected the error to name on_behalf_of, got %q", err)
+	}
+	if uow.commits != 0 {
+		t.Error("expected nothing to be committed")
+	}
+}
+
+func TestBorrowBookHandler_RejectsMalformedOnBehalfOf(t *testing.T) {
+	repo := NewMockBookRepository()
+	id := catalog.GenerateBookID()
+	title, _ := catalog.NewTitle("Clean Code")
+	author, _ := catalog.NewAuthor("Robert Martin")
+	_ = repo.Add(context.Background(), catalog.NewBook(id, title, author))
+
+	uow := &MockUnitOfWork{}
+	handler := NewBorrowBookHandler(repo, uow, catalog.DefaultLoanPolicy(), auth.NewRolePolicy())
+	ctx := auth.WithPrincipal(context.Background(), auth.Principal{
+		Subject: "apikey:kiosk-1", Roles: []string{auth.RoleLendingDesk},
+	})
+
+	for _, onBehalfOf := range []string{"jane", "Jane <jane@example.com>", "jane@example.com, joe@example.com"} {
+		_, err := handler.Handle(ctx, BorrowBookCommand{BookID: id.String(), OnBehalfOf: onBehalfOf})
+
+		var validation shared.ValidationError
+		if !errors.As(err, &validation) || validation.Field != "OnBehalfOf" {
+			t.Errorf("%q: expected an OnBehalfOf validation error, got %v", onBehalfOf, err)
+		}
+	}
+	if uow.commits != 0 {
+		t.Error("expected nothing to be committed")
+	}
 }
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/ports"
+	"library-system/internal/domain/webhooks"
+)
+
+// CreateWebhookCommand represents intent to have catalog events POSTed to
+// a partner's endpoint
+type CreateWebhookCommand struct {
+	URL         string
+	Events      []string
+	Description string
+}
+
+// CreateWebhookResult is returned after creating a webhook. Secret signs
+// every delivery and is only returned here.
+type CreateWebhookResult struct {
+	ID          string
+	URL         string
+	Events      []string
+	Description string
+	Secret      string
+	CreatedAt   time.Time
+}
+
+// CreateWebhookHandler handles the CreateWebhookCommand
+type CreateWebhookHandler struct {
+	repo  webhooks.SubscriptionRepository
+	uow   ports.UnitOfWork
+	authz auth.Authorizer
+}
+
+// NewCreateWebhookHandler creates a new handler
+func NewCreateWebhookHandler(repo webhooks.SubscriptionRepository, uow ports.UnitOfWork, authz auth.Authorizer) *CreateWebhookHandler {
+	return &CreateWebhookHandler{repo: repo, uow: uow, authz: authz}
+}
+
+// Handle executes the command. Only events committed after this point are
+// delivered.
+func (h *CreateWebhookHandler) Handle(ctx context.Context, cmd CreateWebhookCommand) (CreateWebhookResult, error) {
+	if err := h.authz.Authorize(ctx, auth.ActionManageWebhooks, ""); err != nil {
+		return CreateWebhookResult{}, err
+	}
+
+	sub, err := webhooks.NewSubscription(cmd.URL, cmd.Events, cmd.Description, time.Now())
+	if err != nil {
+		return CreateWebhookResult{}, err
+	}
+	if err := h.uow.Do(ctx, func(ctx context.Context) error {
+		return h.repo.Add(ctx, sub)
+	}); err != nil {
+		return CreateWebhookResult{}, err
+	}
+	slog.InfoContext(ctx, "webhook created", "webhook_id", sub.ID().String(), "events", sub.Events())
+
+	return CreateWebhookResult{
+		ID:          sub.ID().String(),
+		URL:         sub.URL(),
+		Events:      sub.Events(),
+		Description: sub.Description(),
+		Secret:      sub.Secret(),
+		CreatedAt:   sub.CreatedAt(),
+	}, nil
+}
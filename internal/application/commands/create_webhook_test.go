@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/domain/webhooks"
+)
+
+// MockSubscriptionRepository is a test double for webhooks.SubscriptionRepository
+type MockSubscriptionRepository struct {
+	subs map[string]*webhooks.Subscription
+}
+
+func NewMockSubscriptionRepository() *MockSubscriptionRepository {
+	return &MockSubscriptionRepository{subs: make(map[string]*webhooks.Subscription)}
+}
+
+func (m *MockSubscriptionRepository) Add(ctx context.Context, sub *webhooks.Subscription) error {
+	m.subs[sub.ID().String()] = sub
+	return nil
+}
+
+func (m *MockSubscriptionRepository) GetByID(ctx context.Context, id webhooks.SubscriptionID) (*webhooks.Subscription, error) {
+	return m.subs[id.String()], nil
+}
+
+func (m *MockSubscriptionRepository) List(ctx context.Context) ([]*webhooks.Subscription, error) {
+	subs := make([]*webhooks.Subscription, 0, len(m.subs))
+	for _, sub := range m.subs {
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
+
+func (m *MockSubscriptionRepository) Remove(ctx context.Context, id webhooks.SubscriptionID) error {
+	delete(m.subs, id.String())
+	return nil
+}
+
+func TestCreateWebhookHandler_ReturnsSecretOnce(t *testing.T) {
+	repo := NewMockSubscriptionRepository()
+	handler := NewCreateWebhookHandler(repo, &MockUnitOfWork{}, auth.NewRolePolicy())
+
+	result, err := handler.Handle(asAdmin(), CreateWebhookCommand{
+		URL:    "http://localhost:9999/hooks",
+		Events: []string{"catalog.book_borrowed", "catalog.book_returned"},
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Secret == "" || len(repo.subs) != 1 {
+		t.Errorf("expected a stored webhook with a secret, got %+v", result)
+	}
+}
+
+func TestCreateWebhookHandler_RequiresAdmin(t *testing.T) {
+	handler := NewCreateWebhookHandler(NewMockSubscriptionRepository(), &MockUnitOfWork{}, auth.NewRolePolicy())
+
+	_, err := handler.Handle(asLibrarian(), CreateWebhookCommand{
+		URL:    "https://partner.example/hooks",
+		Events: []string{"catalog.book_borrowed"},
+	})
+
+	if !errors.Is(err, auth.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"context"
+	"log/slog"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/ports"
+	"library-system/internal/domain/webhooks"
+)
+
+// DeleteWebhookCommand represents intent to stop a webhook. Its pending
+// deliveries and their logs go with it.
+type DeleteWebhookCommand struct {
+	WebhookID string
+}
+
+// DeleteWebhookResult is returned after deleting a webhook
+type DeleteWebhookResult struct {
+	ID string
+}
+
+// DeleteWebhookHandler handles the DeleteWebhookCommand
+type DeleteWebhookHandler struct {
+	repo  webhooks.SubscriptionRepository
+	uow   ports.UnitOfWork
+	authz auth.Authorizer
+}
+
+// NewDeleteWebhookHandler creates a new handler
+func NewDeleteWebhookHandler(repo webhooks.SubscriptionRepository, uow ports.UnitOfWork, authz auth.Authorizer) *DeleteWebhookHandler {
+	return &DeleteWebhookHandler{repo: repo, uow: uow, authz: authz}
+}
+
+// Handle executes the command
+func (h *DeleteWebhookHandler) Handle(ctx context.Context, cmd DeleteWebhookCommand) (DeleteWebhookResult, error) {
+	if err := h.authz.Authorize(ctx, auth.ActionManageWebhooks, ""); err != nil {
+		return DeleteWebhookResult{}, err
+	}
+
+	id, err := webhooks.ParseSubscriptionID(cmd.WebhookID)
+	if err != nil {
+		return DeleteWebhookResult{}, err
+	}
+	err = h.uow.Do(ctx, func(ctx context.Context) error {
+		sub, err := h.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if sub == nil {
+			return webhooks.ErrSubscriptionNotFound
+		}
+		return h.repo.Remove(ctx, id)
+	})
+	if err != nil {
+		return DeleteWebhookResult{}, err
+	}
+	slog.InfoContext(ctx, "webhook deleted", "webhook_id", id.String())
+
+	return DeleteWebhookResult{ID: id.String()}, nil
+}
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"context"
+	"io"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/imports"
+)
+
+// ImportBooksCommand represents intent to add many books from an upload
+type ImportBooksCommand struct {
+	Format imports.Format
+	Source io.Reader
+}
+
+// ImportBooksResult is returned after importing books
+type ImportBooksResult struct {
+	Report imports.Report
+}
+
+// ImportBooksHandler handles the ImportBooksCommand, reading the whole
+// upload before returning. A failed import still returns its report, as
+// the batches stored before the failure are kept.
+type ImportBooksHandler struct {
+	importer *imports.Importer
+	authz    auth.Authorizer
+}
+
+// NewImportBooksHandler creates a new handler
+func NewImportBooksHandler(importer *imports.Importer, authz auth.Authorizer) *ImportBooksHandler {
+	return &ImportBooksHandler{importer: importer, authz: authz}
+}
+
+// Handle executes the command
+func (h *ImportBooksHandler) Handle(ctx context.Context, cmd ImportBooksCommand) (ImportBooksResult, error) {
+	if err := h.authz.Authorize(ctx, auth.ActionAddBook, ""); err != nil {
+		return ImportBooksResult{}, err
+	}
+
+	report, err := h.importer.Import(ctx, cmd.Format, cmd.Source, nil)
+	return ImportBooksResult{Report: report}, err
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/ports"
+	"library-system/internal/domain/access"
+	"library-system/internal/domain/shared"
+)
+
+// IssueAPIKeyCommand represents intent to issue an API key
+type IssueAPIKeyCommand struct {
+	Name      string
+	Scopes    []string
+	ExpiresIn time.Duration // Zero means the key doesn't expire
+}
+
+// IssueAPIKeyResult is returned after issuing a key. Key is the plaintext
+// token; it is not stored and cannot be retrieved again.
+type IssueAPIKeyResult struct {
+	ID        string
+	Name      string
+	Key       string
+	Scopes    []access.Scope
+	ExpiresAt *time.Time
+}
+
+// IssueAPIKeyHandler handles the IssueAPIKeyCommand
+type IssueAPIKeyHandler struct {
+	repo  access.APIKeyRepository
+	uow   ports.UnitOfWork
+	authz auth.Authorizer
+}
+
+// NewIssueAPIKeyHandler creates a new handler
+func NewIssueAPIKeyHandler(repo access.APIKeyRepository, uow ports.UnitOfWork, authz auth.Authorizer) *IssueAPIKeyHandler {
+	return &IssueAPIKeyHandler{repo: repo, uow: uow, authz: authz}
+}
+
+// Handle executes the command
+func (h *IssueAPIKeyHandler) Handle(ctx context.Context, cmd IssueAPIKeyCommand) (IssueAPIKeyResult, error) {
+	if err := h.authz.Authorize(ctx, auth.ActionManageAPIKeys, ""); err != nil {
+		return IssueAPIKeyResult{}, err
+	}
+
+	scopes := make([]access.Scope, 0, len(cmd.Scopes))
+	for _, s := range cmd.Scopes {
+		scope, err := access.ParseScope(s)
+		if err != nil {
+			return IssueAPIKeyResult{}, err
+		}
+		scopes = append(scopes, scope)
+	}
+	if cmd.ExpiresIn < 0 {
+		return IssueAPIKeyResult{}, shared.ValidationError{Field: "ExpiresIn", Message: "expires_in cannot be negative"}
+	}
+
+	now := time.Now()
+	var expiresAt *time.Time
+	if cmd.ExpiresIn > 0 {
+		t := now.Add(cmd.ExpiresIn)
+		expiresAt = &t
+	}
+
+	key, token, err := access.IssueAPIKey(cmd.Name, scopes, now, expiresAt)
+	if err != nil {
+		return IssueAPIKeyResult{}, err
+	}
+	if err := h.uow.Do(ctx, func(ctx context.Context) error {
+		return h.repo.Add(ctx, key)
+	}); err != nil {
+		return IssueAPIKeyResult{}, err
+	}
+	slog.InfoContext(ctx, "API key issued", "key_id", key.ID().String(), "name", key.Name(), "scopes", key.Scopes())
+
+	return IssueAPIKeyResult{
+		ID:        key.ID().String(),
+		Name:      key.Name(),
+		Key:       token,
+		Scopes:    key.Scopes(),
+		ExpiresAt: key.ExpiresAt(),
+	}, nil
+}
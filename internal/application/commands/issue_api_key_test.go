@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/domain/access"
+	"library-system/internal/domain/shared"
+)
+
+// MockAPIKeyRepository is a test double for access.APIKeyRepository
+type MockAPIKeyRepository struct {
+	keys map[string]*access.APIKey
+}
+
+func NewMockAPIKeyRepository() *MockAPIKeyRepository {
+	return &MockAPIKeyRepository{keys: make(map[string]*access.APIKey)}
+}
+
+func (m *MockAPIKeyRepository) Add(ctx context.Context, key *access.APIKey) error {
+	m.keys[key.ID().String()] = key
+	return nil
+}
+
+func (m *MockAPIKeyRepository) GetByID(ctx context.Context, id access.APIKeyID) (*access.APIKey, error) {
+	return m.keys[id.String()], nil
+}
+
+func (m *MockAPIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*access.APIKey, error) {
+	for _, key := range m.keys {
+		if key.Prefix() == prefix {
+			return key, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockAPIKeyRepository) List(ctx context.Context) ([]*access.APIKey, error) {
+	keys := make([]*access.APIKey, 0, len(m.keys))
+	for _, key := range m.keys {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (m *MockAPIKeyRepository) Update(ctx context.Context, key *access.APIKey) error {
+	m.keys[key.ID().String()] = key
+	return nil
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id access.APIKeyID, at time.Time) error {
+	return nil
+}
+
+// asAdmin returns a context authenticated as an administrator
+func asAdmin() context.Context {
+	return auth.WithPrincipal(context.Background(), auth.Principal{
+		Subject: "admin-1", Email: "admin@example.com", Roles: []string{auth.RoleAdmin},
+	})
+}
+
+func TestIssueAPIKeyHandler_Success(t *testing.T) {
+	repo := NewMockAPIKeyRepository()
+	uow := &MockUnitOfWork{}
+	handler := NewIssueAPIKeyHandler(repo, uow, auth.NewRolePolicy())
+
+	result, err := handler.Handle(asAdmin(), IssueAPIKeyCommand{
+		Name:      "kiosk-1",
+		Scopes:    []string{"catalog:read", "lending"},
+		ExpiresIn: 90 * 24 * time.Hour,
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Key == "" || result.ExpiresAt == nil {
+		t.Errorf("expected a key with an expiry, got %+v", result)
+	}
+	stored := repo.keys[result.ID]
+	if stored == nil {
+		t.Fatal("expected key to be stored")
+	}
+	_, secret, _ := access.ParseToken(result.Key)
+	if !stored.Matches(secret) {
+		t.Error("expected stored hash to match the issued key")
+	}
+	if uow.commits != 1 {
+		t.Errorf("expected 1 commit, got %d", uow.commits)
+	}
+}
+
+func TestIssueAPIKeyHandler_UnknownScope(t *testing.T) {
+	handler := NewIssueAPIKeyHandler(NewMockAPIKeyRepository(), &MockUnitOfWork{}, auth.NewRolePolicy())
+
+	_, err := handler.Handle(asAdmin(), IssueAPIKeyCommand{
+		Name:   "kiosk-1",
+		Scopes: []string{"superuser"},
+	})
+
+	if !errors.Is(err, shared.ErrValidation) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+func TestIssueAPIKeyHandler_LibrarianForbidden(t *testing.T) {
+	repo := NewMockAPIKeyRepository()
+	handler := NewIssueAPIKeyHandler(repo, &MockUnitOfWork{}, auth.NewRolePolicy())
+
+	_, err := handler.Handle(asLibrarian(), IssueAPIKeyCommand{
+		Name:   "kiosk-1",
+		Scopes: []string{"admin"},
+	})
+
+	if !errors.Is(err, auth.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+	if len(repo.keys) != 0 {
+		t.Error("expected no key to be stored")
+	}
+}
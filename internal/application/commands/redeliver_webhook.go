@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/ports"
+	"library-system/internal/domain/webhooks"
+)
+
+// RedeliverWebhookCommand represents intent to retry a dead delivery,
+// e.g. once the receiver has been fixed
+type RedeliverWebhookCommand struct {
+	WebhookID  string
+	DeliveryID int64
+}
+
+// RedeliverWebhookResult is returned once the delivery is queued again
+type RedeliverWebhookResult struct {
+	ID            int64
+	Status        webhooks.DeliveryStatus
+	NextAttemptAt time.Time
+}
+
+// RedeliverWebhookHandler handles the RedeliverWebhookCommand
+type RedeliverWebhookHandler struct {
+	repo  webhooks.DeliveryRepository
+	uow   ports.UnitOfWork
+	authz auth.Authorizer
+}
+
+// NewRedeliverWebhookHandler creates a new handler
+func NewRedeliverWebhookHandler(repo webhooks.DeliveryRepository, uow ports.UnitOfWork, authz auth.Authorizer) *RedeliverWebhookHandler {
+	return &RedeliverWebhookHandler{repo: repo, uow: uow, authz: authz}
+}
+
+// Handle executes the command
+func (h *RedeliverWebhookHandler) Handle(ctx context.Context, cmd RedeliverWebhookCommand) (RedeliverWebhookResult, error) {
+	if err := h.authz.Authorize(ctx, auth.ActionManageWebhooks, ""); err != nil {
+		return RedeliverWebhookResult{}, err
+	}
+	webhookID, err := webhooks.ParseSubscriptionID(cmd.WebhookID)
+	if err != nil {
+		return RedeliverWebhookResult{}, err
+	}
+
+	var delivery *webhooks.Delivery
+	err = h.uow.Do(ctx, func(ctx context.Context) error {
+		delivery, err = h.repo.GetByID(ctx, cmd.DeliveryID)
+		if err != nil {
+			return err
+		}
+		if delivery == nil || delivery.SubscriptionID() != webhookID {
+			return webhooks.ErrDeliveryNotFound
+		}
+		if err := delivery.Redeliver(time.Now()); err != nil {
+			return err
+		}
+		return h.repo.Update(ctx, delivery)
+	})
+	if err != nil {
+		return RedeliverWebhookResult{}, err
+	}
+	slog.InfoContext(ctx, "webhook delivery requeued", "webhook_id", webhookID.String(), "delivery_id", delivery.ID())
+
+	return RedeliverWebhookResult{
+		ID:            delivery.ID(),
+		Status:        delivery.Status(),
+		NextAttemptAt: delivery.NextAttemptAt(),
+	}, nil
+}
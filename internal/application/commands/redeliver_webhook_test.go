@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/domain/webhooks"
+)
+
+// MockDeliveryRepository is a test double for webhooks.DeliveryRepository
+type MockDeliveryRepository struct {
+	deliveries map[int64]*webhooks.Delivery
+}
+
+func (m *MockDeliveryRepository) GetByID(ctx context.Context, id int64) (*webhooks.Delivery, error) {
+	return m.deliveries[id], nil
+}
+
+func (m *MockDeliveryRepository) ListBySubscription(ctx context.Context, id webhooks.SubscriptionID, status webhooks.DeliveryStatus, limit int) ([]*webhooks.Delivery, error) {
+	return nil, nil
+}
+
+func (m *MockDeliveryRepository) Attempts(ctx context.Context, ids []int64) (map[int64][]webhooks.Attempt, error) {
+	return nil, nil
+}
+
+func (m *MockDeliveryRepository) Update(ctx context.Context, d *webhooks.Delivery) error {
+	m.deliveries[d.ID()] = d
+	return nil
+}
+
+func TestRedeliverWebhookHandler(t *testing.T) {
+	subID, _ := webhooks.ParseSubscriptionID("0b4f3c1e-0000-4000-8000-000000000000")
+	otherID, _ := webhooks.ParseSubscriptionID("0b4f3c1e-0000-4000-8000-000000000001")
+	now := time.Now()
+	repo := &MockDeliveryRepository{deliveries: map[int64]*webhooks.Delivery{
+		1: webhooks.ReconstructDelivery(1, subID, 10, "catalog.book_borrowed", webhooks.DeliveryDead, 8, now, "503", now, nil),
+		2: webhooks.ReconstructDelivery(2, subID, 11, "catalog.book_returned", webhooks.DeliverySucceeded, 1, now, "", now, &now),
+	}}
+	handler := NewRedeliverWebhookHandler(repo, &MockUnitOfWork{}, auth.NewRolePolicy())
+
+	result, err := handler.Handle(asAdmin(), RedeliverWebhookCommand{WebhookID: subID.String(), DeliveryID: 1})
+	if err != nil || result.Status != webhooks.DeliveryPending {
+		t.Errorf("expected dead delivery to be requeued, got %+v, %v", result, err)
+	}
+
+	_, err = handler.Handle(asAdmin(), RedeliverWebhookCommand{WebhookID: subID.String(), DeliveryID: 2})
+	if !errors.Is(err, webhooks.ErrDeliveryNotDead) {
+		t.Errorf("expected ErrDeliveryNotDead, got %v", err)
+	}
+	_, err = handler.Handle(asAdmin(), RedeliverWebhookCommand{WebhookID: otherID.String(), DeliveryID: 1})
+	if !errors.Is(err, webhooks.ErrDeliveryNotFound) {
+		t.Errorf("expected another webhook's delivery to be not found, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"context"
+	"log/slog"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/ports"
+	"library-system/internal/domain/catalog"
+)
+
+// RemoveBookCommand represents intent to withdraw a book from the catalog
+type RemoveBookCommand struct {
+	BookID string
+}
+
+// RemoveBookResult is returned after removing a book
+type RemoveBookResult struct {
+	BookID string
+}
+
+// RemoveBookHandler handles the RemoveBookCommand
+type RemoveBookHandler struct {
+	repo  catalog.BookRepository
+	uow   ports.UnitOfWork
+	authz auth.Authorizer
+}
+
+// NewRemoveBookHandler creates a new handler
+func NewRemoveBookHandler(repo catalog.BookRepository, uow ports.UnitOfWork, authz auth.Authorizer) *RemoveBookHandler {
+	return &RemoveBookHandler{repo: repo, uow: uow, authz: authz}
+}
+
+// Handle executes the command. A book on loan must be returned first.
+func (h *RemoveBookHandler) Handle(ctx context.Context, cmd RemoveBookCommand) (RemoveBookResult, error) {
+	if err := h.authz.Authorize(ctx, auth.ActionRemoveBook, ""); err != nil {
+		return RemoveBookResult{}, err
+	}
+
+	bookID, err := catalog.ParseBookID(cmd.BookID)
+	if err != nil {
+		return RemoveBookResult{}, err
+	}
+
+	err = h.uow.Do(ctx, func(ctx context.Context) error {
+		book, err := h.repo.GetByID(ctx, bookID)
+		if err != nil {
+			return err
+		}
+		if book == nil {
+			return catalog.ErrBookNotFound
+		}
+		if book.IsBorrowed() {
+			return catalog.ErrBookOnLoan
+		}
+
+		return h.repo.Remove(ctx, bookID)
+	})
+	if err != nil {
+		return RemoveBookResult{}, err
+	}
+	slog.InfoContext(ctx, "book removed", "book_id", bookID.String())
+
+	return RemoveBookResult{BookID: bookID.String()}, nil
+}
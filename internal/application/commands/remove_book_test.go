@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/domain/catalog"
+)
+
+func TestRemoveBookHandler_Success(t *testing.T) {
+	repo := NewMockBookRepository()
+	id := catalog.GenerateBookID()
+	title, _ := catalog.NewTitle("Clean Code")
+	author, _ := catalog.NewAuthor("Robert Martin")
+	_ = repo.Add(context.Background(), catalog.NewBook(id, title, author))
+
+	uow := &MockUnitOfWork{}
+	handler := NewRemoveBookHandler(repo, uow, auth.NewRolePolicy())
+
+	_, err := handler.Handle(asLibrarian(), RemoveBookCommand{
+		BookID: id.String(),
+	})
+
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if len(repo.books) != 0 {
+		t.Errorf("expected book to be removed, got %d books", len(repo.books))
+	}
+	if uow.commits != 1 {
+		t.Errorf("expected 1 commit, got %d", uow.commits)
+	}
+}
+
+func TestRemoveBookHandler_OnLoan(t *testing.T) {
+	repo := NewMockBookRepository()
+	id := catalog.GenerateBookID()
+	title, _ := catalog.NewTitle("Clean Code")
+	author, _ := catalog.NewAuthor("Robert Martin")
+	book := catalog.NewBook(id, title, author)
+	_ = book.Borrow("john@example.com", time.Now())
+	_ = repo.Add(context.Background(), book)
+
+	handler := NewRemoveBookHandler(repo, &MockUnitOfWork{}, auth.NewRolePolicy())
+
+	_, err := handler.Handle(asLibrarian(), RemoveBookCommand{
+		BookID: id.String(),
+	})
+
+	if err != catalog.ErrBookOnLoan {
+		t.Errorf("expected ErrBookOnLoan, got %v", err)
+	}
+	if len(repo.books) != 1 {
+		t.Error("expected book to be kept")
+	}
+}
+
+func TestRemoveBookHandler_PatronForbidden(t *testing.T) {
+	repo := NewMockBookRepository()
+	handler := NewRemoveBookHandler(repo, &MockUnitOfWork{}, auth.NewRolePolicy())
+
+	_, err := handler.Handle(asPatron("john@example.com"), RemoveBookCommand{
+		BookID: "550e8400-e29b-41d4-a716-446655440000",
+	})
+
+	if !errors.Is(err, auth.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
@@ -2,11 +2,15 @@ package commands
 
 import (
 	"context"
+	"log/slog"
 
+	"library-system/internal/application/auth"
+	"library-system/internal/application/ports"
 	"library-system/internal/domain/catalog"
 )
 
-// ReturnBookCommand represents intent to return a book
+// ReturnBookCommand represents intent to return a book. Patrons may return
+// their own loans; returning on someone else's behalf needs a librarian.
 type ReturnBookCommand struct {
 	BookID string
 }
@@ -19,12 +23,14 @@ type ReturnBookResult struct {
 
 // ReturnBookHandler handles the ReturnBookCommand
 type ReturnBookHandler struct {
-	repo catalog.BookRepository
+	repo  catalog.BookRepository
+	uow   ports.UnitOfWork
+	authz auth.Authorizer
 }
 
 // NewReturnBookHandler creates a new handler
-func NewReturnBookHandler(repo catalog.BookRepository) *ReturnBookHandler {
-	return &ReturnBookHandler{repo: repo}
+func NewReturnBookHandler(repo catalog.BookRepository, uow ports.UnitOfWork, authz auth.Authorizer) *ReturnBookHandler {
+	return &ReturnBookHandler{repo: repo, uow: uow, authz: authz}
 }
 
 // Handle executes the command
@@ -34,24 +40,47 @@ func (h *ReturnBookHandler) Handle(ctx context.Context, cmd ReturnBookCommand) (
 		return ReturnBookResult{}, err
 	}
 
-	book, err := h.repo.GetByID(ctx, bookID)
+	var book *catalog.Book
+	err = h.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		book, err = returnBook(ctx, h.repo, h.authz, bookID)
+		return err
+	})
 	if err != nil {
 		return ReturnBookResult{}, err
 	}
+	slog.InfoContext(ctx, "book returned", "book_id", book.ID().String())
+
+	return ReturnBookResult{
+		BookID: book.ID().String(),
+		Title:  book.Title().String(),
+	}, nil
+}
+
+// returnBook loads a book, checks the caller may return its loan, ends
+// the loan and persists it. It must run inside a unit of work so the book
+// stays locked until committed.
+func returnBook(ctx context.Context, repo catalog.BookRepository, authz auth.Authorizer, id catalog.BookID) (*catalog.Book, error) {
+	book, err := repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
 	if book == nil {
-		return ReturnBookResult{}, catalog.ErrBookNotFound
+		return nil, catalog.ErrBookNotFound
 	}
 
-	if err := book.Return(); err != nil {
-		return ReturnBookResult{}, err
+	// A book not on loan has no borrower to authorize against, and saying
+	// so reveals nothing GET /books/{id} doesn't
+	if !book.IsBorrowed() {
+		return nil, catalog.ErrBookNotBorrowed
 	}
-
-	if err := h.repo.Update(ctx, book); err != nil {
-		return ReturnBookResult{}, err
+	// The owner is only known once the loan is loaded
+	if err := authz.Authorize(ctx, auth.ActionReturnBook, book.BorrowerEmail()); err != nil {
+		return nil, err
+	}
+	if err := book.Return(); err != nil {
+		return nil, err
 	}
 
-	return ReturnBookResult{
-		BookID: book.ID().String(),
-		Title:  book.Title().String(),
-	}, nil
+	return book, repo.Update(ctx, book)
 }
@@ -2,9 +2,11 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"library-system/internal/application/auth"
 	"library-system/internal/domain/catalog"
 )
 
@@ -19,8 +21,9 @@ func TestReturnBookHandler_Success(t *testing.T) {
 	_ = book.Borrow("john@example.com", time.Now())
 	_ = repo.Add(context.Background(), book)
 
-	handler := NewReturnBookHandler(repo)
-	ctx := context.Background()
+	uow := &MockUnitOfWork{}
+	handler := NewReturnBookHandler(repo, uow, auth.NewRolePolicy())
+	ctx := asPatron("john@example.com")
 
 	result, err := handler.Handle(ctx, ReturnBookCommand{
 		BookID: id.String(),
@@ -38,12 +41,15 @@ func TestReturnBookHandler_Success(t *testing.T) {
 	if updatedBook.IsBorrowed() {
 		t.Error("book should not be borrowed after return")
 	}
+	if uow.commits != 1 {
+		t.Errorf("expected 1 commit, got %d", uow.commits)
+	}
 }
 
 func TestReturnBookHandler_BookNotFound(t *testing.T) {
 	repo := NewMockBookRepository()
-	handler := NewReturnBookHandler(repo)
-	ctx := context.Background()
+	handler := NewReturnBookHandler(repo, &MockUnitOfWork{}, auth.NewRolePolicy())
+	ctx := asLibrarian()
 
 	_, err := handler.Handle(ctx, ReturnBookCommand{
 		BookID: "550e8400-e29b-41d4-a716-446655440000",
@@ -64,8 +70,9 @@ func TestReturnBookHandler_NotBorrowed(t *testing.T) {
 	book := catalog.NewBook(id, title, author)
 	_ = repo.Add(context.Background(), book)
 
-	handler := NewReturnBookHandler(repo)
-	ctx := context.Background()
+	uow := &MockUnitOfWork{}
+	handler := NewReturnBookHandler(repo, uow, auth.NewRolePolicy())
+	ctx := asLibrarian()
 
 	_, err := handler.Handle(ctx, ReturnBookCommand{
 		BookID: id.String(),
@@ -74,4 +81,69 @@ func TestReturnBookHandler_NotBorrowed(t *testing.T) {
 	if err != catalog.ErrBookNotBorrowed {
 		t.Errorf("expected ErrBookNotBorrowed, got %v", err)
 	}
+	if uow.rollbacks != 1 || uow.commits != 0 {
+		t.Errorf("expected work to be rolled back, got %d commits and %d rollbacks", uow.commits, uow.rollbacks)
+	}
+}
+
+func TestReturnBookHandler_LibrarianReturnsOnBehalf(t *testing.T) {
+	repo := NewMockBookRepository()
+	id := catalog.GenerateBookID()
+	title, _ := catalog.NewTitle("Clean Code")
+	author, _ := catalog.NewAuthor("Robert Martin")
+	book := catalog.NewBook(id, title, author)
+	_ = book.Borrow("john@example.com", time.Now())
+	_ = repo.Add(context.Background(), book)
+
+	handler := NewReturnBookHandler(repo, &MockUnitOfWork{}, auth.NewRolePolicy())
+
+	_, err := handler.Handle(asLibrarian(), ReturnBookCommand{
+		BookID: id.String(),
+	})
+
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestReturnBookHandler_PatronCannotReturnOthersLoan(t *testing.T) {
+	repo := NewMockBookRepository()
+	id := catalog.GenerateBookID()
+	title, _ := catalog.NewTitle("Clean Code")
+	author, _ := catalog.NewAuthor("Robert Martin")
+	book := catalog.NewBook(id, title, author)
+	_ = book.Borrow("john@example.com", time.Now())
+	_ = repo.Add(context.Background(), book)
+
+	uow := &MockUnitOfWork{}
+	handler := NewReturnBookHandler(repo, uow, auth.NewRolePolicy())
+
+	_, err := handler.Handle(asPatron("jane@example.com"), ReturnBookCommand{
+		BookID: id.String(),
+	})
+
+	if !errors.Is(err, auth.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+	if !book.IsBorrowed() || uow.commits != 0 {
+		t.Error("expected the loan to be left untouched")
+	}
+}
+
+func TestReturnBookHandler_PatronReturningReturnedBook(t *testing.T) {
+	repo := NewMockBookRepository()
+	id := catalog.GenerateBookID()
+	title, _ := catalog.NewTitle("Clean Code")
+	author, _ := catalog.NewAuthor("Robert Martin")
+	_ = repo.Add(context.Background(), catalog.NewBook(id, title, author))
+
+	handler := NewReturnBookHandler(repo, &MockUnitOfWork{}, auth.NewRolePolicy())
+
+	_, err := handler.Handle(asPatron("john@example.com"), ReturnBookCommand{
+		BookID: id.String(),
+	})
+
+	if !errors.Is(err, catalog.ErrBookNotBorrowed) {
+		t.Errorf("expected ErrBookNotBorrowed, got %v", err)
+	}
 }
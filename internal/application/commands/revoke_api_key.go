@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/ports"
+	"library-system/internal/domain/access"
+)
+
+// RevokeAPIKeyCommand represents intent to disable an API key immediately
+type RevokeAPIKeyCommand struct {
+	KeyID string
+}
+
+// RevokeAPIKeyResult is returned after revoking a key
+type RevokeAPIKeyResult struct {
+	ID        string
+	RevokedAt time.Time
+}
+
+// RevokeAPIKeyHandler handles the RevokeAPIKeyCommand
+type RevokeAPIKeyHandler struct {
+	repo  access.APIKeyRepository
+	uow   ports.UnitOfWork
+	authz auth.Authorizer
+}
+
+// NewRevokeAPIKeyHandler creates a new handler
+func NewRevokeAPIKeyHandler(repo access.APIKeyRepository, uow ports.UnitOfWork, authz auth.Authorizer) *RevokeAPIKeyHandler {
+	return &RevokeAPIKeyHandler{repo: repo, uow: uow, authz: authz}
+}
+
+// Handle executes the command
+func (h *RevokeAPIKeyHandler) Handle(ctx context.Context, cmd RevokeAPIKeyCommand) (RevokeAPIKeyResult, error) {
+	if err := h.authz.Authorize(ctx, auth.ActionManageAPIKeys, ""); err != nil {
+		return RevokeAPIKeyResult{}, err
+	}
+
+	keyID, err := access.ParseAPIKeyID(cmd.KeyID)
+	if err != nil {
+		return RevokeAPIKeyResult{}, err
+	}
+
+	revokedAt := time.Now()
+	err = h.uow.Do(ctx, func(ctx context.Context) error {
+		key, err := h.repo.GetByID(ctx, keyID)
+		if err != nil {
+			return err
+		}
+		if key == nil {
+			return access.ErrAPIKeyNotFound
+		}
+		if err := key.Revoke(revokedAt); err != nil {
+			return err
+		}
+		return h.repo.Update(ctx, key)
+	})
+	if err != nil {
+		return RevokeAPIKeyResult{}, err
+	}
+	slog.InfoContext(ctx, "API key revoked", "key_id", keyID.String())
+
+	return RevokeAPIKeyResult{ID: keyID.String(), RevokedAt: revokedAt}, nil
+}
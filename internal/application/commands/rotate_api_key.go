@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/ports"
+	"library-system/internal/domain/access"
+)
+
+// DefaultRotationOverlap is how long a rotated key keeps working when the
+// caller doesn't say
+const DefaultRotationOverlap = 24 * time.Hour
+
+// RotateAPIKeyCommand represents intent to replace an API key. The old key
+// stays valid for Overlap so clients can switch over without downtime.
+type RotateAPIKeyCommand struct {
+	KeyID   string
+	Overlap *time.Duration // nil means DefaultRotationOverlap
+}
+
+// RotateAPIKeyResult is returned after rotating a key
+type RotateAPIKeyResult struct {
+	ID            string
+	Name          string
+	Key           string
+	Scopes        []access.Scope
+	ExpiresAt     *time.Time
+	PreviousID    string
+	PreviousUntil *time.Time
+}
+
+// RotateAPIKeyHandler handles the RotateAPIKeyCommand
+type RotateAPIKeyHandler struct {
+	repo  access.APIKeyRepository
+	uow   ports.UnitOfWork
+	authz auth.Authorizer
+}
+
+// NewRotateAPIKeyHandler creates a new handler
+func NewRotateAPIKeyHandler(repo access.APIKeyRepository, uow ports.UnitOfWork, authz auth.Authorizer) *RotateAPIKeyHandler {
+	return &RotateAPIKeyHandler{repo: repo, uow: uow, authz: authz}
+}
+
+// Handle executes the command
+func (h *RotateAPIKeyHandler) Handle(ctx context.Context, cmd RotateAPIKeyCommand) (RotateAPIKeyResult, error) {
+	if err := h.authz.Authorize(ctx, auth.ActionManageAPIKeys, ""); err != nil {
+		return RotateAPIKeyResult{}, err
+	}
+
+	keyID, err := access.ParseAPIKeyID(cmd.KeyID)
+	if err != nil {
+		return RotateAPIKeyResult{}, err
+	}
+	overlap := DefaultRotationOverlap
+	if cmd.Overlap != nil {
+		overlap = *cmd.Overlap
+	}
+
+	var old, successor *access.APIKey
+	var token string
+	err = h.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		old, err = h.repo.GetByID(ctx, keyID)
+		if err != nil {
+			return err
+		}
+		if old == nil {
+			return access.ErrAPIKeyNotFound
+		}
+
+		successor, token, err = old.Rotate(time.Now(), overlap)
+		if err != nil {
+			return err
+		}
+		if err := h.repo.Add(ctx, successor); err != nil {
+			return err
+		}
+		return h.repo.Update(ctx, old)
+	})
+	if err != nil {
+		return RotateAPIKeyResult{}, err
+	}
+	slog.InfoContext(ctx, "API key rotated",
+		"key_id", successor.ID().String(),
+		"previous_key_id", old.ID().String(),
+		"previous_until", old.ExpiresAt(),
+	)
+
+	return RotateAPIKeyResult{
+		ID:            successor.ID().String(),
+		Name:          successor.Name(),
+		Key:           token,
+		Scopes:        successor.Scopes(),
+		ExpiresAt:     successor.ExpiresAt(),
+		PreviousID:    old.ID().String(),
+		PreviousUntil: old.ExpiresAt(),
+	}, nil
+}
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/domain/access"
+)
+
+func TestRotateAPIKeyHandler_Success(t *testing.T) {
+	repo := NewMockAPIKeyRepository()
+	old, _, _ := access.IssueAPIKey("importer", []access.Scope{access.ScopeCatalogRead}, time.Now(), nil)
+	_ = repo.Add(asAdmin(), old)
+
+	uow := &MockUnitOfWork{}
+	handler := NewRotateAPIKeyHandler(repo, uow, auth.NewRolePolicy())
+	overlap := time.Hour
+
+	result, err := handler.Handle(asAdmin(), RotateAPIKeyCommand{
+		KeyID:   old.ID().String(),
+		Overlap: &overlap,
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(repo.keys) != 2 {
+		t.Errorf("expected old and new key to be stored, got %d", len(repo.keys))
+	}
+	if result.PreviousUntil == nil || result.PreviousUntil.After(time.Now().Add(overlap)) {
+		t.Errorf("expected old key to retire within the overlap, got %v", result.PreviousUntil)
+	}
+	if !old.ActiveAt(time.Now()) {
+		t.Error("expected old key to keep working during the overlap")
+	}
+	if uow.commits != 1 {
+		t.Errorf("expected 1 commit, got %d", uow.commits)
+	}
+}
+
+func TestRotateAPIKeyHandler_NotFound(t *testing.T) {
+	handler := NewRotateAPIKeyHandler(NewMockAPIKeyRepository(), &MockUnitOfWork{}, auth.NewRolePolicy())
+
+	_, err := handler.Handle(asAdmin(), RotateAPIKeyCommand{
+		KeyID: "550e8400-e29b-41d4-a716-446655440000",
+	})
+
+	if err != access.ErrAPIKeyNotFound {
+		t.Errorf("expected ErrAPIKeyNotFound, got %v", err)
+	}
+}
+
+func TestRevokeAPIKeyHandler_Success(t *testing.T) {
+	repo := NewMockAPIKeyRepository()
+	key, _, _ := access.IssueAPIKey("kiosk-1", []access.Scope{access.ScopeLending}, time.Now(), nil)
+	_ = repo.Add(asAdmin(), key)
+	handler := NewRevokeAPIKeyHandler(repo, &MockUnitOfWork{}, auth.NewRolePolicy())
+
+	_, err := handler.Handle(asAdmin(), RevokeAPIKeyCommand{KeyID: key.ID().String()})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if key.ActiveAt(time.Now()) {
+		t.Error("expected key to be revoked")
+	}
+	if _, err := handler.Handle(asAdmin(), RevokeAPIKeyCommand{KeyID: key.ID().String()}); err != access.ErrAPIKeyRevoked {
+		t.Errorf("expected ErrAPIKeyRevoked on second revoke, got %v", err)
+	}
+}
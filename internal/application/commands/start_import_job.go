@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"time"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/imports"
+	"library-system/internal/application/ports"
+)
+
+// StartImportJobCommand represents intent to import an upload in the
+// background. Source is closed once the job is done with it.
+type StartImportJobCommand struct {
+	Format imports.Format
+	Source io.ReadCloser
+}
+
+// StartImportJobResult is returned once the job is queued
+type StartImportJobResult struct {
+	JobID  string
+	Status imports.Status
+}
+
+// StartImportJobHandler handles the StartImportJobCommand
+type StartImportJobHandler struct {
+	importer *imports.Importer
+	jobs     imports.JobRepository
+	runner   ports.TaskRunner
+	authz    auth.Authorizer
+}
+
+// NewStartImportJobHandler creates a new handler
+func NewStartImportJobHandler(importer *imports.Importer, jobs imports.JobRepository, runner ports.TaskRunner, authz auth.Authorizer) *StartImportJobHandler {
+	return &StartImportJobHandler{importer: importer, jobs: jobs, runner: runner, authz: authz}
+}
+
+// Handle executes the command
+func (h *StartImportJobHandler) Handle(ctx context.Context, cmd StartImportJobCommand) (StartImportJobResult, error) {
+	if err := h.authz.Authorize(ctx, auth.ActionAddBook, ""); err != nil {
+		cmd.Source.Close()
+		return StartImportJobResult{}, err
+	}
+	if _, err := imports.ParseFormat(string(cmd.Format)); err != nil {
+		cmd.Source.Close()
+		return StartImportJobResult{}, err
+	}
+
+	principal, _ := auth.PrincipalFrom(ctx)
+	job := imports.NewJob(cmd.Format, principal.Subject, time.Now())
+	if err := h.jobs.Add(ctx, job); err != nil {
+		cmd.Source.Close()
+		return StartImportJobResult{}, err
+	}
+
+	if err := h.runner.Submit(func(ctx context.Context) { h.run(ctx, job, cmd.Source) }); err != nil {
+		cmd.Source.Close()
+		job.Finish(job.Report, err, time.Now())
+		if updateErr := h.jobs.Update(ctx, job); updateErr != nil {
+			slog.WarnContext(ctx, "failed to record rejected import job", "job_id", job.ID, "error", updateErr)
+		}
+		return StartImportJobResult{}, err
+	}
+	slog.InfoContext(ctx, "import job queued", "job_id", job.ID)
+
+	return StartImportJobResult{JobID: job.ID, Status: job.Status}, nil
+}
+
+// run imports the upload, recording progress on the job as it goes
+func (h *StartImportJobHandler) run(ctx context.Context, job *imports.Job, src io.ReadCloser) {
+	defer src.Close()
+	logger := slog.With("job_id", job.ID)
+
+	job.Start(time.Now())
+	if err := h.jobs.Update(ctx, job); err != nil {
+		logger.WarnContext(ctx, "failed to record import job start", "error", err)
+	}
+
+	report, err := h.importer.Import(ctx, job.Format, src, func(progress imports.Report) {
+		job.Report = progress
+		if err := h.jobs.Update(ctx, job); err != nil {
+			logger.WarnContext(ctx, "failed to record import job progress", "error", err)
+		}
+	})
+	job.Finish(report, err, time.Now())
+	if err != nil {
+		logger.ErrorContext(ctx, "import job failed", "error", err)
+	}
+
+	// Record the outcome even if the import was cut short by shutdown
+	if err := h.jobs.Update(context.WithoutCancel(ctx), job); err != nil {
+		logger.ErrorContext(ctx, "failed to record import job result", "error", err)
+	}
+}
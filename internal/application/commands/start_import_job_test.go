@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/imports"
+	"library-system/internal/application/ports"
+)
+
+// MockImportJobRepository is a test double for imports.JobRepository
+type MockImportJobRepository struct {
+	jobs map[string]imports.Job
+}
+
+func NewMockImportJobRepository() *MockImportJobRepository {
+	return &MockImportJobRepository{jobs: make(map[string]imports.Job)}
+}
+
+func (m *MockImportJobRepository) Add(ctx context.Context, job *imports.Job) error {
+	m.jobs[job.ID] = *job
+	return nil
+}
+
+func (m *MockImportJobRepository) Get(ctx context.Context, id string) (*imports.Job, error) {
+	job, ok := m.jobs[id]
+	if !ok {
+		return nil, nil
+	}
+	return &job, nil
+}
+
+func (m *MockImportJobRepository) Update(ctx context.Context, job *imports.Job) error {
+	m.jobs[job.ID] = *job
+	return nil
+}
+
+// inlineRunner runs tasks as soon as they are submitted, or rejects them
+type inlineRunner struct {
+	full bool
+}
+
+func (r inlineRunner) Submit(task func(ctx context.Context)) error {
+	if r.full {
+		return ports.ErrTaskQueueFull
+	}
+	task(context.Background())
+	return nil
+}
+
+// closeTracker records whether the upload was closed
+type closeTracker struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeTracker) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestStartImportJobHandler_RunsImportInBackground(t *testing.T) {
+	repo := NewMockBookRepository()
+	jobs := NewMockImportJobRepository()
+	importer := imports.NewImporter(repo, &MockUnitOfWork{}, 10)
+	handler := NewStartImportJobHandler(importer, jobs, inlineRunner{}, auth.NewRolePolicy())
+	src := &closeTracker{Reader: strings.NewReader("title,author\nDune,Frank Herbert\n,Nobody\n")}
+
+	result, err := handler.Handle(asLibrarian(), StartImportJobCommand{Format: imports.FormatCSV, Source: src})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	job := jobs.jobs[result.JobID]
+	if job.Status != imports.StatusSucceeded || job.Report.Imported != 1 || job.Report.Failed != 1 {
+		t.Errorf("expected succeeded job with 1 imported and 1 failed, got %s %+v", job.Status, job.Report)
+	}
+	if job.SubmittedBy != "librarian-1" || job.FinishedAt == nil {
+		t.Errorf("expected submitter and finish time to be recorded, got %+v", job)
+	}
+	if len(repo.books) != 1 || !src.closed {
+		t.Errorf("expected 1 book stored and the upload closed, got %d books, closed=%v", len(repo.books), src.closed)
+	}
+}
+
+func TestStartImportJobHandler_QueueFull(t *testing.T) {
+	jobs := NewMockImportJobRepository()
+	importer := imports.NewImporter(NewMockBookRepository(), &MockUnitOfWork{}, 10)
+	handler := NewStartImportJobHandler(importer, jobs, inlineRunner{full: true}, auth.NewRolePolicy())
+	src := &closeTracker{Reader: strings.NewReader("title,author\n")}
+
+	_, err := handler.Handle(asLibrarian(), StartImportJobCommand{Format: imports.FormatCSV, Source: src})
+
+	if !errors.Is(err, ports.ErrTaskQueueFull) {
+		t.Fatalf("expected ErrTaskQueueFull, got %v", err)
+	}
+	for _, job := range jobs.jobs {
+		if job.Status != imports.StatusFailed {
+			t.Errorf("expected rejected job to be marked failed, got %s", job.Status)
+		}
+	}
+	if !src.closed {
+		t.Error("expected the upload to be closed")
+	}
+}
+
+func TestStartImportJobHandler_PatronForbidden(t *testing.T) {
+	handler := NewStartImportJobHandler(nil, NewMockImportJobRepository(), inlineRunner{}, auth.NewRolePolicy())
+	src := &closeTracker{Reader: strings.NewReader("")}
+
+	_, err := handler.Handle(asPatron("john@example.com"), StartImportJobCommand{Format: imports.FormatCSV, Source: src})
+
+	if !errors.Is(err, auth.ErrForbidden) || !src.closed {
+		t.Errorf("expected ErrForbidden with the upload closed, got %v", err)
+	}
+}
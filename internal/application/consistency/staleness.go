@@ -0,0 +1,23 @@
+package consistency
+
+import (
+	"context"
+	"time"
+)
+
+type maxStalenessKey struct{}
+
+// WithMaxStaleness marks reads made with ctx as tolerating data at most d old.
+// A zero budget means the read must see the latest committed data.
+func WithMaxStaleness(ctx context.Context, d time.Duration) context.Context {
+	if d < 0 {
+		d = 0
+	}
+	return context.WithValue(ctx, maxStalenessKey{}, d)
+}
+
+// MaxStaleness returns the staleness budget carried by ctx, if any.
+func MaxStaleness(ctx context.Context) (time.Duration, bool) {
+	d, ok := ctx.Value(maxStalenessKey{}).(time.Duration)
+	return d, ok
+}
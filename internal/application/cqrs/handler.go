@@ -0,0 +1,62 @@
+package cqrs
+
+import "context"
+
+// Kind distinguishes commands (writes) from queries (reads).
+type Kind string
+
+const (
+	KindCommand Kind = "command"
+	KindQuery   Kind = "query"
+)
+
+// Handler executes a command or query. Every handler in the commands and
+// queries packages satisfies it.
+type Handler[Req, Res any] interface {
+	Handle(ctx context.Context, req Req) (Res, error)
+}
+
+// Info identifies the handler being called.
+type Info struct {
+	Kind Kind
+	Name string // e.g. "borrow_book"
+}
+
+// Interceptor observes a handler call, for metrics, tracing or logging.
+// It must call next exactly once and return its error.
+type Interceptor func(ctx context.Context, info Info, next func(ctx context.Context) error) error
+
+// Wrap decorates h with interceptors; the first interceptor is outermost.
+func Wrap[Req, Res any](kind Kind, name string, h Handler[Req, Res], interceptors ...Interceptor) Handler[Req, Res] {
+	if len(interceptors) == 0 {
+		return h
+	}
+	return &wrapped[Req, Res]{
+		info:         Info{Kind: kind, Name: name},
+		next:         h,
+		interceptors: interceptors,
+	}
+}
+
+type wrapped[Req, Res any] struct {
+	info         Info
+	next         Handler[Req, Res]
+	interceptors []Interceptor
+}
+
+func (w *wrapped[Req, Res]) Handle(ctx context.Context, req Req) (Res, error) {
+	var res Res
+	call := func(ctx context.Context) error {
+		var err error
+		res, err = w.next.Handle(ctx, req)
+		return err
+	}
+	for i := len(w.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := w.interceptors[i], call
+		call = func(ctx context.Context) error {
+			return interceptor(ctx, w.info, next)
+		}
+	}
+	err := call(ctx)
+	return res, err
+}
@@ -0,0 +1,52 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type echoHandler struct{ err error }
+
+func (h echoHandler) Handle(ctx context.Context, req string) (string, error) {
+	return "echo: " + req, h.err
+}
+
+func TestWrap_RunsInterceptorsOutermostFirst(t *testing.T) {
+	var calls []string
+	record := func(label string) Interceptor {
+		return func(ctx context.Context, info Info, next func(ctx context.Context) error) error {
+			calls = append(calls, label+" "+info.Name)
+			return next(ctx)
+		}
+	}
+
+	h := Wrap(KindCommand, "echo", Handler[string, string](echoHandler{}), record("outer"), record("inner"))
+	res, err := h.Handle(context.Background(), "hi")
+
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if res != "echo: hi" {
+		t.Errorf("expected 'echo: hi', got %s", res)
+	}
+	if len(calls) != 2 || calls[0] != "outer echo" || calls[1] != "inner echo" {
+		t.Errorf("unexpected interceptor order: %v", calls)
+	}
+}
+
+func TestWrap_InterceptorSeesHandlerError(t *testing.T) {
+	want := errors.New("boom")
+	var seen error
+
+	h := Wrap(KindQuery, "echo", Handler[string, string](echoHandler{err: want}),
+		func(ctx context.Context, info Info, next func(ctx context.Context) error) error {
+			seen = next(ctx)
+			return seen
+		})
+	_, err := h.Handle(context.Background(), "hi")
+
+	if err != want || seen != want {
+		t.Errorf("expected handler error to propagate, got %v and %v", err, seen)
+	}
+}
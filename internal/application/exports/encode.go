@@ -0,0 +1,90 @@
+package exports
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"library-system/internal/domain/catalog"
+)
+
+// encoder writes books in one format. close flushes it and writes any
+// trailer the format needs.
+type encoder interface {
+	encode(book *catalog.Book) error
+	close() error
+}
+
+func newEncoder(format Format, w io.Writer) (encoder, error) {
+	switch format {
+	case FormatCSV:
+		enc := &csvEncoder{w: csv.NewWriter(w)}
+		// Same title and author columns the importer reads
+		return enc, enc.w.Write([]string{"id", "title", "author", "is_borrowed", "return_due_date"})
+	case FormatNDJSON:
+		buf := bufio.NewWriter(w)
+		return &ndjsonEncoder{buf: buf, enc: json.NewEncoder(buf)}, nil
+	case FormatMARCXML:
+		return newMARCXMLEncoder(w)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvEncoder) encode(book *catalog.Book) error {
+	return e.w.Write([]string{
+		book.ID().String(),
+		book.Title().String(),
+		book.Author().String(),
+		strconv.FormatBool(book.IsBorrowed()),
+		formatDue(book.ReturnDueDate()),
+	})
+}
+
+func (e *csvEncoder) close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// ndjsonBook is one line of an NDJSON export
+type ndjsonBook struct {
+	ID            string `json:"id"`
+	Title         string `json:"title"`
+	Author        string `json:"author"`
+	IsBorrowed    bool   `json:"is_borrowed"`
+	ReturnDueDate string `json:"return_due_date,omitempty"`
+}
+
+type ndjsonEncoder struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+func (e *ndjsonEncoder) encode(book *catalog.Book) error {
+	return e.enc.Encode(ndjsonBook{
+		ID:            book.ID().String(),
+		Title:         book.Title().String(),
+		Author:        book.Author().String(),
+		IsBorrowed:    book.IsBorrowed(),
+		ReturnDueDate: formatDue(book.ReturnDueDate()),
+	})
+}
+
+func (e *ndjsonEncoder) close() error {
+	return e.buf.Flush()
+}
+
+// formatDue renders a due date as RFC 3339, or "" when not on loan
+func formatDue(due *time.Time) string {
+	if due == nil {
+		return ""
+	}
+	return due.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,101 @@
+// Package exports streams the catalog out as CSV, NDJSON or MARCXML.
+package exports
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+
+	"library-system/internal/domain/catalog"
+)
+
+// ErrUnsupportedFormat is returned for export formats other than CSV,
+// NDJSON and MARCXML.
+var ErrUnsupportedFormat = errors.New("unsupported export format, use csv, ndjson or marcxml")
+
+// Format is the encoding of an export.
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatNDJSON  Format = "ndjson"
+	FormatMARCXML Format = "marcxml"
+)
+
+// ParseFormat accepts a format name or its media type.
+func ParseFormat(s string) (Format, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if i := strings.IndexByte(s, ';'); i >= 0 {
+		s = strings.TrimSpace(s[:i])
+	}
+	switch s {
+	case "csv", "text/csv":
+		return FormatCSV, nil
+	case "ndjson", "jsonl", "application/x-ndjson", "application/ndjson", "application/jsonl":
+		return FormatNDJSON, nil
+	case "marcxml", "marc", "application/marcxml+xml":
+		return FormatMARCXML, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// ContentType is the media type of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatMARCXML:
+		return "application/marcxml+xml; charset=utf-8"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// Extension is the file extension for the format, without the dot.
+func (f Format) Extension() string {
+	switch f {
+	case FormatNDJSON:
+		return "ndjson"
+	case FormatMARCXML:
+		return "xml"
+	default:
+		return string(f)
+	}
+}
+
+// Exporter writes every book in the catalog to a stream.
+type Exporter struct {
+	repo catalog.BookRepository
+}
+
+// NewExporter creates an exporter reading from repo.
+func NewExporter(repo catalog.BookRepository) *Exporter {
+	return &Exporter{repo: repo}
+}
+
+// Export encodes every book to w as it is read, and returns how many were
+// written. Nothing is buffered beyond the encoder's own small buffer.
+func (e *Exporter) Export(ctx context.Context, format Format, w io.Writer) (int, error) {
+	enc, err := newEncoder(format, w)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	if err := e.repo.Each(ctx, func(book *catalog.Book) error {
+		count++
+		return enc.encode(book)
+	}); err != nil {
+		return count, err
+	}
+	if err := enc.close(); err != nil {
+		return count, err
+	}
+	slog.InfoContext(ctx, "catalog exported", "format", string(format), "count", count)
+	return count, nil
+}
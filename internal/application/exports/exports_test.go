@@ -0,0 +1,107 @@
+package exports
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"library-system/internal/domain/catalog"
+)
+
+// stubBookRepository serves a fixed catalog; only Each is used by exports
+type stubBookRepository struct {
+	catalog.BookRepository
+	books []*catalog.Book
+}
+
+func (r *stubBookRepository) Each(ctx context.Context, fn func(*catalog.Book) error) error {
+	for _, b := range r.books {
+		if err := fn(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func testCatalog(t *testing.T) *stubBookRepository {
+	t.Helper()
+	newBook := func(title, author string) *catalog.Book {
+		ti, _ := catalog.NewTitle(title)
+		au, _ := catalog.NewAuthor(author)
+		return catalog.NewBook(catalog.GenerateBookID(), ti, au)
+	}
+	borrowed := newBook("Pride & Prejudice", "Austen, Jane")
+	if err := borrowed.Borrow("reader@example.com", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	return &stubBookRepository{books: []*catalog.Book{newBook("Dune", "Frank Herbert"), borrowed}}
+}
+
+func TestExport_CSV(t *testing.T) {
+	var out bytes.Buffer
+
+	n, err := NewExporter(testCatalog(t)).Export(context.Background(), FormatCSV, &out)
+
+	if err != nil || n != 2 {
+		t.Fatalf("expected 2 books exported, got %d, %v", n, err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 || lines[0] != "id,title,author,is_borrowed,return_due_date" {
+		t.Fatalf("expected header and 2 rows, got %q", out.String())
+	}
+	if !strings.Contains(lines[2], `,Pride & Prejudice,"Austen, Jane",true,2026-01-15T00:00:00Z`) {
+		t.Errorf("expected quoted author and due date, got %q", lines[2])
+	}
+}
+
+func TestExport_NDJSON(t *testing.T) {
+	var out bytes.Buffer
+
+	_, err := NewExporter(testCatalog(t)).Export(context.Background(), FormatNDJSON, &out)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"title":"Dune"`) || strings.Contains(lines[0], "return_due_date") {
+		t.Errorf("expected one object per book, got %q", out.String())
+	}
+}
+
+func TestExport_MARCXML(t *testing.T) {
+	var out bytes.Buffer
+
+	_, err := NewExporter(testCatalog(t)).Export(context.Background(), FormatMARCXML, &out)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		XMLName xml.Name     `xml:"http://www.loc.gov/MARC21/slim collection"`
+		Records []marcRecord `xml:"http://www.loc.gov/MARC21/slim record"`
+	}
+	if err := xml.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Fatalf("expected well-formed MARCXML, got %v:\n%s", err, out.String())
+	}
+	if len(doc.Records) != 2 {
+		t.Fatalf("expected 2 records, got %d:\n%s", len(doc.Records), out.String())
+	}
+	title := doc.Records[1].DataFields[1]
+	if title.Tag != "245" || title.Subfields[0].Value != "Pride & Prejudice" {
+		t.Errorf("expected 245 $a title, got %+v", title)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]Format{"csv": FormatCSV, "application/x-ndjson": FormatNDJSON, "MARCXML": FormatMARCXML} {
+		if got, err := ParseFormat(in); err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("pdf"); err != ErrUnsupportedFormat {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
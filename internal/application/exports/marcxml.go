@@ -0,0 +1,82 @@
+package exports
+
+import (
+	"encoding/xml"
+	"io"
+
+	"library-system/internal/domain/catalog"
+)
+
+// marcNamespace is the MARC 21 XML (MARCXML slim) schema namespace
+const marcNamespace = "http://www.loc.gov/MARC21/slim"
+
+// marcLeader describes a bibliographic record for language material
+// (monograph) with full-level encoding; lengths and base address are left
+// as zeros, which MARCXML readers recompute
+const marcLeader = "00000nam a2200000 a 4500"
+
+// MARCXML subset: the book ID as control number (001), the author as
+// main entry (100 $a) and the title statement (245 $a).
+type marcRecord struct {
+	XMLName       xml.Name           `xml:"record"`
+	Leader        string             `xml:"leader"`
+	ControlFields []marcControlField `xml:"controlfield"`
+	DataFields    []marcDataField    `xml:"datafield"`
+}
+
+type marcControlField struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+type marcDataField struct {
+	Tag       string         `xml:"tag,attr"`
+	Ind1      string         `xml:"ind1,attr"`
+	Ind2      string         `xml:"ind2,attr"`
+	Subfields []marcSubfield `xml:"subfield"`
+}
+
+type marcSubfield struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+type marcXMLEncoder struct {
+	enc        *xml.Encoder
+	collection xml.StartElement
+}
+
+func newMARCXMLEncoder(w io.Writer) (*marcXMLEncoder, error) {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return nil, err
+	}
+	e := &marcXMLEncoder{
+		enc: xml.NewEncoder(w),
+		collection: xml.StartElement{
+			Name: xml.Name{Local: "collection"},
+			Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: marcNamespace}},
+		},
+	}
+	e.enc.Indent("", "  ")
+	return e, e.enc.EncodeToken(e.collection)
+}
+
+func (e *marcXMLEncoder) encode(book *catalog.Book) error {
+	return e.enc.Encode(marcRecord{
+		Leader:        marcLeader,
+		ControlFields: []marcControlField{{Tag: "001", Value: book.ID().String()}},
+		DataFields: []marcDataField{
+			// ind1 1: author entered surname first; ind2 blank: undefined
+			{Tag: "100", Ind1: "1", Ind2: " ", Subfields: []marcSubfield{{Code: "a", Value: book.Author().String()}}},
+			// ind1 1: title added entry; ind2 0: no nonfiling characters
+			{Tag: "245", Ind1: "1", Ind2: "0", Subfields: []marcSubfield{{Code: "a", Value: book.Title().String()}}},
+		},
+	})
+}
+
+func (e *marcXMLEncoder) close() error {
+	if err := e.enc.EncodeToken(e.collection.End()); err != nil {
+		return err
+	}
+	return e.enc.Flush()
+}
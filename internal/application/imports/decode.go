@@ -0,0 +1,179 @@
+// Package imports loads books in bulk from CSV or JSON Lines uploads.
+package imports
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"library-system/internal/domain/catalog"
+	"library-system/internal/domain/shared"
+)
+
+// maxLineBytes bounds a single NDJSON line
+const maxLineBytes = 1 << 20
+
+// ErrUnsupportedFormat is returned for upload formats other than CSV and
+// NDJSON.
+var ErrUnsupportedFormat = errors.New("unsupported import format, use csv or ndjson")
+
+// Format is the encoding of an upload.
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+// ParseFormat accepts a format name or a media type such as text/csv or
+// application/x-ndjson.
+func ParseFormat(s string) (Format, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if i := strings.IndexByte(s, ';'); i >= 0 {
+		s = strings.TrimSpace(s[:i])
+	}
+	switch s {
+	case "csv", "text/csv":
+		return FormatCSV, nil
+	case "ndjson", "jsonl", "application/x-ndjson", "application/ndjson", "application/jsonl", "application/x-jsonlines":
+		return FormatNDJSON, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// record is one book read from an upload. Err is set when the row could
+// not be decoded; the upload itself may still be read further.
+type record struct {
+	Line   int
+	Title  string
+	Author string
+	Err    error
+}
+
+// book validates the record through the catalog's value objects
+func (r record) book() (*catalog.Book, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	title, err := catalog.NewTitle(r.Title)
+	if err != nil {
+		return nil, err
+	}
+	author, err := catalog.NewAuthor(r.Author)
+	if err != nil {
+		return nil, err
+	}
+	return catalog.NewBook(catalog.GenerateBookID(), title, author), nil
+}
+
+// decoder streams records from an upload. next returns io.EOF after the
+// last record; any other error means the upload cannot be read further.
+type decoder interface {
+	next() (record, error)
+}
+
+func newDecoder(format Format, src io.Reader) (decoder, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVDecoder(src)
+	case FormatNDJSON:
+		scanner := bufio.NewScanner(src)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxLineBytes)
+		return &ndjsonDecoder{scanner: scanner}, nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// csvDecoder reads CSV with a header row naming the title and author
+// columns, in any order; other columns are ignored
+type csvDecoder struct {
+	reader         *csv.Reader
+	title, author  int // column indexes
+	requiredFields int
+}
+
+func newCSVDecoder(src io.Reader) (*csvDecoder, error) {
+	reader := csv.NewReader(src)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, shared.ValidationError{Field: "file", Message: "CSV upload is empty, expected a header row"}
+	}
+	if err != nil {
+		return nil, shared.ValidationError{Field: "file", Message: fmt.Sprintf("invalid CSV header: %v", err)}
+	}
+
+	d := &csvDecoder{reader: reader, title: -1, author: -1}
+	for i, name := range header {
+		switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))) {
+		case "title":
+			d.title = i
+		case "author":
+			d.author = i
+		}
+	}
+	if d.title < 0 || d.author < 0 {
+		return nil, shared.ValidationError{Field: "file", Message: "CSV header must include title and author columns"}
+	}
+	d.requiredFields = max(d.title, d.author) + 1
+	return d, nil
+}
+
+func (d *csvDecoder) next() (record, error) {
+	fields, err := d.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return record{Line: parseErr.StartLine, Err: shared.ValidationError{Field: "row", Message: parseErr.Err.Error()}}, nil
+		}
+		return record{}, err
+	}
+	line, _ := d.reader.FieldPos(0)
+	if len(fields) < d.requiredFields {
+		return record{Line: line, Err: shared.ValidationError{
+			Field: "row", Message: fmt.Sprintf("expected at least %d fields, got %d", d.requiredFields, len(fields)),
+		}}, nil
+	}
+	return record{Line: line, Title: fields[d.title], Author: fields[d.author]}, nil
+}
+
+// ndjsonDecoder reads one {"title": ..., "author": ...} object per line,
+// skipping blank lines
+type ndjsonDecoder struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (d *ndjsonDecoder) next() (record, error) {
+	for d.scanner.Scan() {
+		d.line++
+		text := d.scanner.Bytes()
+		if len(bytes.TrimSpace(text)) == 0 {
+			continue
+		}
+		var row struct {
+			Title  string `json:"title"`
+			Author string `json:"author"`
+		}
+		if err := json.Unmarshal(text, &row); err != nil {
+			return record{Line: d.line, Err: shared.ValidationError{Field: "row", Message: "invalid JSON: " + err.Error()}}, nil
+		}
+		return record{Line: d.line, Title: row.Title, Author: row.Author}, nil
+	}
+	if err := d.scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return record{}, shared.ValidationError{Field: "file", Message: fmt.Sprintf("line %d exceeds %d bytes", d.line+1, maxLineBytes)}
+		}
+		return record{}, err
+	}
+	return record{}, io.EOF
+}
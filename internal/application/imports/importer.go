@@ -0,0 +1,131 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+
+	"library-system/internal/application/ports"
+	"library-system/internal/domain/catalog"
+)
+
+const (
+	// DefaultBatchSize is how many books are copied per round trip
+	DefaultBatchSize = 1000
+	// MaxReportedErrors caps the per-row errors kept in a Report
+	MaxReportedErrors = 1000
+)
+
+// RowError describes a row that was not imported.
+type RowError struct {
+	Line  int
+	Error string
+}
+
+// Report summarizes an import. Total counts every row read, Imported the
+// valid rows stored and Failed the rejected ones. ImportedThroughLine is
+// the line of the last row stored, so an import that stopped partway can
+// be resumed with the rows after it.
+type Report struct {
+	Total               int
+	Imported            int
+	Failed              int
+	Errors              []RowError
+	ErrorsTruncated     bool
+	ImportedThroughLine int
+}
+
+func (r *Report) fail(line int, err error) {
+	r.Failed++
+	if len(r.Errors) == MaxReportedErrors {
+		r.ErrorsTruncated = true
+		return
+	}
+	r.Errors = append(r.Errors, RowError{Line: line, Error: err.Error()})
+}
+
+// Importer validates uploaded rows and stores the valid ones in bulk.
+//
+// Invalid rows are reported and skipped. Valid rows are stored batchSize
+// at a time, each batch in its own unit of work, so memory stays bounded
+// by the batch rather than the upload, and no transaction stays open long
+// enough to hold back the snapshot xmin that event delivery waits on. The
+// trade-off is that an import is not atomic: if storing fails or the
+// upload cannot be read to the end, the batches already stored are kept,
+// and the report says how far the import got.
+type Importer struct {
+	repo      catalog.BookRepository
+	uow       ports.UnitOfWork
+	batchSize int
+}
+
+// NewImporter creates an importer that stores books batchSize at a time.
+func NewImporter(repo catalog.BookRepository, uow ports.UnitOfWork, batchSize int) *Importer {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	return &Importer{repo: repo, uow: uow, batchSize: batchSize}
+}
+
+// Import reads src to the end. progress, if set, is called after every
+// batch is stored with the report so far. On error the report still
+// counts the batches stored before it.
+func (im *Importer) Import(ctx context.Context, format Format, src io.Reader, progress func(Report)) (Report, error) {
+	dec, err := newDecoder(format, src)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{Errors: []RowError{}}
+	batch := make([]*catalog.Book, 0, im.batchSize)
+	lastLine := 0
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := im.uow.Do(ctx, func(ctx context.Context) error {
+			return im.repo.AddAll(ctx, batch)
+		}); err != nil {
+			return err
+		}
+		report.Imported += len(batch)
+		report.ImportedThroughLine = lastLine
+		batch = batch[:0]
+		if progress != nil {
+			progress(report)
+		}
+		return nil
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		rec, err := dec.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return report, err
+		}
+		report.Total++
+		book, err := rec.book()
+		if err != nil {
+			report.fail(rec.Line, err)
+			continue
+		}
+		batch = append(batch, book)
+		lastLine = rec.Line
+		if len(batch) == im.batchSize {
+			if err := flush(); err != nil {
+				return report, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return report, err
+	}
+	slog.InfoContext(ctx, "books imported", "total", report.Total, "imported", report.Imported, "failed", report.Failed)
+	return report, nil
+}
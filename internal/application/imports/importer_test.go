@@ -0,0 +1,147 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"library-system/internal/domain/catalog"
+	"library-system/internal/domain/shared"
+)
+
+// stubBookRepository records bulk inserts; only AddAll is used by imports.
+// It fails every batch with err, or the failOnBatch-th batch if set.
+type stubBookRepository struct {
+	catalog.BookRepository
+	batches     [][]string
+	err         error
+	failOnBatch int
+	calls       int
+}
+
+func (r *stubBookRepository) AddAll(ctx context.Context, books []*catalog.Book) error {
+	r.calls++
+	if r.err != nil {
+		return r.err
+	}
+	if r.calls == r.failOnBatch {
+		return errors.New("connection reset")
+	}
+	titles := make([]string, len(books))
+	for i, b := range books {
+		titles[i] = b.Title().String()
+	}
+	r.batches = append(r.batches, titles)
+	return nil
+}
+
+type inlineUnitOfWork struct{}
+
+func (inlineUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// countingUnitOfWork counts the units of work an import opens
+type countingUnitOfWork struct {
+	calls int
+}
+
+func (u *countingUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.calls++
+	return fn(ctx)
+}
+
+func TestImport_CSVReportsInvalidRows(t *testing.T) {
+	repo := &stubBookRepository{}
+	csv := "isbn,Author,Title\n" +
+		"1,Frank Herbert,Dune\n" +
+		"2,,Nameless\n" +
+		"3,Jane Austen,Emma\n" +
+		"4\n" +
+		"5,Ursula K. Le Guin,The Dispossessed\n"
+
+	report, err := NewImporter(repo, inlineUnitOfWork{}, 2).Import(context.Background(), FormatCSV, strings.NewReader(csv), nil)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if report.Total != 5 || report.Imported != 3 || report.Failed != 2 {
+		t.Errorf("expected 5 total, 3 imported, 2 failed, got %+v", report)
+	}
+	if len(report.Errors) != 2 || report.Errors[0].Line != 3 || report.Errors[1].Line != 5 {
+		t.Errorf("expected errors on lines 3 and 5, got %+v", report.Errors)
+	}
+	if len(repo.batches) != 2 || len(repo.batches[0]) != 2 || repo.batches[1][0] != "The Dispossessed" {
+		t.Errorf("expected batches of 2 then 1, got %v", repo.batches)
+	}
+}
+
+func TestImport_NDJSONSkipsBlankLinesAndReportsBadJSON(t *testing.T) {
+	repo := &stubBookRepository{}
+	ndjson := `{"title":"Dune","author":"Frank Herbert"}` + "\n\n" + `{"title":` + "\n" + `{"title":"Emma","author":"Jane Austen"}`
+
+	report, err := NewImporter(repo, inlineUnitOfWork{}, 10).Import(context.Background(), FormatNDJSON, strings.NewReader(ndjson), nil)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if report.Imported != 2 || report.Failed != 1 || report.Errors[0].Line != 3 {
+		t.Errorf("expected 2 imported and line 3 rejected, got %+v", report)
+	}
+}
+
+func TestImport_CSVRequiresTitleAndAuthorColumns(t *testing.T) {
+	_, err := NewImporter(&stubBookRepository{}, inlineUnitOfWork{}, 10).
+		Import(context.Background(), FormatCSV, strings.NewReader("name,writer\nDune,Frank Herbert\n"), nil)
+
+	if !errors.Is(err, shared.ErrValidation) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+func TestImport_StorageFailureImportsNothing(t *testing.T) {
+	repo := &stubBookRepository{err: errors.New("connection reset")}
+
+	report, err := NewImporter(repo, inlineUnitOfWork{}, 1).
+		Import(context.Background(), FormatNDJSON, strings.NewReader(`{"title":"Dune","author":"Frank Herbert"}`), nil)
+
+	if err == nil || report.Imported != 0 {
+		t.Errorf("expected error with nothing imported, got %v and %+v", err, report)
+	}
+}
+
+func TestImport_CommitsEachBatchAndReportsProgressOnFailure(t *testing.T) {
+	repo := &stubBookRepository{failOnBatch: 2}
+	uow := &countingUnitOfWork{}
+	csv := "title,author\nDune,Frank Herbert\nEmma,Jane Austen\nBeloved,Toni Morrison\n"
+
+	report, err := NewImporter(repo, uow, 1).Import(context.Background(), FormatCSV, strings.NewReader(csv), nil)
+
+	if err == nil {
+		t.Fatal("expected the failed batch to be reported")
+	}
+	if uow.calls != 2 {
+		t.Errorf("expected a unit of work per batch, got %d", uow.calls)
+	}
+	if report.Imported != 1 || report.ImportedThroughLine != 2 {
+		t.Errorf("expected the first row, on line 2, to stay imported, got %+v", report)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := map[string]Format{
+		"csv":                     FormatCSV,
+		"text/csv; charset=utf-8": FormatCSV,
+		"application/x-ndjson":    FormatNDJSON,
+		"JSONL":                   FormatNDJSON,
+	}
+	for in, want := range tests {
+		if got, err := ParseFormat(in); err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("application/json"); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
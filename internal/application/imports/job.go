@@ -0,0 +1,73 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrJobNotFound is returned for unknown import job IDs.
+var ErrJobNotFound = errors.New("import job not found")
+
+// Status is the lifecycle state of an import job.
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+// Job is an import running in the background. Report is updated as
+// batches are stored; Error is set when the job failed.
+type Job struct {
+	ID          string
+	Format      Format
+	Status      Status
+	SubmittedBy string
+	Report      Report
+	Error       string
+	CreatedAt   time.Time
+	StartedAt   *time.Time
+	FinishedAt  *time.Time
+}
+
+// NewJob creates a queued job.
+func NewJob(format Format, submittedBy string, now time.Time) *Job {
+	return &Job{
+		ID:          uuid.NewString(),
+		Format:      format,
+		Status:      StatusQueued,
+		SubmittedBy: submittedBy,
+		Report:      Report{Errors: []RowError{}},
+		CreatedAt:   now,
+	}
+}
+
+// Start marks the job as running.
+func (j *Job) Start(now time.Time) {
+	j.Status = StatusRunning
+	j.StartedAt = &now
+}
+
+// Finish records the outcome of the import.
+func (j *Job) Finish(report Report, err error, now time.Time) {
+	j.Report = report
+	j.FinishedAt = &now
+	if err != nil {
+		j.Status = StatusFailed
+		j.Error = err.Error()
+		return
+	}
+	j.Status = StatusSucceeded
+}
+
+// JobRepository persists import jobs. Get returns nil, nil for unknown IDs.
+type JobRepository interface {
+	Add(ctx context.Context, job *Job) error
+	Get(ctx context.Context, id string) (*Job, error)
+	Update(ctx context.Context, job *Job) error
+}
@@ -0,0 +1,80 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"library-system/internal/domain/shared"
+)
+
+// ErrEventFeedClosed is returned when subscribing to a feed that has shut
+// down.
+var ErrEventFeedClosed = errors.New("event feed closed")
+
+// EventPosition locates an event in commit order: by the transaction that
+// wrote it, then by ID. IDs alone follow insert order, so an event from a
+// long transaction can commit after one with a higher ID; transaction IDs
+// are only read once every older transaction has finished, so nothing
+// commits behind a position already passed.
+type EventPosition struct {
+	TxID uint64
+	ID   int64
+}
+
+// Less reports whether p comes before q
+func (p EventPosition) Less(q EventPosition) bool {
+	return p.TxID < q.TxID || (p.TxID == q.TxID && p.ID < q.ID)
+}
+
+// IsZero reports whether p is before every event
+func (p EventPosition) IsZero() bool {
+	return p == EventPosition{}
+}
+
+// String formats p as "<tx_id>-<id>", e.g. for an SSE event id
+func (p EventPosition) String() string {
+	return strconv.FormatUint(p.TxID, 10) + "-" + strconv.FormatInt(p.ID, 10)
+}
+
+// ParseEventPosition parses a position formatted by String
+func ParseEventPosition(s string) (EventPosition, error) {
+	tx, id, ok := strings.Cut(s, "-")
+	if ok {
+		p := EventPosition{}
+		var errTx, errID error
+		p.TxID, errTx = strconv.ParseUint(tx, 10, 64)
+		p.ID, errID = strconv.ParseInt(id, 10, 64)
+		if errTx == nil && errID == nil && p.ID >= 0 {
+			return p, nil
+		}
+	}
+	return EventPosition{}, fmt.Errorf("invalid event position %q, expected <tx_id>-<id>", s)
+}
+
+// LoggedEvent is a domain event as recorded in the event log
+type LoggedEvent struct {
+	Position   EventPosition
+	BookID     string
+	OccurredAt time.Time
+	Event      shared.DomainEvent
+}
+
+// EventLog reads the durable log of domain events.
+type EventLog interface {
+	// After returns up to limit committed events past after, in commit
+	// order. A non-empty bookIDs keeps only events about those books.
+	After(ctx context.Context, after EventPosition, bookIDs []string, limit int) ([]LoggedEvent, error)
+}
+
+// EventFeed pushes events as they are committed to the log.
+type EventFeed interface {
+	// Subscribe returns a channel receiving, in order, every event past
+	// cursor. The channel is closed when the subscriber falls too far
+	// behind or the feed shuts down; the log still holds what was missed.
+	// cancel must be called once the subscriber is done.
+	Subscribe(ctx context.Context) (events <-chan LoggedEvent, cursor EventPosition, cancel func(), err error)
+}
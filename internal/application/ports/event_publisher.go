@@ -0,0 +1,36 @@
+package ports
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// EnvelopeSpecVersion is the version of the EventEnvelope format. It
+// changes only when a field is removed or changes meaning; consumers should
+// ignore fields they don't know.
+const EnvelopeSpecVersion = 1
+
+// EventEnvelope is a domain event as published to other teams. Events are
+// published in (TxID, ID) order, which is the order they committed in; ID
+// alone is unique but not monotonic in that order, since a transaction
+// that took an ID early can commit after one that took a later ID.
+type EventEnvelope struct {
+	SpecVersion      int             `json:"spec_version"`
+	ID               int64           `json:"id"`    // unique; identifies the event, e.g. to drop duplicates
+	TxID             uint64          `json:"tx_id"` // the recording transaction; with ID, the ordering key
+	Type             string          `json:"type"`  // e.g. catalog.book_borrowed
+	OccurredAt       time.Time       `json:"occurred_at"`
+	AggregateType    string          `json:"aggregate_type"` // e.g. book
+	AggregateID      string          `json:"aggregate_id"`
+	AggregateVersion int             `json:"aggregate_version"` // the aggregate's version after the change; -1 if not recorded
+	Data             json.RawMessage `json:"data"`
+}
+
+// EventPublisher hands events to a message broker. Publish returns once the
+// broker has accepted every event, in order, or with an error, after which
+// the whole batch is published again: consumers see each event at least
+// once and should drop duplicates by ID.
+type EventPublisher interface {
+	Publish(ctx context.Context, events []EventEnvelope) error
+}
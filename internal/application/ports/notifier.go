@@ -0,0 +1,16 @@
+package ports
+
+import "context"
+
+// Notification is a plain-text message to one recipient
+type Notification struct {
+	To      string // email address
+	Subject string
+	Body    string
+}
+
+// Notifier delivers notifications to people, e.g. by email. An error means
+// the notification was not accepted and may be retried.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
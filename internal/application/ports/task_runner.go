@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrTaskQueueFull is returned when a TaskRunner cannot take more work.
+var ErrTaskQueueFull = errors.New("too many background tasks queued, retry later")
+
+// TaskRunner runs work in the background, outside the request that
+// submitted it. The ctx passed to a task is cancelled when the service
+// shuts down.
+type TaskRunner interface {
+	Submit(task func(ctx context.Context)) error
+}
@@ -0,0 +1,13 @@
+package ports
+
+import "context"
+
+// UnitOfWork runs a block of work atomically across repositories.
+//
+// Repositories called with the ctx passed to fn take part in the same
+// transaction. If fn returns an error (or panics) every change is rolled
+// back; otherwise they are committed together. Calling Do with a ctx that
+// is already inside a unit of work joins it rather than nesting.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,45 @@
+package queries
+
+import (
+	"context"
+	"io"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/exports"
+)
+
+// ExportBooksQuery represents a request to stream the whole catalog to
+// Destination
+type ExportBooksQuery struct {
+	Format      exports.Format
+	Destination io.Writer
+}
+
+// ExportBooksResult is returned once the export is written
+type ExportBooksResult struct {
+	Count int
+}
+
+// ExportBooksHandler handles the ExportBooksQuery
+type ExportBooksHandler struct {
+	exporter *exports.Exporter
+	authz    auth.Authorizer
+}
+
+// NewExportBooksHandler creates a new handler
+func NewExportBooksHandler(exporter *exports.Exporter, authz auth.Authorizer) *ExportBooksHandler {
+	return &ExportBooksHandler{exporter: exporter, authz: authz}
+}
+
+// Handle executes the query
+func (h *ExportBooksHandler) Handle(ctx context.Context, query ExportBooksQuery) (ExportBooksResult, error) {
+	if err := h.authz.Authorize(ctx, auth.ActionExportCatalog, ""); err != nil {
+		return ExportBooksResult{}, err
+	}
+
+	count, err := h.exporter.Export(ctx, query.Format, query.Destination)
+	if err != nil {
+		return ExportBooksResult{Count: count}, err
+	}
+	return ExportBooksResult{Count: count}, nil
+}
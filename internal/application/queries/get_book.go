@@ -4,12 +4,18 @@ import (
 	"context"
 	"time"
 
+	"library-system/internal/application/auth"
+	"library-system/internal/application/consistency"
 	"library-system/internal/domain/catalog"
 )
 
 // GetBookQuery represents a request to get a book by ID
 type GetBookQuery struct {
 	BookID string
+
+	// MaxStaleness bounds how old the data may be. nil reads from any
+	// healthy replica; zero forces a read from the primary.
+	MaxStaleness *time.Duration
 }
 
 // GetBookResult is returned after fetching a book
@@ -18,18 +24,20 @@ type GetBookResult struct {
 	Title         string
 	Author        string
 	IsBorrowed    bool
+	BorrowerEmail string `json:",omitempty"` // Only shown to librarians and the borrower
 	BorrowedAt    *time.Time
 	ReturnDueDate *time.Time
 }
 
 // GetBookHandler handles the GetBookQuery
 type GetBookHandler struct {
-	repo catalog.BookRepository
+	repo  catalog.BookRepository
+	authz auth.Authorizer
 }
 
 // NewGetBookHandler creates a new handler
-func NewGetBookHandler(repo catalog.BookRepository) *GetBookHandler {
-	return &GetBookHandler{repo: repo}
+func NewGetBookHandler(repo catalog.BookRepository, authz auth.Authorizer) *GetBookHandler {
+	return &GetBookHandler{repo: repo, authz: authz}
 }
 
 // Handle executes the query
@@ -39,6 +47,10 @@ func (h *GetBookHandler) Handle(ctx context.Context, query GetBookQuery) (GetBoo
 		return GetBookResult{}, err
 	}
 
+	if query.MaxStaleness != nil {
+		ctx = consistency.WithMaxStaleness(ctx, *query.MaxStaleness)
+	}
+
 	book, err := h.repo.GetByID(ctx, bookID)
 	if err != nil {
 		return GetBookResult{}, err
@@ -47,10 +59,22 @@ func (h *GetBookHandler) Handle(ctx context.Context, query GetBookQuery) (GetBoo
 		return GetBookResult{}, catalog.ErrBookNotFound
 	}
 
-	return GetBookResult{
-		ID:         book.ID().String(),
-		Title:      book.Title().String(),
-		Author:     book.Author().String(),
-		IsBorrowed: book.IsBorrowed(),
-	}, nil
+	return bookResult(ctx, h.authz, book), nil
+}
+
+// bookResult converts a book for the caller. Anyone may see that a book is
+// out; only the borrower and librarians may see who has it.
+func bookResult(ctx context.Context, authz auth.Authorizer, book *catalog.Book) GetBookResult {
+	result := GetBookResult{
+		ID:            book.ID().String(),
+		Title:         book.Title().String(),
+		Author:        book.Author().String(),
+		IsBorrowed:    book.IsBorrowed(),
+		BorrowedAt:    book.BorrowedAt(),
+		ReturnDueDate: book.ReturnDueDate(),
+	}
+	if book.IsBorrowed() && authz.Authorize(ctx, auth.ActionViewLoans, book.BorrowerEmail()) == nil {
+		result.BorrowerEmail = book.BorrowerEmail()
+	}
+	return result
 }
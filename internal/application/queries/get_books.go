@@ -0,0 +1,78 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/consistency"
+	"library-system/internal/domain/catalog"
+	"library-system/internal/domain/shared"
+)
+
+// GetBooksQuery represents a request to get several books by ID in one
+// round trip, e.g. for a batching loader
+type GetBooksQuery struct {
+	BookIDs []string
+
+	// MaxStaleness bounds how old the data may be. nil reads from any
+	// healthy replica; zero forces a read from the primary.
+	MaxStaleness *time.Duration
+}
+
+// GetBooksResult is returned after fetching books. Books follow the order
+// of BookIDs, leaving out IDs that don't exist.
+type GetBooksResult struct {
+	Books []GetBookResult
+}
+
+// GetBooksHandler handles the GetBooksQuery
+type GetBooksHandler struct {
+	repo  catalog.BookRepository
+	authz auth.Authorizer
+}
+
+// NewGetBooksHandler creates a new handler
+func NewGetBooksHandler(repo catalog.BookRepository, authz auth.Authorizer) *GetBooksHandler {
+	return &GetBooksHandler{repo: repo, authz: authz}
+}
+
+// Handle executes the query
+func (h *GetBooksHandler) Handle(ctx context.Context, query GetBooksQuery) (GetBooksResult, error) {
+	if len(query.BookIDs) > MaxLimit {
+		return GetBooksResult{}, shared.ValidationError{Field: "BookIDs", Message: fmt.Sprintf("at most %d books per query", MaxLimit)}
+	}
+	ids := make([]catalog.BookID, len(query.BookIDs))
+	for i, raw := range query.BookIDs {
+		id, err := catalog.ParseBookID(raw)
+		if err != nil {
+			return GetBooksResult{}, err
+		}
+		ids[i] = id
+	}
+	if len(ids) == 0 {
+		return GetBooksResult{}, nil
+	}
+
+	if query.MaxStaleness != nil {
+		ctx = consistency.WithMaxStaleness(ctx, *query.MaxStaleness)
+	}
+
+	books, err := h.repo.GetByIDs(ctx, ids)
+	if err != nil {
+		return GetBooksResult{}, err
+	}
+
+	byID := make(map[catalog.BookID]*catalog.Book, len(books))
+	for _, book := range books {
+		byID[book.ID()] = book
+	}
+	results := make([]GetBookResult, 0, len(books))
+	for _, id := range ids {
+		if book, ok := byID[id]; ok {
+			results = append(results, bookResult(ctx, h.authz, book))
+		}
+	}
+	return GetBooksResult{Books: results}, nil
+}
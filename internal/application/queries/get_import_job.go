@@ -0,0 +1,65 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/imports"
+)
+
+// GetImportJobQuery represents a request for an import job's status
+type GetImportJobQuery struct {
+	JobID string
+}
+
+// GetImportJobResult is returned after fetching a job
+type GetImportJobResult struct {
+	ID          string
+	Format      imports.Format
+	Status      imports.Status
+	SubmittedBy string
+	Report      imports.Report
+	Error       string
+	CreatedAt   time.Time
+	StartedAt   *time.Time
+	FinishedAt  *time.Time
+}
+
+// GetImportJobHandler handles the GetImportJobQuery
+type GetImportJobHandler struct {
+	jobs  imports.JobRepository
+	authz auth.Authorizer
+}
+
+// NewGetImportJobHandler creates a new handler
+func NewGetImportJobHandler(jobs imports.JobRepository, authz auth.Authorizer) *GetImportJobHandler {
+	return &GetImportJobHandler{jobs: jobs, authz: authz}
+}
+
+// Handle executes the query
+func (h *GetImportJobHandler) Handle(ctx context.Context, query GetImportJobQuery) (GetImportJobResult, error) {
+	if err := h.authz.Authorize(ctx, auth.ActionAddBook, ""); err != nil {
+		return GetImportJobResult{}, err
+	}
+
+	job, err := h.jobs.Get(ctx, query.JobID)
+	if err != nil {
+		return GetImportJobResult{}, err
+	}
+	if job == nil {
+		return GetImportJobResult{}, imports.ErrJobNotFound
+	}
+
+	return GetImportJobResult{
+		ID:          job.ID,
+		Format:      job.Format,
+		Status:      job.Status,
+		SubmittedBy: job.SubmittedBy,
+		Report:      job.Report,
+		Error:       job.Error,
+		CreatedAt:   job.CreatedAt,
+		StartedAt:   job.StartedAt,
+		FinishedAt:  job.FinishedAt,
+	}, nil
+}
@@ -0,0 +1,96 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/consistency"
+	"library-system/internal/domain/catalog"
+	"library-system/internal/domain/shared"
+)
+
+// GetLoansQuery represents a request to list the loans of several
+// borrowers in one round trip, e.g. for a batching loader
+type GetLoansQuery struct {
+	BorrowerEmails []string
+
+	// MaxStaleness bounds how old the data may be. nil reads from any
+	// healthy replica; zero forces a read from the primary.
+	MaxStaleness *time.Duration
+}
+
+// GetLoansResult is returned after fetching loans. Borrowers follow the
+// order of BorrowerEmails, leaving out those the caller may not view.
+type GetLoansResult struct {
+	Borrowers []ListLoansResult
+}
+
+// GetLoansHandler handles the GetLoansQuery
+type GetLoansHandler struct {
+	repo  catalog.BookRepository
+	authz auth.Authorizer
+}
+
+// NewGetLoansHandler creates a new handler
+func NewGetLoansHandler(repo catalog.BookRepository, authz auth.Authorizer) *GetLoansHandler {
+	return &GetLoansHandler{repo: repo, authz: authz}
+}
+
+// Handle executes the query
+func (h *GetLoansHandler) Handle(ctx context.Context, query GetLoansQuery) (GetLoansResult, error) {
+	if len(query.BorrowerEmails) > MaxLimit {
+		return GetLoansResult{}, shared.ValidationError{Field: "BorrowerEmails", Message: fmt.Sprintf("at most %d borrowers per query", MaxLimit)}
+	}
+	if _, ok := auth.PrincipalFrom(ctx); !ok {
+		return GetLoansResult{}, auth.ErrUnauthenticated
+	}
+
+	var allowed []string
+	for _, email := range query.BorrowerEmails {
+		err := h.authz.Authorize(ctx, auth.ActionViewLoans, email)
+		if errors.Is(err, auth.ErrForbidden) {
+			continue
+		}
+		if err != nil {
+			return GetLoansResult{}, err
+		}
+		allowed = append(allowed, email)
+	}
+	if len(allowed) == 0 {
+		return GetLoansResult{}, nil
+	}
+
+	if query.MaxStaleness != nil {
+		ctx = consistency.WithMaxStaleness(ctx, *query.MaxStaleness)
+	}
+
+	books, err := h.repo.ListBorrowedByAny(ctx, allowed)
+	if err != nil {
+		return GetLoansResult{}, err
+	}
+
+	byBorrower := make(map[string][]LoanSummary, len(allowed))
+	for _, book := range books {
+		key := strings.ToLower(book.BorrowerEmail())
+		byBorrower[key] = append(byBorrower[key], LoanSummary{
+			BookID:        book.ID().String(),
+			Title:         book.Title().String(),
+			Author:        book.Author().String(),
+			BorrowedAt:    book.BorrowedAt(),
+			ReturnDueDate: book.ReturnDueDate(),
+		})
+	}
+	results := make([]ListLoansResult, len(allowed))
+	for i, email := range allowed {
+		loans := byBorrower[strings.ToLower(email)]
+		if loans == nil {
+			loans = []LoanSummary{}
+		}
+		results[i] = ListLoansResult{BorrowerEmail: email, Loans: loans}
+	}
+	return GetLoansResult{Borrowers: results}, nil
+}
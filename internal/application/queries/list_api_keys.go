@@ -0,0 +1,74 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/domain/access"
+)
+
+// ListAPIKeysQuery represents a request to list every API key
+type ListAPIKeysQuery struct{}
+
+// APIKeySummary describes a key without its secret
+type APIKeySummary struct {
+	ID         string
+	Name       string
+	Prefix     string
+	Scopes     []access.Scope
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time
+	RevokedAt  *time.Time
+	LastUsedAt *time.Time
+	RotatedTo  string `json:",omitempty"`
+	Active     bool
+}
+
+// ListAPIKeysResult is returned after fetching keys
+type ListAPIKeysResult struct {
+	Keys []APIKeySummary `json:"keys"`
+}
+
+// ListAPIKeysHandler handles the ListAPIKeysQuery
+type ListAPIKeysHandler struct {
+	repo  access.APIKeyRepository
+	authz auth.Authorizer
+}
+
+// NewListAPIKeysHandler creates a new handler
+func NewListAPIKeysHandler(repo access.APIKeyRepository, authz auth.Authorizer) *ListAPIKeysHandler {
+	return &ListAPIKeysHandler{repo: repo, authz: authz}
+}
+
+// Handle executes the query
+func (h *ListAPIKeysHandler) Handle(ctx context.Context, query ListAPIKeysQuery) (ListAPIKeysResult, error) {
+	if err := h.authz.Authorize(ctx, auth.ActionManageAPIKeys, ""); err != nil {
+		return ListAPIKeysResult{}, err
+	}
+
+	keys, err := h.repo.List(ctx)
+	if err != nil {
+		return ListAPIKeysResult{}, err
+	}
+
+	now := time.Now()
+	summaries := make([]APIKeySummary, len(keys))
+	for i, key := range keys {
+		summaries[i] = APIKeySummary{
+			ID:         key.ID().String(),
+			Name:       key.Name(),
+			Prefix:     access.TokenPrefix + key.Prefix(),
+			Scopes:     key.Scopes(),
+			CreatedAt:  key.CreatedAt(),
+			ExpiresAt:  key.ExpiresAt(),
+			RevokedAt:  key.RevokedAt(),
+			LastUsedAt: key.LastUsedAt(),
+			Active:     key.ActiveAt(now),
+		}
+		if next := key.RotatedTo(); next != nil {
+			summaries[i].RotatedTo = next.String()
+		}
+	}
+	return ListAPIKeysResult{Keys: summaries}, nil
+}
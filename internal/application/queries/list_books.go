@@ -3,7 +3,9 @@ package queries
 import (
 	"context"
 	"sync"
+	"time"
 
+	"library-system/internal/application/consistency"
 	"library-system/internal/domain/catalog"
 )
 
@@ -16,6 +18,10 @@ const (
 type ListBooksQuery struct {
 	Limit  int
 	Offset int
+
+	// MaxStaleness bounds how old the data may be. nil reads from any
+	// healthy replica; zero forces a read from the primary.
+	MaxStaleness *time.Duration
 }
 
 // BookSummary is a simplified view of a book for listings
@@ -59,6 +65,10 @@ func (h *ListBooksHandler) Handle(ctx context.Context, query ListBooksQuery) (Li
 		offset = 0
 	}
 
+	if query.MaxStaleness != nil {
+		ctx = consistency.WithMaxStaleness(ctx, *query.MaxStaleness)
+	}
+
 	// Run List and Count in parallel
 	var books []*catalog.Book
 	var total int
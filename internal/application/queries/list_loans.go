@@ -0,0 +1,93 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/consistency"
+	"library-system/internal/domain/catalog"
+	"library-system/internal/domain/shared"
+)
+
+// ListLoansQuery represents a request to list the books a borrower has on
+// loan. An empty BorrowerEmail means the caller's own loans; naming
+// someone else requires a librarian.
+type ListLoansQuery struct {
+	BorrowerEmail string
+
+	// MaxStaleness bounds how old the data may be. nil reads from any
+	// healthy replica; zero forces a read from the primary.
+	MaxStaleness *time.Duration
+}
+
+// LoanSummary is a book on loan
+type LoanSummary struct {
+	BookID        string
+	Title         string
+	Author        string
+	BorrowedAt    *time.Time
+	ReturnDueDate *time.Time
+}
+
+// ListLoansResult is returned after fetching loans
+type ListLoansResult struct {
+	BorrowerEmail string        `json:"borrower_email"`
+	Loans         []LoanSummary `json:"loans"`
+}
+
+// ListLoansHandler handles the ListLoansQuery
+type ListLoansHandler struct {
+	repo  catalog.BookRepository
+	authz auth.Authorizer
+}
+
+// NewListLoansHandler creates a new handler
+func NewListLoansHandler(repo catalog.BookRepository, authz auth.Authorizer) *ListLoansHandler {
+	return &ListLoansHandler{repo: repo, authz: authz}
+}
+
+// Handle executes the query
+func (h *ListLoansHandler) Handle(ctx context.Context, query ListLoansQuery) (ListLoansResult, error) {
+	borrower := query.BorrowerEmail
+	if borrower == "" {
+		principal, ok := auth.PrincipalFrom(ctx)
+		if !ok {
+			return ListLoansResult{}, auth.ErrUnauthenticated
+		}
+		borrower = principal.Email
+	}
+	if borrower == "" {
+		// API keys and tokens without an email claim have no loans of
+		// their own, and an empty borrower must never reach the repository
+		return ListLoansResult{}, shared.ValidationError{Field: "BorrowerEmail", Message: "borrower email is required for callers without an email"}
+	}
+	if err := h.authz.Authorize(ctx, auth.ActionViewLoans, borrower); err != nil {
+		return ListLoansResult{}, err
+	}
+
+	if query.MaxStaleness != nil {
+		ctx = consistency.WithMaxStaleness(ctx, *query.MaxStaleness)
+	}
+
+	books, err := h.repo.ListBorrowedBy(ctx, borrower)
+	if err != nil {
+		return ListLoansResult{}, err
+	}
+
+	loans := make([]LoanSummary, len(books))
+	for i, book := range books {
+		loans[i] = LoanSummary{
+			BookID:        book.ID().String(),
+			Title:         book.Title().String(),
+			Author:        book.Author().String(),
+			BorrowedAt:    book.BorrowedAt(),
+			ReturnDueDate: book.ReturnDueDate(),
+		}
+	}
+
+	return ListLoansResult{
+		BorrowerEmail: borrower,
+		Loans:         loans,
+	}, nil
+}
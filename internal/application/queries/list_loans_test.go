@@ -0,0 +1,43 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/domain/catalog"
+	"library-system/internal/domain/shared"
+)
+
+// stubBookRepository records loan lookups; only ListBorrowedBy is used
+type stubBookRepository struct {
+	catalog.BookRepository
+	lookups []string
+}
+
+func (r *stubBookRepository) ListBorrowedBy(ctx context.Context, borrowerEmail string) ([]*catalog.Book, error) {
+	r.lookups = append(r.lookups, borrowerEmail)
+	return nil, nil
+}
+
+func TestListLoansHandler_RequiresBorrowerForCallersWithoutEmail(t *testing.T) {
+	callers := map[string]auth.Principal{
+		"API key":        {Subject: "apikey:1", Roles: []string{auth.RoleLendingDesk}},
+		"token no email": {Subject: "user-1", Roles: []string{auth.RoleLibrarian}},
+	}
+	for name, principal := range callers {
+		repo := &stubBookRepository{}
+		ctx := auth.WithPrincipal(context.Background(), principal)
+
+		_, err := NewListLoansHandler(repo, auth.NewRolePolicy()).Handle(ctx, ListLoansQuery{})
+
+		var invalid shared.ValidationError
+		if !errors.As(err, &invalid) || invalid.Field != "BorrowerEmail" {
+			t.Errorf("%s: expected BorrowerEmail validation error, got %v", name, err)
+		}
+		if len(repo.lookups) != 0 {
+			t.Errorf("%s: expected no lookup, got %q", name, repo.lookups)
+		}
+	}
+}
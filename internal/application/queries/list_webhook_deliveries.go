@@ -0,0 +1,125 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/domain/webhooks"
+)
+
+// ListWebhookDeliveriesQuery represents a request for a webhook's recent
+// deliveries and their attempts. Status "dead" gives the dead-letter view.
+type ListWebhookDeliveriesQuery struct {
+	WebhookID string
+	Status    string // empty for any
+	Limit     int
+}
+
+// WebhookAttempt is one try at a delivery
+type WebhookAttempt struct {
+	At         time.Time
+	StatusCode int    `json:",omitempty"`
+	Error      string `json:",omitempty"`
+	DurationMs int64
+}
+
+// WebhookDelivery is one event sent, or to be sent, to a webhook
+type WebhookDelivery struct {
+	ID            int64
+	EventID       int64
+	EventName     string
+	Status        webhooks.DeliveryStatus
+	Attempts      []WebhookAttempt
+	NextAttemptAt *time.Time `json:",omitempty"` // only while pending
+	LastError     string     `json:",omitempty"`
+	CreatedAt     time.Time
+	DeliveredAt   *time.Time `json:",omitempty"`
+}
+
+// ListWebhookDeliveriesResult is returned after fetching deliveries
+type ListWebhookDeliveriesResult struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+// ListWebhookDeliveriesHandler handles the ListWebhookDeliveriesQuery
+type ListWebhookDeliveriesHandler struct {
+	subs       webhooks.SubscriptionRepository
+	deliveries webhooks.DeliveryRepository
+	authz      auth.Authorizer
+}
+
+// NewListWebhookDeliveriesHandler creates a new handler
+func NewListWebhookDeliveriesHandler(subs webhooks.SubscriptionRepository, deliveries webhooks.DeliveryRepository, authz auth.Authorizer) *ListWebhookDeliveriesHandler {
+	return &ListWebhookDeliveriesHandler{subs: subs, deliveries: deliveries, authz: authz}
+}
+
+// Handle executes the query
+func (h *ListWebhookDeliveriesHandler) Handle(ctx context.Context, query ListWebhookDeliveriesQuery) (ListWebhookDeliveriesResult, error) {
+	if err := h.authz.Authorize(ctx, auth.ActionManageWebhooks, ""); err != nil {
+		return ListWebhookDeliveriesResult{}, err
+	}
+	id, err := webhooks.ParseSubscriptionID(query.WebhookID)
+	if err != nil {
+		return ListWebhookDeliveriesResult{}, err
+	}
+	var status webhooks.DeliveryStatus
+	if query.Status != "" {
+		if status, err = webhooks.ParseDeliveryStatus(query.Status); err != nil {
+			return ListWebhookDeliveriesResult{}, err
+		}
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	limit = min(limit, MaxLimit)
+
+	sub, err := h.subs.GetByID(ctx, id)
+	if err != nil {
+		return ListWebhookDeliveriesResult{}, err
+	}
+	if sub == nil {
+		return ListWebhookDeliveriesResult{}, webhooks.ErrSubscriptionNotFound
+	}
+	found, err := h.deliveries.ListBySubscription(ctx, id, status, limit)
+	if err != nil {
+		return ListWebhookDeliveriesResult{}, err
+	}
+	ids := make([]int64, len(found))
+	for i, d := range found {
+		ids[i] = d.ID()
+	}
+	attempts, err := h.deliveries.Attempts(ctx, ids)
+	if err != nil {
+		return ListWebhookDeliveriesResult{}, err
+	}
+
+	result := ListWebhookDeliveriesResult{Deliveries: make([]WebhookDelivery, len(found))}
+	for i, d := range found {
+		delivery := WebhookDelivery{
+			ID:          d.ID(),
+			EventID:     d.EventID(),
+			EventName:   d.EventName(),
+			Status:      d.Status(),
+			Attempts:    make([]WebhookAttempt, 0, len(attempts[d.ID()])),
+			LastError:   d.LastError(),
+			CreatedAt:   d.CreatedAt(),
+			DeliveredAt: d.DeliveredAt(),
+		}
+		if d.Status() == webhooks.DeliveryPending {
+			next := d.NextAttemptAt()
+			delivery.NextAttemptAt = &next
+		}
+		for _, a := range attempts[d.ID()] {
+			delivery.Attempts = append(delivery.Attempts, WebhookAttempt{
+				At:         a.At,
+				StatusCode: a.StatusCode,
+				Error:      a.Error,
+				DurationMs: a.Duration.Milliseconds(),
+			})
+		}
+		result.Deliveries[i] = delivery
+	}
+	return result, nil
+}
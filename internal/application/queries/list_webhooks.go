@@ -0,0 +1,60 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/domain/webhooks"
+)
+
+// ListWebhooksQuery represents a request to list every webhook
+type ListWebhooksQuery struct{}
+
+// WebhookSummary describes a webhook without its secret
+type WebhookSummary struct {
+	ID          string
+	URL         string
+	Events      []string
+	Description string
+	CreatedAt   time.Time
+}
+
+// ListWebhooksResult is returned after fetching webhooks
+type ListWebhooksResult struct {
+	Webhooks []WebhookSummary `json:"webhooks"`
+}
+
+// ListWebhooksHandler handles the ListWebhooksQuery
+type ListWebhooksHandler struct {
+	repo  webhooks.SubscriptionRepository
+	authz auth.Authorizer
+}
+
+// NewListWebhooksHandler creates a new handler
+func NewListWebhooksHandler(repo webhooks.SubscriptionRepository, authz auth.Authorizer) *ListWebhooksHandler {
+	return &ListWebhooksHandler{repo: repo, authz: authz}
+}
+
+// Handle executes the query
+func (h *ListWebhooksHandler) Handle(ctx context.Context, query ListWebhooksQuery) (ListWebhooksResult, error) {
+	if err := h.authz.Authorize(ctx, auth.ActionManageWebhooks, ""); err != nil {
+		return ListWebhooksResult{}, err
+	}
+
+	subs, err := h.repo.List(ctx)
+	if err != nil {
+		return ListWebhooksResult{}, err
+	}
+	summaries := make([]WebhookSummary, len(subs))
+	for i, sub := range subs {
+		summaries[i] = WebhookSummary{
+			ID:          sub.ID().String(),
+			URL:         sub.URL(),
+			Events:      sub.Events(),
+			Description: sub.Description(),
+			CreatedAt:   sub.CreatedAt(),
+		}
+	}
+	return ListWebhooksResult{Webhooks: summaries}, nil
+}
@@ -0,0 +1,154 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/ports"
+	"library-system/internal/domain/catalog"
+	"library-system/internal/domain/shared"
+)
+
+// replayBatch is how many logged events are read per query while catching
+// a resumed stream up
+const replayBatch = 500
+
+// WatchEventsQuery represents a request to follow catalog changes as they
+// happen, handing each one to Emit
+type WatchEventsQuery struct {
+	// LastEvent resumes after an event the caller already has, replaying
+	// what it missed from the log; the zero position starts with the next
+	// change
+	LastEvent ports.EventPosition
+	// BookIDs keeps only events about these books when not empty
+	BookIDs []string
+	// Opened is called once the stream is established, before any event
+	Opened func()
+	Emit   func(CatalogEvent) error
+}
+
+// CatalogEvent is one change to the catalog. The borrower of a
+// catalog.BookBorrowed is blanked unless the caller may view that loan.
+type CatalogEvent struct {
+	Position   ports.EventPosition
+	Name       string // e.g. catalog.book_borrowed
+	BookID     string
+	OccurredAt time.Time
+	Data       shared.DomainEvent
+}
+
+// WatchEventsResult is returned once the stream ends
+type WatchEventsResult struct {
+	Count int
+}
+
+// WatchEventsHandler handles the WatchEventsQuery
+type WatchEventsHandler struct {
+	log   ports.EventLog
+	feed  ports.EventFeed
+	authz auth.Authorizer
+}
+
+// NewWatchEventsHandler creates a new handler
+func NewWatchEventsHandler(log ports.EventLog, feed ports.EventFeed, authz auth.Authorizer) *WatchEventsHandler {
+	return &WatchEventsHandler{log: log, feed: feed, authz: authz}
+}
+
+// Handle executes the query. It blocks until ctx is done or the feed drops
+// the caller (for falling behind or shutting down), when the caller should
+// resume from the last event it got.
+func (h *WatchEventsHandler) Handle(ctx context.Context, query WatchEventsQuery) (WatchEventsResult, error) {
+	if _, ok := auth.PrincipalFrom(ctx); !ok {
+		return WatchEventsResult{}, auth.ErrUnauthenticated
+	}
+	if len(query.BookIDs) > MaxLimit {
+		return WatchEventsResult{}, shared.ValidationError{Field: "BookIDs", Message: fmt.Sprintf("at most %d books per stream", MaxLimit)}
+	}
+	books := make(map[string]bool, len(query.BookIDs))
+	for _, raw := range query.BookIDs {
+		id, err := catalog.ParseBookID(raw)
+		if err != nil {
+			return WatchEventsResult{}, err
+		}
+		books[id.String()] = true
+	}
+	bookIDs := make([]string, 0, len(books))
+	for id := range books {
+		bookIDs = append(bookIDs, id)
+	}
+
+	// Subscribe before replaying so nothing committed in between is lost:
+	// the log covers everything up to cursor, the feed everything after
+	live, cursor, cancel, err := h.feed.Subscribe(ctx)
+	if err != nil {
+		return WatchEventsResult{}, err
+	}
+	defer cancel()
+	if query.Opened != nil {
+		query.Opened()
+	}
+
+	var result WatchEventsResult
+	last := query.LastEvent
+	if !last.IsZero() {
+	replay:
+		for last.Less(cursor) {
+			events, err := h.log.After(ctx, last, bookIDs, replayBatch)
+			if err != nil {
+				return result, err
+			}
+			for _, e := range events {
+				if cursor.Less(e.Position) {
+					break replay
+				}
+				if err := h.emit(ctx, query.Emit, e); err != nil {
+					return result, err
+				}
+				result.Count++
+				last = e.Position
+			}
+			if len(events) < replayBatch {
+				break
+			}
+		}
+	}
+	if last.Less(cursor) {
+		last = cursor
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return result, nil
+		case e, ok := <-live:
+			if !ok {
+				return result, nil
+			}
+			if !last.Less(e.Position) || (len(books) > 0 && !books[e.BookID]) {
+				continue
+			}
+			if err := h.emit(ctx, query.Emit, e); err != nil {
+				return result, err
+			}
+			result.Count++
+			last = e.Position
+		}
+	}
+}
+
+func (h *WatchEventsHandler) emit(ctx context.Context, emit func(CatalogEvent) error, e ports.LoggedEvent) error {
+	data := e.Event
+	if borrowed, ok := data.(catalog.BookBorrowed); ok && h.authz.Authorize(ctx, auth.ActionViewLoans, borrowed.BorrowerEmail) != nil {
+		borrowed.BorrowerEmail = ""
+		data = borrowed
+	}
+	return emit(CatalogEvent{
+		Position:   e.Position,
+		Name:       e.Event.EventName(),
+		BookID:     e.BookID,
+		OccurredAt: e.OccurredAt,
+		Data:       data,
+	})
+}
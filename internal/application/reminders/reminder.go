@@ -0,0 +1,214 @@
+// Package reminders tells borrowers when a loan is about to fall due and
+// when it is overdue.
+package reminders
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"log/slog"
+	"text/template"
+	"time"
+
+	"library-system/internal/application/ports"
+	"library-system/internal/domain/catalog"
+)
+
+// Kind is which reminder a loan gets
+type Kind string
+
+const (
+	KindDueSoon Kind = "due_soon" // the loan falls due within the lead time
+	KindOverdue Kind = "overdue"  // the loan is past due
+)
+
+// ledgerRetention is how long a reminder is remembered after its loan
+// ends, covering sweeps that still read the loan from a lagging replica
+const ledgerRetention = 24 * time.Hour
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+// Sent identifies a reminder for one loan. A loan is a book and when it was
+// borrowed, so borrowing the same book again gets new reminders.
+type Sent struct {
+	BookID        string
+	BorrowedAt    time.Time
+	Kind          Kind
+	BorrowerEmail string
+	At            time.Time
+}
+
+// Ledger remembers which reminders were sent, so a borrower is never sent
+// the same one twice, however many instances sweep at once
+type Ledger interface {
+	// Claim records a reminder before it is sent and reports whether it
+	// was new; false means it was already sent
+	Claim(ctx context.Context, s Sent) (bool, error)
+	// Release forgets a claimed reminder that could not be sent, so the
+	// next sweep tries again
+	Release(ctx context.Context, s Sent) error
+	// Forget drops reminders sent before the given time for loans that
+	// have ended
+	Forget(ctx context.Context, sentBefore time.Time) (int64, error)
+}
+
+// templateData is what the reminder templates can use
+type templateData struct {
+	Title      string
+	Author     string
+	BorrowedAt time.Time
+	DueDate    time.Time
+}
+
+// Reminder sweeps loans and sends the reminders they are owed
+type Reminder struct {
+	books     catalog.BookRepository
+	ledger    Ledger
+	notifier  ports.Notifier
+	lead      time.Duration
+	interval  time.Duration
+	templates map[Kind]*template.Template
+	now       func() time.Time
+}
+
+// NewReminder creates a reminder that warns borrowers daysBefore days
+// before a loan is due, and again once it is overdue, sweeping every
+// interval
+func NewReminder(books catalog.BookRepository, ledger Ledger, notifier ports.Notifier, daysBefore int, interval time.Duration) (*Reminder, error) {
+	templates := make(map[Kind]*template.Template)
+	for _, kind := range []Kind{KindDueSoon, KindOverdue} {
+		t, err := template.ParseFS(templateFiles, "templates/"+string(kind)+".tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s template: %w", kind, err)
+		}
+		templates[kind] = t
+	}
+	return &Reminder{
+		books:     books,
+		ledger:    ledger,
+		notifier:  notifier,
+		lead:      time.Duration(daysBefore) * 24 * time.Hour,
+		interval:  interval,
+		templates: templates,
+		now:       time.Now,
+	}, nil
+}
+
+// Run sweeps straight away and then every interval until ctx is cancelled
+func (r *Reminder) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if sent, err := r.Sweep(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			slog.WarnContext(ctx, "failed to sweep loans for reminders", "error", err)
+		} else if sent > 0 {
+			slog.InfoContext(ctx, "reminders sent", "count", sent)
+		}
+		if _, err := r.ledger.Forget(ctx, r.now().Add(-ledgerRetention)); err != nil && ctx.Err() == nil {
+			slog.WarnContext(ctx, "failed to forget old reminders", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep sends every reminder that is due and returns how many were sent.
+// A reminder that fails is logged and retried on the next sweep.
+func (r *Reminder) Sweep(ctx context.Context) (int, error) {
+	now := r.now()
+	books, err := r.books.ListDueBefore(ctx, now.Add(r.lead))
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, book := range books {
+		if ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+		borrowedAt, due := book.BorrowedAt(), book.ReturnDueDate()
+		if borrowedAt == nil || due == nil {
+			continue
+		}
+		kind := KindOverdue
+		if due.After(now) {
+			// A loan shorter than the lead time would be warned about as
+			// soon as it was made
+			if due.Sub(*borrowedAt) <= r.lead {
+				continue
+			}
+			kind = KindDueSoon
+		}
+
+		ok, err := r.remind(ctx, book, kind, now)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to send reminder", "book_id", book.ID().String(), "kind", string(kind), "error", err)
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// remind sends one reminder unless it was already sent
+func (r *Reminder) remind(ctx context.Context, book *catalog.Book, kind Kind, now time.Time) (bool, error) {
+	notification, err := r.render(book, kind)
+	if err != nil {
+		return false, err
+	}
+
+	record := Sent{
+		BookID:        book.ID().String(),
+		BorrowedAt:    *book.BorrowedAt(),
+		Kind:          kind,
+		BorrowerEmail: book.BorrowerEmail(),
+		At:            now,
+	}
+	claimed, err := r.ledger.Claim(ctx, record)
+	if err != nil || !claimed {
+		return false, err
+	}
+	if err := r.notifier.Notify(ctx, notification); err != nil {
+		if releaseErr := r.ledger.Release(context.WithoutCancel(ctx), record); releaseErr != nil {
+			slog.ErrorContext(ctx, "failed to release unsent reminder; it will not be retried",
+				"book_id", record.BookID, "kind", string(kind), "error", releaseErr)
+		}
+		return false, err
+	}
+	slog.DebugContext(ctx, "reminder sent", "book_id", record.BookID, "kind", string(kind))
+	return true, nil
+}
+
+// render fills in the templates for a reminder
+func (r *Reminder) render(book *catalog.Book, kind Kind) (ports.Notification, error) {
+	data := templateData{
+		Title:      book.Title().String(),
+		Author:     book.Author().String(),
+		BorrowedAt: *book.BorrowedAt(),
+		DueDate:    *book.ReturnDueDate(),
+	}
+	var subject, body bytes.Buffer
+	if err := r.templates[kind].ExecuteTemplate(&subject, "subject", data); err != nil {
+		return ports.Notification{}, err
+	}
+	if err := r.templates[kind].ExecuteTemplate(&body, "body", data); err != nil {
+		return ports.Notification{}, err
+	}
+	return ports.Notification{
+		To:      book.BorrowerEmail(),
+		Subject: subject.String(),
+		Body:    body.String(),
+	}, nil
+}
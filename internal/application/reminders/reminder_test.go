@@ -0,0 +1,163 @@
+package reminders
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"library-system/internal/application/ports"
+	"library-system/internal/domain/catalog"
+)
+
+// stubBookRepository serves loans; only ListDueBefore is used by reminders
+type stubBookRepository struct {
+	catalog.BookRepository
+	books []*catalog.Book
+}
+
+func (r *stubBookRepository) ListDueBefore(ctx context.Context, before time.Time) ([]*catalog.Book, error) {
+	var due []*catalog.Book
+	for _, b := range r.books {
+		if b.IsBorrowed() && b.ReturnDueDate().Before(before) {
+			due = append(due, b)
+		}
+	}
+	return due, nil
+}
+
+type memoryLedger struct {
+	mu   sync.Mutex
+	sent map[Sent]bool
+}
+
+func key(s Sent) Sent {
+	return Sent{BookID: s.BookID, BorrowedAt: s.BorrowedAt, Kind: s.Kind}
+}
+
+func (l *memoryLedger) Claim(ctx context.Context, s Sent) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.sent == nil {
+		l.sent = make(map[Sent]bool)
+	}
+	if l.sent[key(s)] {
+		return false, nil
+	}
+	l.sent[key(s)] = true
+	return true, nil
+}
+
+func (l *memoryLedger) Release(ctx context.Context, s Sent) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.sent, key(s))
+	return nil
+}
+
+func (l *memoryLedger) Forget(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+type recordingNotifier struct {
+	sent []ports.Notification
+	err  error
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, notification ports.Notification) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+var now = time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+// borrowedBook was lent to john@example.com borrowedDaysAgo for loanDays
+func borrowedBook(t *testing.T, title string, borrowedDaysAgo, loanDays int) *catalog.Book {
+	t.Helper()
+	bookTitle, _ := catalog.NewTitle(title)
+	author, _ := catalog.NewAuthor("Frank Herbert")
+	book := catalog.NewBook(catalog.GenerateBookID(), bookTitle, author)
+	policy, _ := catalog.NewLoanPolicy(loanDays)
+	if err := book.BorrowWithPolicy("john@example.com", now.AddDate(0, 0, -borrowedDaysAgo), policy); err != nil {
+		t.Fatal(err)
+	}
+	return book
+}
+
+func newTestReminder(t *testing.T, books []*catalog.Book, notifier *recordingNotifier) *Reminder {
+	t.Helper()
+	r, err := NewReminder(&stubBookRepository{books: books}, &memoryLedger{}, notifier, 2, time.Hour)
+	if err != nil {
+		t.Fatalf("expected templates to parse, got %v", err)
+	}
+	r.now = func() time.Time { return now }
+	return r
+}
+
+func TestSweep_RemindsDueSoonAndOverdueOnce(t *testing.T) {
+	notifier := &recordingNotifier{}
+	r := newTestReminder(t, []*catalog.Book{
+		borrowedBook(t, "Dune", 13, 14),       // due tomorrow
+		borrowedBook(t, "Emma", 20, 14),       // six days overdue
+		borrowedBook(t, "Middlemarch", 1, 14), // due in 13 days
+	}, notifier)
+
+	sent, err := r.Sweep(context.Background())
+
+	if err != nil || sent != 2 {
+		t.Fatalf("expected 2 reminders, got %d (%v)", sent, err)
+	}
+	if !strings.Contains(notifier.sent[0].Subject, "Dune") || !strings.Contains(notifier.sent[0].Subject, "is due back on Tuesday 11 March") {
+		t.Errorf("unexpected due-soon subject %q", notifier.sent[0].Subject)
+	}
+	if notifier.sent[1].Subject != `"Emma" is overdue` || notifier.sent[1].To != "john@example.com" {
+		t.Errorf("unexpected overdue reminder %+v", notifier.sent[1])
+	}
+	if !strings.Contains(notifier.sent[1].Body, "was due back on Tuesday 4 March 2025") {
+		t.Errorf("expected the due date in the body, got %q", notifier.sent[1].Body)
+	}
+
+	if sent, _ := r.Sweep(context.Background()); sent != 0 || len(notifier.sent) != 2 {
+		t.Errorf("expected no reminder to be sent twice, got %d more", sent)
+	}
+}
+
+func TestSweep_OverdueFollowsDueSoon(t *testing.T) {
+	notifier := &recordingNotifier{}
+	r := newTestReminder(t, []*catalog.Book{borrowedBook(t, "Dune", 13, 14)}, notifier)
+	_, _ = r.Sweep(context.Background())
+
+	r.now = func() time.Time { return now.AddDate(0, 0, 2) }
+	sent, _ := r.Sweep(context.Background())
+
+	if sent != 1 || notifier.sent[1].Subject != `"Dune" is overdue` {
+		t.Errorf("expected an overdue reminder after the due-soon one, got %+v", notifier.sent)
+	}
+}
+
+func TestSweep_SkipsLoansShorterThanLead(t *testing.T) {
+	notifier := &recordingNotifier{}
+	r := newTestReminder(t, []*catalog.Book{borrowedBook(t, "Dune", 0, 1)}, notifier)
+
+	if sent, _ := r.Sweep(context.Background()); sent != 0 {
+		t.Errorf("expected a one-day loan not to be warned about when made, got %d", sent)
+	}
+}
+
+func TestSweep_RetriesFailedNotifications(t *testing.T) {
+	notifier := &recordingNotifier{err: errors.New("mail server down")}
+	r := newTestReminder(t, []*catalog.Book{borrowedBook(t, "Emma", 20, 14)}, notifier)
+
+	if sent, _ := r.Sweep(context.Background()); sent != 0 {
+		t.Fatalf("expected nothing sent while the notifier fails, got %d", sent)
+	}
+	notifier.err = nil
+	if sent, _ := r.Sweep(context.Background()); sent != 1 {
+		t.Errorf("expected the reminder to be retried, got %d", sent)
+	}
+}
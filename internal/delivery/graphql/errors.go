@@ -0,0 +1,66 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/domain/catalog"
+	"library-system/internal/domain/shared"
+)
+
+// resolverError is what resolvers return: the error message plus an
+// extensions.code clients can switch on, mirroring the HTTP statuses
+type resolverError struct {
+	code    string
+	message string
+}
+
+func (e resolverError) Error() string {
+	return e.message
+}
+
+// Extensions is picked up by graphql-go and rendered as "extensions"
+func (e resolverError) Extensions() map[string]any {
+	return map[string]any{"code": e.code}
+}
+
+// codeFor translates an application or domain error to an error code
+func codeFor(err error) string {
+	switch {
+	case errors.Is(err, catalog.ErrBookNotFound),
+		errors.Is(err, shared.ErrNotFound):
+		return "NOT_FOUND"
+	case errors.Is(err, catalog.ErrBookAlreadyBorrowed),
+		errors.Is(err, catalog.ErrBookNotBorrowed),
+		errors.Is(err, catalog.ErrBookOnLoan),
+		errors.Is(err, shared.ErrConflict):
+		return "CONFLICT"
+	case errors.Is(err, catalog.ErrBookIDEmpty),
+		errors.Is(err, catalog.ErrBookIDInvalidFormat),
+		errors.Is(err, catalog.ErrBorrowerEmailRequired),
+		errors.Is(err, shared.ErrValidation):
+		return "BAD_REQUEST"
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return "UNAUTHENTICATED"
+	case errors.Is(err, auth.ErrForbidden):
+		return "FORBIDDEN"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "TIMEOUT"
+	default:
+		return "INTERNAL"
+	}
+}
+
+// resolveError converts err for the response. Unexpected errors are logged
+// and replaced with a generic message so internals don't leak.
+func resolveError(ctx context.Context, err error) error {
+	code := codeFor(err)
+	message := err.Error()
+	if code == "INTERNAL" {
+		slog.ErrorContext(ctx, "resolver failed", "error", err)
+		message = "internal server error"
+	}
+	return resolverError{code: code, message: message}
+}
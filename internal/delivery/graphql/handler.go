@@ -0,0 +1,120 @@
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+const (
+	// maxRequestBytes bounds the request body; queries are small
+	maxRequestBytes = 1 << 20
+	// maxDepth rejects queries nested deeper than any real screen needs,
+	// e.g. book → loan → borrower → loans → book → loan ...
+	maxDepth = 8
+	// maxRootFields bounds the top-level fields of one request, aliases
+	// included, so a single POST can't fan out into hundreds of lookups
+	// or mutations under one rate limit token
+	maxRootFields = 20
+)
+
+// request is a GraphQL-over-HTTP POST body
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Handler serves POST requests with a JSON GraphQL body. Every request
+// gets fresh loaders, so batching and caching never cross requests.
+type Handler struct {
+	schema   *gql.Schema
+	resolver *Resolver
+}
+
+// NewHandler parses the schema against resolver
+func NewHandler(resolver *Resolver) (*Handler, error) {
+	schema, err := gql.ParseSchema(schemaSDL, resolver,
+		gql.UseStringDescriptions(),
+		gql.MaxDepth(maxDepth),
+		gql.Logger(panicLogger{}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{schema: schema, resolver: resolver}, nil
+}
+
+// ServeHTTP executes one query. GraphQL errors are reported in the body
+// with 200, as clients expect; only unreadable requests get 4xx.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeErrors(w, http.StatusMethodNotAllowed, "GraphQL queries must be POSTed")
+		return
+	}
+
+	var req request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&req); err != nil {
+		status := http.StatusBadRequest
+		if errors.As(err, new(*http.MaxBytesError)) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		writeErrors(w, status, "invalid GraphQL request: "+err.Error())
+		return
+	}
+
+	ctx := withLoaders(r.Context(), newLoaders(h.resolver.getBooks, h.resolver.getLoans))
+	ctx = withRootFieldBudget(ctx, maxRootFields)
+	response := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(ctx, "failed to write GraphQL response", "error", err)
+	}
+}
+
+type rootFieldsKey struct{}
+
+// withRootFieldBudget allows n top-level fields to resolve in ctx
+func withRootFieldBudget(ctx context.Context, n int32) context.Context {
+	left := new(atomic.Int32)
+	left.Store(n)
+	return context.WithValue(ctx, rootFieldsKey{}, left)
+}
+
+// spendRootField is called by every top-level resolver and fails once the
+// request has used up its budget
+func spendRootField(ctx context.Context) error {
+	if left, ok := ctx.Value(rootFieldsKey{}).(*atomic.Int32); ok && left.Add(-1) < 0 {
+		return resolverError{code: "BAD_REQUEST", message: fmt.Sprintf("at most %d top-level fields, aliases included, per request", maxRootFields)}
+	}
+	return nil
+}
+
+// writeErrors writes a request-level error in the GraphQL response shape
+func writeErrors(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"errors": []map[string]string{{"message": message}},
+	})
+}
+
+// panicLogger reports resolver panics through slog instead of the
+// standard logger, so they carry the request ID
+type panicLogger struct{}
+
+func (panicLogger) LogPanic(ctx context.Context, value any) {
+	slog.ErrorContext(ctx, "graphql resolver panicked", "panic", value)
+}
@@ -0,0 +1,185 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"library-system/internal/application/commands"
+	"library-system/internal/application/queries"
+	"library-system/internal/domain/catalog"
+)
+
+// handlerFunc adapts a function to cqrs.Handler
+type handlerFunc[Req, Res any] func(context.Context, Req) (Res, error)
+
+func (f handlerFunc[Req, Res]) Handle(ctx context.Context, req Req) (Res, error) {
+	return f(ctx, req)
+}
+
+func unused[Req, Res any]() handlerFunc[Req, Res] {
+	return func(context.Context, Req) (Res, error) {
+		var res Res
+		return res, nil
+	}
+}
+
+var due = time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+
+const missingBookID = "00000000-0000-0000-0000-000000000000"
+
+// batchCounts is how many batched queries a request needed
+type batchCounts struct {
+	books atomic.Int32
+	loans atomic.Int32
+}
+
+// serveQuery runs query against a catalog of three books, each borrowed
+// by a different reader, and reports how many batches were needed
+func serveQuery(t *testing.T, query string) (map[string]any, *batchCounts) {
+	t.Helper()
+	batches := &batchCounts{}
+	getBooks := handlerFunc[queries.GetBooksQuery, queries.GetBooksResult](func(ctx context.Context, q queries.GetBooksQuery) (queries.GetBooksResult, error) {
+		batches.books.Add(1)
+		var result queries.GetBooksResult
+		for _, id := range q.BookIDs {
+			if id == missingBookID {
+				continue
+			}
+			result.Books = append(result.Books, queries.GetBookResult{
+				ID: id, Title: "Book " + id, IsBorrowed: true, BorrowerEmail: id + "@example.com", BorrowedAt: &due, ReturnDueDate: &due,
+			})
+		}
+		return result, nil
+	})
+	getLoans := handlerFunc[queries.GetLoansQuery, queries.GetLoansResult](func(ctx context.Context, q queries.GetLoansQuery) (queries.GetLoansResult, error) {
+		batches.loans.Add(1)
+		var result queries.GetLoansResult
+		for _, email := range q.BorrowerEmails {
+			result.Borrowers = append(result.Borrowers, queries.ListLoansResult{BorrowerEmail: email, Loans: []queries.LoanSummary{
+				{BookID: strings.TrimSuffix(email, "@example.com"), BorrowedAt: &due, ReturnDueDate: &due},
+			}})
+		}
+		return result, nil
+	})
+	listBooks := handlerFunc[queries.ListBooksQuery, queries.ListBooksResult](func(ctx context.Context, q queries.ListBooksQuery) (queries.ListBooksResult, error) {
+		return queries.ListBooksResult{Total: 3, Limit: q.Limit, Books: []queries.BookSummary{
+			{ID: "a", IsBorrowed: true}, {ID: "b", IsBorrowed: true}, {ID: "c", IsBorrowed: true},
+		}}, nil
+	})
+	getBook := handlerFunc[queries.GetBookQuery, queries.GetBookResult](func(ctx context.Context, q queries.GetBookQuery) (queries.GetBookResult, error) {
+		return queries.GetBookResult{}, catalog.ErrBookNotFound
+	})
+
+	h, err := NewHandler(NewResolver(
+		unused[commands.AddBookCommand, commands.AddBookResult](),
+		unused[commands.BorrowBookCommand, commands.BorrowBookResult](),
+		unused[commands.ReturnBookCommand, commands.ReturnBookResult](),
+		unused[commands.RemoveBookCommand, commands.RemoveBookResult](),
+		getBook,
+		getBooks,
+		listBooks,
+		unused[queries.ListLoansQuery, queries.ListLoansResult](),
+		getLoans,
+	))
+	if err != nil {
+		t.Fatalf("schema does not match resolvers: %v", err)
+	}
+
+	body, _ := json.Marshal(request{Query: query})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+		t.Fatal(err)
+	}
+	return out, batches
+}
+
+func TestHandler_BatchesLoanLookupsAcrossAList(t *testing.T) {
+	out, batches := serveQuery(t, `{ books(limit: 3) { total books { id loan { returnDueDate book { title } } } } }`)
+
+	if out["errors"] != nil {
+		t.Fatalf("unexpected errors: %v", out["errors"])
+	}
+	if n := batches.books.Load(); n != 1 {
+		t.Errorf("expected one GetBooks batch for three loans, got %d", n)
+	}
+	books := out["data"].(map[string]any)["books"].(map[string]any)["books"].([]any)
+	loan := books[2].(map[string]any)["loan"].(map[string]any)
+	if loan["returnDueDate"] != "2026-01-15T00:00:00Z" || loan["book"].(map[string]any)["title"] != "Book c" {
+		t.Errorf("expected loan of book c, got %v", loan)
+	}
+}
+
+func TestHandler_BatchesBorrowerLoansAcrossAList(t *testing.T) {
+	out, batches := serveQuery(t, `{ books(limit: 3) { books { loan { borrower { email loans { book { id } } } } } } }`)
+
+	if out["errors"] != nil {
+		t.Fatalf("unexpected errors: %v", out["errors"])
+	}
+	if n := batches.loans.Load(); n != 1 {
+		t.Errorf("expected one GetLoans batch for three borrowers, got %d", n)
+	}
+	books := out["data"].(map[string]any)["books"].(map[string]any)["books"].([]any)
+	borrower := books[1].(map[string]any)["loan"].(map[string]any)["borrower"].(map[string]any)
+	loans := borrower["loans"].([]any)
+	if borrower["email"] != "b@example.com" || len(loans) != 1 || loans[0].(map[string]any)["book"].(map[string]any)["id"] != "b" {
+		t.Errorf("expected b@example.com's loan of book b, got %v", borrower)
+	}
+}
+
+func TestHandler_MissingBookIsNull(t *testing.T) {
+	out, _ := serveQuery(t, `{ book(id: "`+missingBookID+`") { id } }`)
+
+	if out["errors"] != nil || out["data"].(map[string]any)["book"] != nil {
+		t.Errorf("expected null book without errors, got %v", out)
+	}
+}
+
+func TestHandler_BatchesAliasedBookLookups(t *testing.T) {
+	out, batches := serveQuery(t, `{
+		a: book(id: "0b4f3c1e-8d2a-4c6b-9e1f-2a3b4c5d6e7f") { title }
+		b: book(id: "1c5a4d2f-9e3b-4d7c-8f2a-3b4c5d6e7f80") { title }
+	}`)
+
+	if out["errors"] != nil {
+		t.Fatalf("unexpected errors: %v", out["errors"])
+	}
+	if n := batches.books.Load(); n != 1 {
+		t.Errorf("expected both books in one batch, got %d batches", n)
+	}
+}
+
+func TestHandler_LimitsRootFields(t *testing.T) {
+	var query strings.Builder
+	query.WriteString("{")
+	for i := range maxRootFields + 1 {
+		fmt.Fprintf(&query, " b%d: books(limit: 1) { total }", i)
+	}
+	query.WriteString(" }")
+
+	out, _ := serveQuery(t, query.String())
+
+	errs, _ := out["errors"].([]any)
+	if len(errs) != 1 || !strings.Contains(fmt.Sprint(errs[0]), "top-level fields") {
+		t.Errorf("expected one field over the limit to be rejected, got %v", out["errors"])
+	}
+}
+
+func TestHandler_ErrorsCarryCode(t *testing.T) {
+	err := resolveError(context.Background(), catalog.ErrBookAlreadyBorrowed)
+
+	if ext := err.(resolverError).Extensions(); ext["code"] != "CONFLICT" {
+		t.Errorf("expected CONFLICT, got %v", ext)
+	}
+}
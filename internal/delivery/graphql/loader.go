@@ -0,0 +1,110 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	"github.com/graph-gophers/dataloader/v7"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/cqrs"
+	"library-system/internal/application/queries"
+	"library-system/internal/domain/catalog"
+)
+
+// loaderWait is how long a loader collects keys before fetching them.
+// Sibling fields resolve concurrently, so a short window is enough to
+// gather every book in a list.
+const loaderWait = time.Millisecond
+
+type loadersKey struct{}
+
+// loaders batch the lookups of one request so that a list of N books
+// costs one query instead of N, and so do their N borrowers' loans. They
+// also cache, so they must never outlive the request that created them.
+type loaders struct {
+	books *dataloader.Loader[string, *queries.GetBookResult]
+	loans *dataloader.Loader[string, *queries.ListLoansResult]
+}
+
+func newLoaders(
+	getBooks cqrs.Handler[queries.GetBooksQuery, queries.GetBooksResult],
+	getLoans cqrs.Handler[queries.GetLoansQuery, queries.GetLoansResult],
+) *loaders {
+	return &loaders{
+		books: dataloader.NewBatchedLoader(batchBooks(getBooks),
+			dataloader.WithWait[string, *queries.GetBookResult](loaderWait),
+			dataloader.WithBatchCapacity[string, *queries.GetBookResult](queries.MaxLimit),
+		),
+		loans: dataloader.NewBatchedLoader(batchLoans(getLoans),
+			dataloader.WithWait[string, *queries.ListLoansResult](loaderWait),
+			dataloader.WithBatchCapacity[string, *queries.ListLoansResult](queries.MaxLimit),
+		),
+	}
+}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// batchBooks fetches a batch of books with one GetBooks query. Keys with
+// no book get catalog.ErrBookNotFound.
+func batchBooks(getBooks cqrs.Handler[queries.GetBooksQuery, queries.GetBooksResult]) dataloader.BatchFunc[string, *queries.GetBookResult] {
+	return func(ctx context.Context, ids []string) []*dataloader.Result[*queries.GetBookResult] {
+		results := make([]*dataloader.Result[*queries.GetBookResult], len(ids))
+
+		found, err := getBooks.Handle(ctx, queries.GetBooksQuery{BookIDs: ids})
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[*queries.GetBookResult]{Error: err}
+			}
+			return results
+		}
+
+		byID := make(map[string]*queries.GetBookResult, len(found.Books))
+		for i := range found.Books {
+			byID[found.Books[i].ID] = &found.Books[i]
+		}
+		for i, id := range ids {
+			if book, ok := byID[id]; ok {
+				results[i] = &dataloader.Result[*queries.GetBookResult]{Data: book}
+			} else {
+				results[i] = &dataloader.Result[*queries.GetBookResult]{Error: catalog.ErrBookNotFound}
+			}
+		}
+		return results
+	}
+}
+
+// batchLoans fetches the loans of a batch of borrowers with one GetLoans
+// query. Borrowers the caller may not view get auth.ErrForbidden.
+func batchLoans(getLoans cqrs.Handler[queries.GetLoansQuery, queries.GetLoansResult]) dataloader.BatchFunc[string, *queries.ListLoansResult] {
+	return func(ctx context.Context, emails []string) []*dataloader.Result[*queries.ListLoansResult] {
+		results := make([]*dataloader.Result[*queries.ListLoansResult], len(emails))
+
+		found, err := getLoans.Handle(ctx, queries.GetLoansQuery{BorrowerEmails: emails})
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[*queries.ListLoansResult]{Error: err}
+			}
+			return results
+		}
+
+		byEmail := make(map[string]*queries.ListLoansResult, len(found.Borrowers))
+		for i := range found.Borrowers {
+			byEmail[found.Borrowers[i].BorrowerEmail] = &found.Borrowers[i]
+		}
+		for i, email := range emails {
+			if loans, ok := byEmail[email]; ok {
+				results[i] = &dataloader.Result[*queries.ListLoansResult]{Data: loans}
+			} else {
+				results[i] = &dataloader.Result[*queries.ListLoansResult]{Error: auth.ErrForbidden}
+			}
+		}
+		return results
+	}
+}
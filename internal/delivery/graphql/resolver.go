@@ -0,0 +1,315 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	gql "github.com/graph-gophers/graphql-go"
+
+	"library-system/internal/application/commands"
+	"library-system/internal/application/cqrs"
+	"library-system/internal/application/queries"
+	"library-system/internal/domain/catalog"
+)
+
+// Resolver is the root resolver. Queries and mutations call the same
+// command and query handlers as the HTTP and gRPC APIs.
+type Resolver struct {
+	addBook    cqrs.Handler[commands.AddBookCommand, commands.AddBookResult]
+	borrowBook cqrs.Handler[commands.BorrowBookCommand, commands.BorrowBookResult]
+	returnBook cqrs.Handler[commands.ReturnBookCommand, commands.ReturnBookResult]
+	removeBook cqrs.Handler[commands.RemoveBookCommand, commands.RemoveBookResult]
+	getBook    cqrs.Handler[queries.GetBookQuery, queries.GetBookResult]
+	getBooks   cqrs.Handler[queries.GetBooksQuery, queries.GetBooksResult]
+	listBooks  cqrs.Handler[queries.ListBooksQuery, queries.ListBooksResult]
+	listLoans  cqrs.Handler[queries.ListLoansQuery, queries.ListLoansResult]
+	getLoans   cqrs.Handler[queries.GetLoansQuery, queries.GetLoansResult]
+}
+
+// NewResolver creates a new root resolver
+func NewResolver(
+	addBook cqrs.Handler[commands.AddBookCommand, commands.AddBookResult],
+	borrowBook cqrs.Handler[commands.BorrowBookCommand, commands.BorrowBookResult],
+	returnBook cqrs.Handler[commands.ReturnBookCommand, commands.ReturnBookResult],
+	removeBook cqrs.Handler[commands.RemoveBookCommand, commands.RemoveBookResult],
+	getBook cqrs.Handler[queries.GetBookQuery, queries.GetBookResult],
+	getBooks cqrs.Handler[queries.GetBooksQuery, queries.GetBooksResult],
+	listBooks cqrs.Handler[queries.ListBooksQuery, queries.ListBooksResult],
+	listLoans cqrs.Handler[queries.ListLoansQuery, queries.ListLoansResult],
+	getLoans cqrs.Handler[queries.GetLoansQuery, queries.GetLoansResult],
+) *Resolver {
+	return &Resolver{
+		addBook:    addBook,
+		borrowBook: borrowBook,
+		returnBook: returnBook,
+		removeBook: removeBook,
+		getBook:    getBook,
+		getBooks:   getBooks,
+		listBooks:  listBooks,
+		listLoans:  listLoans,
+		getLoans:   getLoans,
+	}
+}
+
+// Book resolves Query.book through the request's loader, so aliased
+// lookups of several books are fetched together
+func (r *Resolver) Book(ctx context.Context, args struct{ ID gql.ID }) (*bookResolver, error) {
+	if err := spendRootField(ctx); err != nil {
+		return nil, err
+	}
+	id, err := catalog.ParseBookID(string(args.ID))
+	if err != nil {
+		// Checked here so one bad ID can't fail the whole batch
+		return nil, resolveError(ctx, err)
+	}
+	book, err := loadersFrom(ctx).books.Load(ctx, id.String())()
+	if err != nil {
+		if errors.Is(err, catalog.ErrBookNotFound) {
+			return nil, nil
+		}
+		return nil, resolveError(ctx, err)
+	}
+	return &bookResolver{r: r, book: *book, complete: true}, nil
+}
+
+// Books resolves Query.books
+func (r *Resolver) Books(ctx context.Context, args struct {
+	Limit  int32
+	Offset int32
+}) (*bookPageResolver, error) {
+	if err := spendRootField(ctx); err != nil {
+		return nil, err
+	}
+	result, err := r.listBooks.Handle(ctx, queries.ListBooksQuery{
+		Limit:  int(args.Limit),
+		Offset: int(args.Offset),
+	})
+	if err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	return &bookPageResolver{r: r, page: result}, nil
+}
+
+// Borrower resolves Query.borrower
+func (r *Resolver) Borrower(ctx context.Context, args struct{ Email *string }) (*borrowerResolver, error) {
+	if err := spendRootField(ctx); err != nil {
+		return nil, err
+	}
+	var email string
+	if args.Email != nil {
+		email = *args.Email
+	}
+	loans, err := r.listLoans.Handle(ctx, queries.ListLoansQuery{BorrowerEmail: email})
+	if err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	return &borrowerResolver{r: r, email: loans.BorrowerEmail, loans: &loans}, nil
+}
+
+// AddBook resolves Mutation.addBook
+func (r *Resolver) AddBook(ctx context.Context, args struct {
+	Title  string
+	Author string
+}) (*bookResolver, error) {
+	if err := spendRootField(ctx); err != nil {
+		return nil, err
+	}
+	result, err := r.addBook.Handle(ctx, commands.AddBookCommand{
+		Title:  args.Title,
+		Author: args.Author,
+	})
+	if err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	return &bookResolver{r: r, complete: true, book: queries.GetBookResult{
+		ID:         result.ID,
+		Title:      result.Title,
+		Author:     result.Author,
+		IsBorrowed: result.IsBorrowed,
+	}}, nil
+}
+
+// BorrowBook resolves Mutation.borrowBook
+func (r *Resolver) BorrowBook(ctx context.Context, args struct {
+	ID         gql.ID
+	OnBehalfOf *string
+}) (*bookResolver, error) {
+	if err := spendRootField(ctx); err != nil {
+		return nil, err
+	}
+	var onBehalfOf string
+	if args.OnBehalfOf != nil {
+		onBehalfOf = *args.OnBehalfOf
+	}
+	if _, err := r.borrowBook.Handle(ctx, commands.BorrowBookCommand{
+		BookID:     string(args.ID),
+		OnBehalfOf: onBehalfOf,
+	}); err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	return r.reload(ctx, string(args.ID))
+}
+
+// ReturnBook resolves Mutation.returnBook
+func (r *Resolver) ReturnBook(ctx context.Context, args struct{ ID gql.ID }) (*bookResolver, error) {
+	if err := spendRootField(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := r.returnBook.Handle(ctx, commands.ReturnBookCommand{
+		BookID: string(args.ID),
+	}); err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	return r.reload(ctx, string(args.ID))
+}
+
+// RemoveBook resolves Mutation.removeBook
+func (r *Resolver) RemoveBook(ctx context.Context, args struct{ ID gql.ID }) (gql.ID, error) {
+	if err := spendRootField(ctx); err != nil {
+		return "", err
+	}
+	result, err := r.removeBook.Handle(ctx, commands.RemoveBookCommand{
+		BookID: string(args.ID),
+	})
+	if err != nil {
+		return "", resolveError(ctx, err)
+	}
+	return gql.ID(result.BookID), nil
+}
+
+// reload reads a book back from the primary after a mutation, so the
+// response reflects the write rather than a lagging replica
+func (r *Resolver) reload(ctx context.Context, id string) (*bookResolver, error) {
+	var fresh time.Duration
+	book, err := r.getBook.Handle(ctx, queries.GetBookQuery{BookID: id, MaxStaleness: &fresh})
+	if err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	return &bookResolver{r: r, book: book, complete: true}, nil
+}
+
+// bookResolver resolves Book. Listings only carry a summary, so loan
+// details are fetched through the request's loader when asked for.
+type bookResolver struct {
+	r        *Resolver
+	book     queries.GetBookResult
+	complete bool
+}
+
+func (b *bookResolver) ID() gql.ID       { return gql.ID(b.book.ID) }
+func (b *bookResolver) Title() string    { return b.book.Title }
+func (b *bookResolver) Author() string   { return b.book.Author }
+func (b *bookResolver) IsBorrowed() bool { return b.book.IsBorrowed }
+
+func (b *bookResolver) Loan(ctx context.Context) (*loanResolver, error) {
+	if !b.book.IsBorrowed {
+		return nil, nil
+	}
+	book := b.book
+	if !b.complete {
+		loaded, err := loadersFrom(ctx).books.Load(ctx, book.ID)()
+		if err != nil {
+			return nil, resolveError(ctx, err)
+		}
+		book = *loaded
+	}
+	if !book.IsBorrowed || book.BorrowedAt == nil || book.ReturnDueDate == nil {
+		return nil, nil // returned since the listing was read
+	}
+	return &loanResolver{
+		r:             b.r,
+		book:          &bookResolver{r: b.r, book: book, complete: true},
+		borrowerEmail: book.BorrowerEmail,
+		borrowedAt:    *book.BorrowedAt,
+		returnDueDate: *book.ReturnDueDate,
+	}, nil
+}
+
+// loanResolver resolves Loan
+type loanResolver struct {
+	r             *Resolver
+	book          *bookResolver
+	borrowerEmail string // Empty when the caller may not see it
+	borrowedAt    time.Time
+	returnDueDate time.Time
+}
+
+func (l *loanResolver) Book() *bookResolver     { return l.book }
+func (l *loanResolver) BorrowedAt() gql.Time    { return gql.Time{Time: l.borrowedAt} }
+func (l *loanResolver) ReturnDueDate() gql.Time { return gql.Time{Time: l.returnDueDate} }
+func (l *loanResolver) Borrower() *borrowerResolver {
+	if l.borrowerEmail == "" {
+		return nil
+	}
+	return &borrowerResolver{r: l.r, email: l.borrowerEmail}
+}
+
+// borrowerResolver resolves Borrower. When it was reached through a book,
+// loans are listed on first use through the request's loader, so the
+// borrowers of a list of books cost one query rather than one each.
+type borrowerResolver struct {
+	r     *Resolver
+	email string
+	loans *queries.ListLoansResult
+}
+
+func (b *borrowerResolver) Email() string { return b.email }
+
+func (b *borrowerResolver) Loans(ctx context.Context) ([]*loanResolver, error) {
+	result := b.loans
+	if result == nil {
+		loans, err := loadersFrom(ctx).loans.Load(ctx, b.email)()
+		if err != nil {
+			return nil, resolveError(ctx, err)
+		}
+		result = loans
+	}
+
+	out := make([]*loanResolver, 0, len(result.Loans))
+	for _, loan := range result.Loans {
+		if loan.BorrowedAt == nil || loan.ReturnDueDate == nil {
+			continue
+		}
+		book := queries.GetBookResult{
+			ID:            loan.BookID,
+			Title:         loan.Title,
+			Author:        loan.Author,
+			IsBorrowed:    true,
+			BorrowerEmail: result.BorrowerEmail,
+			BorrowedAt:    loan.BorrowedAt,
+			ReturnDueDate: loan.ReturnDueDate,
+		}
+		out = append(out, &loanResolver{
+			r:             b.r,
+			book:          &bookResolver{r: b.r, book: book, complete: true},
+			borrowerEmail: result.BorrowerEmail,
+			borrowedAt:    *loan.BorrowedAt,
+			returnDueDate: *loan.ReturnDueDate,
+		})
+	}
+	return out, nil
+}
+
+// bookPageResolver resolves BookPage
+type bookPageResolver struct {
+	r    *Resolver
+	page queries.ListBooksResult
+}
+
+func (p *bookPageResolver) Total() int32  { return int32(p.page.Total) }
+func (p *bookPageResolver) Limit() int32  { return int32(p.page.Limit) }
+func (p *bookPageResolver) Offset() int32 { return int32(p.page.Offset) }
+
+func (p *bookPageResolver) Books() []*bookResolver {
+	books := make([]*bookResolver, len(p.page.Books))
+	for i, b := range p.page.Books {
+		books[i] = &bookResolver{r: p.r, book: queries.GetBookResult{
+			ID:         b.ID,
+			Title:      b.Title,
+			Author:     b.Author,
+			IsBorrowed: b.IsBorrowed,
+		}}
+	}
+	return books
+}
@@ -0,0 +1,752 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v6.32.1
+// source: library/v1/books.proto
+
+package librarypb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Book struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title      string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Author     string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	IsBorrowed bool                   `protobuf:"varint,4,opt,name=is_borrowed,json=isBorrowed,proto3" json:"is_borrowed,omitempty"`
+	// Only set for librarians and the borrower
+	BorrowerEmail string                 `protobuf:"bytes,5,opt,name=borrower_email,json=borrowerEmail,proto3" json:"borrower_email,omitempty"`
+	BorrowedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=borrowed_at,json=borrowedAt,proto3" json:"borrowed_at,omitempty"`
+	ReturnDueDate *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=return_due_date,json=returnDueDate,proto3" json:"return_due_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Book) Reset() {
+	*x = Book{}
+	mi := &file_library_v1_books_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Book) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Book) ProtoMessage() {}
+
+func (x *Book) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_books_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Book.ProtoReflect.Descriptor instead.
+func (*Book) Descriptor() ([]byte, []int) {
+	return file_library_v1_books_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Book) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Book) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Book) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Book) GetIsBorrowed() bool {
+	if x != nil {
+		return x.IsBorrowed
+	}
+	return false
+}
+
+func (x *Book) GetBorrowerEmail() string {
+	if x != nil {
+		return x.BorrowerEmail
+	}
+	return ""
+}
+
+func (x *Book) GetBorrowedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BorrowedAt
+	}
+	return nil
+}
+
+func (x *Book) GetReturnDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReturnDueDate
+	}
+	return nil
+}
+
+type AddBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Author        string                 `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddBookRequest) Reset() {
+	*x = AddBookRequest{}
+	mi := &file_library_v1_books_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddBookRequest) ProtoMessage() {}
+
+func (x *AddBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_books_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddBookRequest.ProtoReflect.Descriptor instead.
+func (*AddBookRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_books_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AddBookRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *AddBookRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+type GetBookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Bounds how old the data may be. Unset reads from any healthy replica;
+	// zero forces a read from the primary.
+	MaxStaleness  *durationpb.Duration `protobuf:"bytes,2,opt,name=max_staleness,json=maxStaleness,proto3" json:"max_staleness,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBookRequest) Reset() {
+	*x = GetBookRequest{}
+	mi := &file_library_v1_books_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookRequest) ProtoMessage() {}
+
+func (x *GetBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_books_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookRequest.ProtoReflect.Descriptor instead.
+func (*GetBookRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_books_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetBookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetBookRequest) GetMaxStaleness() *durationpb.Duration {
+	if x != nil {
+		return x.MaxStaleness
+	}
+	return nil
+}
+
+type ListBooksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Defaults to 50, capped at 100
+	Limit         int32                `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	MaxStaleness  *durationpb.Duration `protobuf:"bytes,3,opt,name=max_staleness,json=maxStaleness,proto3" json:"max_staleness,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBooksRequest) Reset() {
+	*x = ListBooksRequest{}
+	mi := &file_library_v1_books_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBooksRequest) ProtoMessage() {}
+
+func (x *ListBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_books_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBooksRequest.ProtoReflect.Descriptor instead.
+func (*ListBooksRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_books_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListBooksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListBooksRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListBooksRequest) GetMaxStaleness() *durationpb.Duration {
+	if x != nil {
+		return x.MaxStaleness
+	}
+	return nil
+}
+
+type ListBooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Books         []*Book                `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBooksResponse) Reset() {
+	*x = ListBooksResponse{}
+	mi := &file_library_v1_books_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBooksResponse) ProtoMessage() {}
+
+func (x *ListBooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_books_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBooksResponse.ProtoReflect.Descriptor instead.
+func (*ListBooksResponse) Descriptor() ([]byte, []int) {
+	return file_library_v1_books_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListBooksResponse) GetBooks() []*Book {
+	if x != nil {
+		return x.Books
+	}
+	return nil
+}
+
+func (x *ListBooksResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListBooksResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListBooksResponse) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type BorrowBookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Borrower email when lending on someone else's behalf
+	OnBehalfOf    string `protobuf:"bytes,2,opt,name=on_behalf_of,json=onBehalfOf,proto3" json:"on_behalf_of,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BorrowBookRequest) Reset() {
+	*x = BorrowBookRequest{}
+	mi := &file_library_v1_books_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BorrowBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BorrowBookRequest) ProtoMessage() {}
+
+func (x *BorrowBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_books_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BorrowBookRequest.ProtoReflect.Descriptor instead.
+func (*BorrowBookRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_books_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BorrowBookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BorrowBookRequest) GetOnBehalfOf() string {
+	if x != nil {
+		return x.OnBehalfOf
+	}
+	return ""
+}
+
+type BorrowBookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookId        string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	BorrowedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=borrowed_at,json=borrowedAt,proto3" json:"borrowed_at,omitempty"`
+	ReturnDueDate *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=return_due_date,json=returnDueDate,proto3" json:"return_due_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BorrowBookResponse) Reset() {
+	*x = BorrowBookResponse{}
+	mi := &file_library_v1_books_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BorrowBookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BorrowBookResponse) ProtoMessage() {}
+
+func (x *BorrowBookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_books_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BorrowBookResponse.ProtoReflect.Descriptor instead.
+func (*BorrowBookResponse) Descriptor() ([]byte, []int) {
+	return file_library_v1_books_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BorrowBookResponse) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
+func (x *BorrowBookResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *BorrowBookResponse) GetBorrowedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BorrowedAt
+	}
+	return nil
+}
+
+func (x *BorrowBookResponse) GetReturnDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReturnDueDate
+	}
+	return nil
+}
+
+type ReturnBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnBookRequest) Reset() {
+	*x = ReturnBookRequest{}
+	mi := &file_library_v1_books_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnBookRequest) ProtoMessage() {}
+
+func (x *ReturnBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_books_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnBookRequest.ProtoReflect.Descriptor instead.
+func (*ReturnBookRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_books_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReturnBookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ReturnBookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookId        string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnBookResponse) Reset() {
+	*x = ReturnBookResponse{}
+	mi := &file_library_v1_books_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnBookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnBookResponse) ProtoMessage() {}
+
+func (x *ReturnBookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_books_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnBookResponse.ProtoReflect.Descriptor instead.
+func (*ReturnBookResponse) Descriptor() ([]byte, []int) {
+	return file_library_v1_books_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReturnBookResponse) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
+func (x *ReturnBookResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type RemoveBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveBookRequest) Reset() {
+	*x = RemoveBookRequest{}
+	mi := &file_library_v1_books_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveBookRequest) ProtoMessage() {}
+
+func (x *RemoveBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_books_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveBookRequest.ProtoReflect.Descriptor instead.
+func (*RemoveBookRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_books_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RemoveBookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_library_v1_books_proto protoreflect.FileDescriptor
+
+const file_library_v1_books_proto_rawDesc = "" +
+	"\n" +
+	"\x16library/v1/books.proto\x12\n" +
+	"library.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8d\x02\n" +
+	"\x04Book\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06author\x18\x03 \x01(\tR\x06author\x12\x1f\n" +
+	"\vis_borrowed\x18\x04 \x01(\bR\n" +
+	"isBorrowed\x12%\n" +
+	"\x0eborrower_email\x18\x05 \x01(\tR\rborrowerEmail\x12;\n" +
+	"\vborrowed_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"borrowedAt\x12B\n" +
+	"\x0freturn_due_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rreturnDueDate\">\n" +
+	"\x0eAddBookRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x16\n" +
+	"\x06author\x18\x02 \x01(\tR\x06author\"`\n" +
+	"\x0eGetBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12>\n" +
+	"\rmax_staleness\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\fmaxStaleness\"\x80\x01\n" +
+	"\x10ListBooksRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12>\n" +
+	"\rmax_staleness\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\fmaxStaleness\"\x7f\n" +
+	"\x11ListBooksResponse\x12&\n" +
+	"\x05books\x18\x01 \x03(\v2\x10.library.v1.BookR\x05books\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"E\n" +
+	"\x11BorrowBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\fon_behalf_of\x18\x02 \x01(\tR\n" +
+	"onBehalfOf\"\xc4\x01\n" +
+	"\x12BorrowBookResponse\x12\x17\n" +
+	"\abook_id\x18\x01 \x01(\tR\x06bookId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12;\n" +
+	"\vborrowed_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"borrowedAt\x12B\n" +
+	"\x0freturn_due_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rreturnDueDate\"#\n" +
+	"\x11ReturnBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"C\n" +
+	"\x12ReturnBookResponse\x12\x17\n" +
+	"\abook_id\x18\x01 \x01(\tR\x06bookId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\"#\n" +
+	"\x11RemoveBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id2\xa8\x03\n" +
+	"\vBookService\x127\n" +
+	"\aAddBook\x12\x1a.library.v1.AddBookRequest\x1a\x10.library.v1.Book\x127\n" +
+	"\aGetBook\x12\x1a.library.v1.GetBookRequest\x1a\x10.library.v1.Book\x12H\n" +
+	"\tListBooks\x12\x1c.library.v1.ListBooksRequest\x1a\x1d.library.v1.ListBooksResponse\x12K\n" +
+	"\n" +
+	"BorrowBook\x12\x1d.library.v1.BorrowBookRequest\x1a\x1e.library.v1.BorrowBookResponse\x12K\n" +
+	"\n" +
+	"ReturnBook\x12\x1d.library.v1.ReturnBookRequest\x1a\x1e.library.v1.ReturnBookResponse\x12C\n" +
+	"\n" +
+	"RemoveBook\x12\x1d.library.v1.RemoveBookRequest\x1a\x16.google.protobuf.EmptyB;Z9library-system/internal/delivery/grpc/librarypb;librarypbb\x06proto3"
+
+var (
+	file_library_v1_books_proto_rawDescOnce sync.Once
+	file_library_v1_books_proto_rawDescData []byte
+)
+
+func file_library_v1_books_proto_rawDescGZIP() []byte {
+	file_library_v1_books_proto_rawDescOnce.Do(func() {
+		file_library_v1_books_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_library_v1_books_proto_rawDesc), len(file_library_v1_books_proto_rawDesc)))
+	})
+	return file_library_v1_books_proto_rawDescData
+}
+
+var file_library_v1_books_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_library_v1_books_proto_goTypes = []any{
+	(*Book)(nil),                  // 0: library.v1.Book
+	(*AddBookRequest)(nil),        // 1: library.v1.AddBookRequest
+	(*GetBookRequest)(nil),        // 2: library.v1.GetBookRequest
+	(*ListBooksRequest)(nil),      // 3: library.v1.ListBooksRequest
+	(*ListBooksResponse)(nil),     // 4: library.v1.ListBooksResponse
+	(*BorrowBookRequest)(nil),     // 5: library.v1.BorrowBookRequest
+	(*BorrowBookResponse)(nil),    // 6: library.v1.BorrowBookResponse
+	(*ReturnBookRequest)(nil),     // 7: library.v1.ReturnBookRequest
+	(*ReturnBookResponse)(nil),    // 8: library.v1.ReturnBookResponse
+	(*RemoveBookRequest)(nil),     // 9: library.v1.RemoveBookRequest
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 11: google.protobuf.Duration
+	(*emptypb.Empty)(nil),         // 12: google.protobuf.Empty
+}
+var file_library_v1_books_proto_depIdxs = []int32{
+	10, // 0: library.v1.Book.borrowed_at:type_name -> google.protobuf.Timestamp
+	10, // 1: library.v1.Book.return_due_date:type_name -> google.protobuf.Timestamp
+	11, // 2: library.v1.GetBookRequest.max_staleness:type_name -> google.protobuf.Duration
+	11, // 3: library.v1.ListBooksRequest.max_staleness:type_name -> google.protobuf.Duration
+	0,  // 4: library.v1.ListBooksResponse.books:type_name -> library.v1.Book
+	10, // 5: library.v1.BorrowBookResponse.borrowed_at:type_name -> google.protobuf.Timestamp
+	10, // 6: library.v1.BorrowBookResponse.return_due_date:type_name -> google.protobuf.Timestamp
+	1,  // 7: library.v1.BookService.AddBook:input_type -> library.v1.AddBookRequest
+	2,  // 8: library.v1.BookService.GetBook:input_type -> library.v1.GetBookRequest
+	3,  // 9: library.v1.BookService.ListBooks:input_type -> library.v1.ListBooksRequest
+	5,  // 10: library.v1.BookService.BorrowBook:input_type -> library.v1.BorrowBookRequest
+	7,  // 11: library.v1.BookService.ReturnBook:input_type -> library.v1.ReturnBookRequest
+	9,  // 12: library.v1.BookService.RemoveBook:input_type -> library.v1.RemoveBookRequest
+	0,  // 13: library.v1.BookService.AddBook:output_type -> library.v1.Book
+	0,  // 14: library.v1.BookService.GetBook:output_type -> library.v1.Book
+	4,  // 15: library.v1.BookService.ListBooks:output_type -> library.v1.ListBooksResponse
+	6,  // 16: library.v1.BookService.BorrowBook:output_type -> library.v1.BorrowBookResponse
+	8,  // 17: library.v1.BookService.ReturnBook:output_type -> library.v1.ReturnBookResponse
+	12, // 18: library.v1.BookService.RemoveBook:output_type -> google.protobuf.Empty
+	13, // [13:19] is the sub-list for method output_type
+	7,  // [7:13] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_library_v1_books_proto_init() }
+func file_library_v1_books_proto_init() {
+	if File_library_v1_books_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_library_v1_books_proto_rawDesc), len(file_library_v1_books_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_library_v1_books_proto_goTypes,
+		DependencyIndexes: file_library_v1_books_proto_depIdxs,
+		MessageInfos:      file_library_v1_books_proto_msgTypes,
+	}.Build()
+	File_library_v1_books_proto = out.File
+	file_library_v1_books_proto_goTypes = nil
+	file_library_v1_books_proto_depIdxs = nil
+}
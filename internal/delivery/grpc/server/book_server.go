@@ -0,0 +1,188 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"library-system/internal/application/commands"
+	"library-system/internal/application/cqrs"
+	"library-system/internal/application/queries"
+	"library-system/internal/delivery/grpc/librarypb"
+)
+
+// BookServer implements librarypb.BookServiceServer on the same command
+// and query handlers as the HTTP BookHandler
+type BookServer struct {
+	librarypb.UnimplementedBookServiceServer
+
+	addBook    cqrs.Handler[commands.AddBookCommand, commands.AddBookResult]
+	borrowBook cqrs.Handler[commands.BorrowBookCommand, commands.BorrowBookResult]
+	returnBook cqrs.Handler[commands.ReturnBookCommand, commands.ReturnBookResult]
+	removeBook cqrs.Handler[commands.RemoveBookCommand, commands.RemoveBookResult]
+	getBook    cqrs.Handler[queries.GetBookQuery, queries.GetBookResult]
+	listBooks  cqrs.Handler[queries.ListBooksQuery, queries.ListBooksResult]
+}
+
+// NewBookServer creates a new server
+func NewBookServer(
+	addBook cqrs.Handler[commands.AddBookCommand, commands.AddBookResult],
+	borrowBook cqrs.Handler[commands.BorrowBookCommand, commands.BorrowBookResult],
+	returnBook cqrs.Handler[commands.ReturnBookCommand, commands.ReturnBookResult],
+	removeBook cqrs.Handler[commands.RemoveBookCommand, commands.RemoveBookResult],
+	getBook cqrs.Handler[queries.GetBookQuery, queries.GetBookResult],
+	listBooks cqrs.Handler[queries.ListBooksQuery, queries.ListBooksResult],
+) *BookServer {
+	return &BookServer{
+		addBook:    addBook,
+		borrowBook: borrowBook,
+		returnBook: returnBook,
+		removeBook: removeBook,
+		getBook:    getBook,
+		listBooks:  listBooks,
+	}
+}
+
+// AddBook handles BookService.AddBook
+func (s *BookServer) AddBook(ctx context.Context, req *librarypb.AddBookRequest) (*librarypb.Book, error) {
+	result, err := s.addBook.Handle(ctx, commands.AddBookCommand{
+		Title:  req.GetTitle(),
+		Author: req.GetAuthor(),
+	})
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return &librarypb.Book{
+		Id:         result.ID,
+		Title:      result.Title,
+		Author:     result.Author,
+		IsBorrowed: result.IsBorrowed,
+	}, nil
+}
+
+// GetBook handles BookService.GetBook
+func (s *BookServer) GetBook(ctx context.Context, req *librarypb.GetBookRequest) (*librarypb.Book, error) {
+	maxStaleness, err := parseMaxStaleness(req.GetMaxStaleness())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.getBook.Handle(ctx, queries.GetBookQuery{
+		BookID:       req.GetId(),
+		MaxStaleness: maxStaleness,
+	})
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return &librarypb.Book{
+		Id:            result.ID,
+		Title:         result.Title,
+		Author:        result.Author,
+		IsBorrowed:    result.IsBorrowed,
+		BorrowerEmail: result.BorrowerEmail,
+		BorrowedAt:    timestamp(result.BorrowedAt),
+		ReturnDueDate: timestamp(result.ReturnDueDate),
+	}, nil
+}
+
+// ListBooks handles BookService.ListBooks
+func (s *BookServer) ListBooks(ctx context.Context, req *librarypb.ListBooksRequest) (*librarypb.ListBooksResponse, error) {
+	maxStaleness, err := parseMaxStaleness(req.GetMaxStaleness())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.listBooks.Handle(ctx, queries.ListBooksQuery{
+		Limit:        int(req.GetLimit()),
+		Offset:       int(req.GetOffset()),
+		MaxStaleness: maxStaleness,
+	})
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	books := make([]*librarypb.Book, len(result.Books))
+	for i, b := range result.Books {
+		books[i] = &librarypb.Book{
+			Id:         b.ID,
+			Title:      b.Title,
+			Author:     b.Author,
+			IsBorrowed: b.IsBorrowed,
+		}
+	}
+	return &librarypb.ListBooksResponse{
+		Books:  books,
+		Total:  int32(result.Total),
+		Limit:  int32(result.Limit),
+		Offset: int32(result.Offset),
+	}, nil
+}
+
+// BorrowBook handles BookService.BorrowBook
+func (s *BookServer) BorrowBook(ctx context.Context, req *librarypb.BorrowBookRequest) (*librarypb.BorrowBookResponse, error) {
+	result, err := s.borrowBook.Handle(ctx, commands.BorrowBookCommand{
+		BookID:     req.GetId(),
+		OnBehalfOf: req.GetOnBehalfOf(),
+	})
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return &librarypb.BorrowBookResponse{
+		BookId:        result.BookID,
+		Title:         result.Title,
+		BorrowedAt:    timestamppb.New(result.BorrowedAt),
+		ReturnDueDate: timestamppb.New(result.ReturnDueDate),
+	}, nil
+}
+
+// ReturnBook handles BookService.ReturnBook
+func (s *BookServer) ReturnBook(ctx context.Context, req *librarypb.ReturnBookRequest) (*librarypb.ReturnBookResponse, error) {
+	result, err := s.returnBook.Handle(ctx, commands.ReturnBookCommand{
+		BookID: req.GetId(),
+	})
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return &librarypb.ReturnBookResponse{
+		BookId: result.BookID,
+		Title:  result.Title,
+	}, nil
+}
+
+// RemoveBook handles BookService.RemoveBook
+func (s *BookServer) RemoveBook(ctx context.Context, req *librarypb.RemoveBookRequest) (*emptypb.Empty, error) {
+	if _, err := s.removeBook.Handle(ctx, commands.RemoveBookCommand{
+		BookID: req.GetId(),
+	}); err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// parseMaxStaleness converts the optional max_staleness field; unset means
+// any healthy replica will do
+func parseMaxStaleness(d *durationpb.Duration) (*time.Duration, error) {
+	if d == nil {
+		return nil, nil
+	}
+	if err := d.CheckValid(); err != nil || d.AsDuration() < 0 {
+		return nil, invalidArgument("max_staleness must be a non-negative duration")
+	}
+	staleness := d.AsDuration()
+	return &staleness, nil
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
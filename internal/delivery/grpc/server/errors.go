@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/domain/catalog"
+	"library-system/internal/domain/shared"
+)
+
+// codeFor translates an application or domain error to a gRPC status code,
+// mirroring the HTTP statusFor mapping
+func codeFor(err error) codes.Code {
+	switch {
+	case errors.Is(err, catalog.ErrBookNotFound),
+		errors.Is(err, shared.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, catalog.ErrBookAlreadyBorrowed),
+		errors.Is(err, catalog.ErrBookNotBorrowed),
+		errors.Is(err, catalog.ErrBookOnLoan),
+		errors.Is(err, shared.ErrConflict):
+		return codes.FailedPrecondition
+	case errors.Is(err, catalog.ErrBookIDEmpty),
+		errors.Is(err, catalog.ErrBookIDInvalidFormat),
+		errors.Is(err, catalog.ErrBorrowerEmailRequired),
+		errors.Is(err, shared.ErrValidation):
+		return codes.InvalidArgument
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return codes.Unauthenticated
+	case errors.Is(err, auth.ErrForbidden):
+		return codes.PermissionDenied
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	default:
+		return codes.Internal
+	}
+}
+
+// statusError converts err to a status error. Unexpected errors are logged
+// and replaced with a generic message so internals don't leak; the request
+// ID in the response header lets support find the log line.
+func statusError(ctx context.Context, err error) error {
+	code := codeFor(err)
+	message := err.Error()
+	if code == codes.Internal {
+		slog.ErrorContext(ctx, "rpc failed", "error", err)
+		message = "internal server error"
+	}
+	return status.Error(code, message)
+}
+
+// invalidArgument is for errors raised by the delivery layer itself
+func invalidArgument(message string) error {
+	return status.Error(codes.InvalidArgument, message)
+}
@@ -0,0 +1,254 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/infrastructure/logging"
+)
+
+const (
+	// RequestIDKey carries the request ID in both directions
+	RequestIDKey = "x-request-id"
+	// APIKeyKey carries API keys for service-to-service clients
+	APIKeyKey = "x-api-key"
+)
+
+// maxRequestIDLength bounds caller-supplied IDs so they can't bloat logs
+const maxRequestIDLength = 128
+
+// TokenVerifier validates a bearer token and returns the caller it identifies
+type TokenVerifier interface {
+	Verify(token string) (auth.Principal, error)
+}
+
+// APIKeyVerifier resolves an API key to the caller it identifies. Invalid
+// keys are reported as auth.ErrUnauthenticated.
+type APIKeyVerifier interface {
+	Authenticate(ctx context.Context, key string) (auth.Principal, error)
+}
+
+// Recoverer turns a panic in a handler into codes.Internal instead of
+// crashing the process
+func Recoverer() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				slog.ErrorContext(ctx, "rpc panicked", "method", info.FullMethod, "panic", r, "stack", string(debug.Stack()))
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// RequestID accepts the caller's x-request-id or generates one, stores it
+// in the context for logging, and echoes it in the response header
+func RequestID() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		id := firstValue(ctx, RequestIDKey)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+
+		ctx = logging.WithRequestID(ctx, id)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, id))
+
+		return handler(ctx, req)
+	}
+}
+
+// Tracing starts a server span per call, continuing any W3C traceparent
+// sent in the metadata
+func Tracing() grpc.UnaryServerInterceptor {
+	tracer := otel.Tracer("library-system/grpc")
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+
+		service, method := splitMethod(info.FullMethod)
+		ctx, span := tracer.Start(ctx, strings.TrimPrefix(info.FullMethod, "/"),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.RPCSystemGRPC,
+				semconv.RPCService(service),
+				semconv.RPCMethod(method),
+				semconv.ClientAddress(peerIP(ctx)),
+			),
+		)
+		defer span.End()
+		if id := logging.RequestID(ctx); id != "" {
+			span.SetAttributes(attribute.String("rpc.request.id", id))
+		}
+
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+		if serverFault(code) {
+			span.SetStatus(otelcodes.Error, code.String())
+		}
+		return resp, err
+	}
+}
+
+// RPCObserver records call metrics
+type RPCObserver interface {
+	ObserveRPC(method, code string, d time.Duration)
+}
+
+// Metrics records latency for every call, labelled by method and code
+func Metrics(observer RPCObserver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		observer.ObserveRPC(info.FullMethod, status.Code(err).String(), time.Since(start))
+		return resp, err
+	}
+}
+
+// Logger logs each call once it completes, with the request context so
+// the request ID and trace ID are attached
+func Logger() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		var client string
+		if p, ok := peer.FromContext(ctx); ok {
+			client = p.Addr.String()
+		}
+		slog.InfoContext(ctx, "rpc",
+			"method", info.FullMethod,
+			"code", status.Code(err).String(),
+			"latency_ms", time.Since(start).Milliseconds(),
+			"client_addr", client,
+		)
+		return resp, err
+	}
+}
+
+// Authenticate requires either x-api-key metadata or a valid
+// "authorization: Bearer <token>" and stores the caller's principal in the
+// context. Calls without credentials, or with ones that fail
+// verification, are rejected with codes.Unauthenticated.
+func Authenticate(tokens TokenVerifier, keys APIKeyVerifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var principal auth.Principal
+		if key := firstValue(ctx, APIKeyKey); key != "" {
+			var err error
+			principal, err = keys.Authenticate(ctx, key)
+			if errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, status.Error(codes.Unauthenticated, "invalid API key")
+			}
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to verify API key", "error", err)
+				return nil, status.Error(codes.Unavailable, "authentication unavailable")
+			}
+		} else {
+			token, ok := bearerToken(firstValue(ctx, "authorization"))
+			if !ok {
+				return nil, status.Error(codes.Unauthenticated, "missing bearer token or API key")
+			}
+			var err error
+			principal, err = tokens.Verify(token)
+			if err != nil {
+				return nil, status.Error(codes.Unauthenticated, "invalid bearer token")
+			}
+		}
+
+		return handler(auth.WithPrincipal(ctx, principal), req)
+	}
+}
+
+// metadataCarrier lets the otel propagator read incoming metadata
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// splitMethod splits "/package.Service/Method" into its service and method
+func splitMethod(fullMethod string) (string, string) {
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	return service, method
+}
+
+// serverFault reports whether a code means the server failed, as opposed
+// to the caller sending a bad request
+func serverFault(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented, codes.Internal, codes.Unavailable, codes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
+
+// firstValue returns the first value of an incoming metadata key
+func firstValue(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// bearerToken extracts the token from an authorization metadata value
+func bearerToken(value string) (string, bool) {
+	scheme, token, ok := strings.Cut(value, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// validRequestID allows printable ASCII without spaces, which covers
+// UUIDs, ULIDs and most tracing IDs
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
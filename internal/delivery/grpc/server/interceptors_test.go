@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/delivery/grpc/librarypb"
+	"library-system/internal/domain/catalog"
+	"library-system/internal/infrastructure/ratelimit"
+)
+
+type stubVerifier struct{}
+
+func (stubVerifier) Verify(token string) (auth.Principal, error) {
+	if token != "good" {
+		return auth.Principal{}, errors.New("bad token")
+	}
+	return auth.Principal{Subject: "user-1", Email: "reader@example.com"}, nil
+}
+
+type stubKeys struct{}
+
+func (stubKeys) Authenticate(ctx context.Context, key string) (auth.Principal, error) {
+	switch key {
+	case "good-key":
+		return auth.Principal{Subject: "apikey:1", Roles: []string{auth.RoleLendingDesk}}, nil
+	case "db-down":
+		return auth.Principal{}, errors.New("connection refused")
+	default:
+		return auth.Principal{}, auth.ErrUnauthenticated
+	}
+}
+
+func call(kv ...string) (codes.Code, auth.Principal) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(kv...))
+	var principal auth.Principal
+	_, err := Authenticate(stubVerifier{}, stubKeys{})(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/library.v1.BookService/GetBook"},
+		func(ctx context.Context, req any) (any, error) {
+			principal, _ = auth.PrincipalFrom(ctx)
+			return nil, nil
+		})
+	return status.Code(err), principal
+}
+
+func TestAuthenticate_ValidTokenSetsPrincipal(t *testing.T) {
+	code, principal := call("authorization", "Bearer good")
+
+	if code != codes.OK || principal.Email != "reader@example.com" {
+		t.Errorf("expected OK with principal, got %v %+v", code, principal)
+	}
+}
+
+func TestAuthenticate_ValidAPIKeySetsPrincipal(t *testing.T) {
+	code, principal := call(APIKeyKey, "good-key")
+
+	if code != codes.OK || principal.Subject != "apikey:1" {
+		t.Errorf("expected OK with key principal, got %v %+v", code, principal)
+	}
+}
+
+func TestAuthenticate_RejectsMissingOrBadCredentials(t *testing.T) {
+	cases := map[string][]string{
+		"none":       nil,
+		"bad token":  {"authorization", "Bearer bad"},
+		"bad scheme": {"authorization", "Basic good"},
+		"bad key":    {APIKeyKey, "nope"},
+	}
+	for name, kv := range cases {
+		if code, _ := call(kv...); code != codes.Unauthenticated {
+			t.Errorf("%s: expected Unauthenticated, got %v", name, code)
+		}
+	}
+}
+
+func TestAuthenticate_KeyStoreFailureIsUnavailable(t *testing.T) {
+	if code, _ := call(APIKeyKey, "db-down"); code != codes.Unavailable {
+		t.Errorf("expected Unavailable, got %v", code)
+	}
+}
+
+func TestCodeFor_MapsDomainErrors(t *testing.T) {
+	cases := map[error]codes.Code{
+		catalog.ErrBookNotFound:        codes.NotFound,
+		catalog.ErrBookAlreadyBorrowed: codes.FailedPrecondition,
+		catalog.ErrBookIDInvalidFormat: codes.InvalidArgument,
+		auth.ErrForbidden:              codes.PermissionDenied,
+		context.DeadlineExceeded:       codes.DeadlineExceeded,
+		errors.New("boom"):             codes.Internal,
+	}
+	for err, want := range cases {
+		if got := codeFor(err); got != want {
+			t.Errorf("codeFor(%v) = %v, want %v", err, got, want)
+		}
+	}
+}
+
+func TestStatusError_HidesInternalErrors(t *testing.T) {
+	err := statusError(context.Background(), errors.New("pq: password authentication failed"))
+
+	if s := status.Convert(err); s.Code() != codes.Internal || s.Message() != "internal server error" {
+		t.Errorf("expected generic internal error, got %v", s)
+	}
+}
+
+func TestRateLimit_SeparateReadAndWriteBudgetsPerPrincipal(t *testing.T) {
+	limit := RateLimit(ratelimit.NewMemoryStore(), ratelimit.Limit{Rate: 0.001, Burst: 2}, ratelimit.Limit{Rate: 0.001, Burst: 1}, ReadMethods())
+	invoke := func(subject, method string) codes.Code {
+		ctx := auth.WithPrincipal(context.Background(), auth.Principal{Subject: subject})
+		_, err := limit(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+			return nil, nil
+		})
+		return status.Code(err)
+	}
+
+	got := []codes.Code{
+		invoke("user-1", librarypb.BookService_BorrowBook_FullMethodName),
+		invoke("user-1", librarypb.BookService_BorrowBook_FullMethodName),
+		invoke("user-1", librarypb.BookService_GetBook_FullMethodName),
+		invoke("user-2", librarypb.BookService_BorrowBook_FullMethodName),
+	}
+
+	want := []codes.Code{codes.OK, codes.ResourceExhausted, codes.OK, codes.OK}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/infrastructure/ratelimit"
+)
+
+// RateLimits configures the gRPC rate limiters. They draw on the same
+// store as the HTTP ones, and PerIP on the same buckets, so a client
+// can't double its budget by switching protocols. A nil Store disables
+// rate limiting.
+type RateLimits struct {
+	Store ratelimit.Store
+	PerIP ratelimit.Limit
+	Read  ratelimit.Limit
+	Write ratelimit.Limit
+	// ReadMethods lists the full method names that draw on the read
+	// budget; every other method draws on the write budget
+	ReadMethods map[string]bool
+}
+
+// RateLimitByIP applies one token-bucket budget per peer IP across all
+// methods. It belongs before Authenticate, so calls with bad credentials
+// are limited too.
+func RateLimitByIP(store ratelimit.Store, limit ratelimit.Limit) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := take(ctx, store, "ip:"+peerIP(ctx), limit); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// RateLimit applies token-bucket budgets per principal and method, so it
+// belongs after Authenticate. Methods in readMethods draw on the read
+// budget, the rest on the smaller write budget.
+//
+// Every response carries ratelimit-limit, ratelimit-remaining and
+// ratelimit-reset headers; rejected calls fail with
+// codes.ResourceExhausted and a retry-after header. If the store fails
+// the call is let through, as over HTTP.
+func RateLimit(store ratelimit.Store, read, write ratelimit.Limit, readMethods map[string]bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		budget, limit := "write", write
+		if readMethods[info.FullMethod] {
+			budget, limit = "read", read
+		}
+		client := "ip:" + peerIP(ctx)
+		if p, ok := auth.PrincipalFrom(ctx); ok {
+			client = p.Subject
+		}
+		if err := take(ctx, store, budget+":"+client+":"+info.FullMethod, limit); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// take draws a token from the bucket named key, returning a
+// codes.ResourceExhausted error if there is none
+func take(ctx context.Context, store ratelimit.Store, key string, limit ratelimit.Limit) error {
+	result, err := store.Take(ctx, key, limit)
+	if err != nil {
+		slog.WarnContext(ctx, "rate limiter unavailable, allowing call", "error", err)
+		return nil
+	}
+
+	md := metadata.Pairs(
+		"ratelimit-limit", strconv.Itoa(result.Limit),
+		"ratelimit-remaining", strconv.Itoa(result.Remaining),
+		"ratelimit-reset", ceilSeconds(result.ResetAfter),
+	)
+	if !result.Allowed {
+		md.Set("retry-after", ceilSeconds(result.RetryAfter))
+	}
+	_ = grpc.SetHeader(ctx, md)
+	if !result.Allowed {
+		return status.Error(codes.ResourceExhausted, "rate limit exceeded, retry later")
+	}
+	return nil
+}
+
+// peerIP returns the IP the call came from, without the port
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// ceilSeconds formats d as whole seconds, rounded up so clients that wait
+// that long are not rejected again
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	"library-system/internal/delivery/grpc/librarypb"
+)
+
+// New creates a gRPC server serving BookService with request IDs,
+// tracing, metrics, logging, authentication and, if limits has a store,
+// rate limiting, plus server reflection so tools such as grpcurl can
+// discover the API. Reflection is served without credentials; it only
+// describes the schema.
+func New(books *BookServer, tokens TokenVerifier, keys APIKeyVerifier, observer RPCObserver, limits RateLimits) *grpc.Server {
+	interceptors := []grpc.UnaryServerInterceptor{
+		Recoverer(),
+		RequestID(),
+		Tracing(),
+		Logger(),
+		Metrics(observer),
+	}
+	if limits.Store != nil {
+		interceptors = append(interceptors,
+			RateLimitByIP(limits.Store, limits.PerIP),
+			Authenticate(tokens, keys),
+			RateLimit(limits.Store, limits.Read, limits.Write, limits.ReadMethods),
+		)
+	} else {
+		interceptors = append(interceptors, Authenticate(tokens, keys))
+	}
+
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...))
+	librarypb.RegisterBookServiceServer(server, books)
+	reflection.Register(server)
+	return server
+}
+
+// ReadMethods are the BookService methods that only read, for
+// RateLimits.ReadMethods
+func ReadMethods() map[string]bool {
+	return map[string]bool{
+		librarypb.BookService_GetBook_FullMethodName:   true,
+		librarypb.BookService_ListBooks_FullMethodName: true,
+	}
+}
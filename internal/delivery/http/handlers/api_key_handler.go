@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"library-system/internal/application/commands"
+	"library-system/internal/application/cqrs"
+	"library-system/internal/application/queries"
+	"library-system/internal/delivery/http/models"
+)
+
+// APIKeyHandler handles API key administration requests
+type APIKeyHandler struct {
+	issue  cqrs.Handler[commands.IssueAPIKeyCommand, commands.IssueAPIKeyResult]
+	rotate cqrs.Handler[commands.RotateAPIKeyCommand, commands.RotateAPIKeyResult]
+	revoke cqrs.Handler[commands.RevokeAPIKeyCommand, commands.RevokeAPIKeyResult]
+	list   cqrs.Handler[queries.ListAPIKeysQuery, queries.ListAPIKeysResult]
+}
+
+// NewAPIKeyHandler creates a new handler
+func NewAPIKeyHandler(
+	issue cqrs.Handler[commands.IssueAPIKeyCommand, commands.IssueAPIKeyResult],
+	rotate cqrs.Handler[commands.RotateAPIKeyCommand, commands.RotateAPIKeyResult],
+	revoke cqrs.Handler[commands.RevokeAPIKeyCommand, commands.RevokeAPIKeyResult],
+	list cqrs.Handler[queries.ListAPIKeysQuery, queries.ListAPIKeysResult],
+) *APIKeyHandler {
+	return &APIKeyHandler{issue: issue, rotate: rotate, revoke: revoke, list: list}
+}
+
+// Issue handles POST /admin/api-keys. The plaintext key is only in this response.
+func (h *APIKeyHandler) Issue(c *gin.Context) {
+	var req models.IssueAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondStatus(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	expiresIn, err := parseOptionalDuration(req.ExpiresIn)
+	if err != nil {
+		respondStatus(c, http.StatusBadRequest, "expires_in must be a duration such as 2160h")
+		return
+	}
+
+	result, err := h.issue.Handle(c.Request.Context(), commands.IssueAPIKeyCommand{
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresIn: expiresIn,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// List handles GET /admin/api-keys
+func (h *APIKeyHandler) List(c *gin.Context) {
+	result, err := h.list.Handle(c.Request.Context(), queries.ListAPIKeysQuery{})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Rotate handles POST /admin/api-keys/:id/rotate
+func (h *APIKeyHandler) Rotate(c *gin.Context) {
+	var req models.RotateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondStatus(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	cmd := commands.RotateAPIKeyCommand{KeyID: c.Param("id")}
+	if req.Overlap != "" {
+		overlap, err := time.ParseDuration(req.Overlap)
+		if err != nil {
+			respondStatus(c, http.StatusBadRequest, "overlap must be a duration such as 24h")
+			return
+		}
+		cmd.Overlap = &overlap
+	}
+
+	result, err := h.rotate.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// Revoke handles DELETE /admin/api-keys/:id
+func (h *APIKeyHandler) Revoke(c *gin.Context) {
+	if _, err := h.revoke.Handle(c.Request.Context(), commands.RevokeAPIKeyCommand{
+		KeyID: c.Param("id"),
+	}); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseOptionalDuration parses a Go duration, treating "" as zero
+func parseOptionalDuration(raw string) (time.Duration, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(raw)
+}
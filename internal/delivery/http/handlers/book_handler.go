@@ -1,37 +1,46 @@
 package handlers
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"library-system/internal/application/commands"
+	"library-system/internal/application/cqrs"
 	"library-system/internal/application/queries"
 	"library-system/internal/delivery/http/models"
 )
 
+var errInvalidMaxStaleness = errors.New("max_staleness must be a non-negative duration such as 0s or 500ms")
+
 // BookHandler handles book HTTP requests
 type BookHandler struct {
-	addBook    *commands.AddBookHandler
-	borrowBook *commands.BorrowBookHandler
-	returnBook *commands.ReturnBookHandler
-	getBook    *queries.GetBookHandler
-	listBooks  *queries.ListBooksHandler
+	addBook    cqrs.Handler[commands.AddBookCommand, commands.AddBookResult]
+	borrowBook cqrs.Handler[commands.BorrowBookCommand, commands.BorrowBookResult]
+	returnBook cqrs.Handler[commands.ReturnBookCommand, commands.ReturnBookResult]
+	removeBook cqrs.Handler[commands.RemoveBookCommand, commands.RemoveBookResult]
+	getBook    cqrs.Handler[queries.GetBookQuery, queries.GetBookResult]
+	listBooks  cqrs.Handler[queries.ListBooksQuery, queries.ListBooksResult]
 }
 
 // NewBookHandler creates a new handler
 func NewBookHandler(
-	addBook *commands.AddBookHandler,
-	borrowBook *commands.BorrowBookHandler,
-	returnBook *commands.ReturnBookHandler,
-	getBook *queries.GetBookHandler,
-	listBooks *queries.ListBooksHandler,
+	addBook cqrs.Handler[commands.AddBookCommand, commands.AddBookResult],
+	borrowBook cqrs.Handler[commands.BorrowBookCommand, commands.BorrowBookResult],
+	returnBook cqrs.Handler[commands.ReturnBookCommand, commands.ReturnBookResult],
+	removeBook cqrs.Handler[commands.RemoveBookCommand, commands.RemoveBookResult],
+	getBook cqrs.Handler[queries.GetBookQuery, queries.GetBookResult],
+	listBooks cqrs.Handler[queries.ListBooksQuery, queries.ListBooksResult],
 ) *BookHandler {
 	return &BookHandler{
 		addBook:    addBook,
 		borrowBook: borrowBook,
 		returnBook: returnBook,
+		removeBook: removeBook,
 		getBook:    getBook,
 		listBooks:  listBooks,
 	}
@@ -41,7 +50,7 @@ func NewBookHandler(
 func (h *BookHandler) AddBook(c *gin.Context) {
 	var req models.AddBookRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondStatus(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -50,7 +59,7 @@ func (h *BookHandler) AddBook(c *gin.Context) {
 		Author: req.Author,
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -61,11 +70,18 @@ func (h *BookHandler) AddBook(c *gin.Context) {
 func (h *BookHandler) GetBook(c *gin.Context) {
 	id := c.Param("id")
 
+	maxStaleness, err := parseMaxStaleness(c)
+	if err != nil {
+		respondStatus(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	result, err := h.getBook.Handle(c.Request.Context(), queries.GetBookQuery{
-		BookID: id,
+		BookID:       id,
+		MaxStaleness: maxStaleness,
 	})
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -77,34 +93,42 @@ func (h *BookHandler) ListBooks(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
+	maxStaleness, err := parseMaxStaleness(c)
+	if err != nil {
+		respondStatus(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	result, err := h.listBooks.Handle(c.Request.Context(), queries.ListBooksQuery{
-		Limit:  limit,
-		Offset: offset,
+		Limit:        limit,
+		Offset:       offset,
+		MaxStaleness: maxStaleness,
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, result)
 }
 
-// BorrowBook handles POST /books/:id/borrow
+// BorrowBook handles POST /books/:id/borrow for the authenticated caller,
+// or for {"on_behalf_of": email} when the caller may lend to others
 func (h *BookHandler) BorrowBook(c *gin.Context) {
 	id := c.Param("id")
 
 	var req models.BorrowBookRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondStatus(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	result, err := h.borrowBook.Handle(c.Request.Context(), commands.BorrowBookCommand{
-		BookID:        id,
-		BorrowerEmail: req.BorrowerEmail,
+		BookID:     id,
+		OnBehalfOf: req.OnBehalfOf,
 	})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -119,9 +143,37 @@ func (h *BookHandler) ReturnBook(c *gin.Context) {
 		BookID: id,
 	})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, result)
 }
+
+// RemoveBook handles DELETE /books/:id
+func (h *BookHandler) RemoveBook(c *gin.Context) {
+	id := c.Param("id")
+
+	if _, err := h.removeBook.Handle(c.Request.Context(), commands.RemoveBookCommand{
+		BookID: id,
+	}); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseMaxStaleness reads the optional max_staleness query parameter
+// (a Go duration such as "0s" or "500ms").
+func parseMaxStaleness(c *gin.Context) (*time.Duration, error) {
+	raw, ok := c.GetQuery("max_staleness")
+	if !ok {
+		return nil, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d < 0 {
+		return nil, errInvalidMaxStaleness
+	}
+	return &d, nil
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"library-system/internal/delivery/http/openapi"
+)
+
+// swaggerUIVersion pins the Swagger UI assets loaded by /docs
+const swaggerUIVersion = "5.17.14"
+
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Library System API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@` + swaggerUIVersion + `/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@` + swaggerUIVersion + `/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>
+`
+
+// DocsHandler publishes the OpenAPI document and a browsable view of it
+type DocsHandler struct {
+	spec *openapi.Spec
+}
+
+// NewDocsHandler creates a new handler
+func NewDocsHandler(spec *openapi.Spec) *DocsHandler {
+	return &DocsHandler{spec: spec}
+}
+
+// Spec handles GET /openapi.json
+func (h *DocsHandler) Spec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json", h.spec.JSON())
+}
+
+// UI handles GET /docs with Swagger UI pointed at /openapi.json
+func (h *DocsHandler) UI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(docsPage))
+}
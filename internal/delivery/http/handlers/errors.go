@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/exports"
+	"library-system/internal/application/imports"
+	"library-system/internal/application/ports"
+	"library-system/internal/domain/access"
+	"library-system/internal/domain/catalog"
+	"library-system/internal/domain/shared"
+	"library-system/internal/domain/webhooks"
+	"library-system/internal/infrastructure/logging"
+)
+
+// errorResponse is the body of every error reply
+type errorResponse struct {
+	Error     string `json:"error"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// importErrorResponse is the body of a failed import that stored some
+// batches before failing, so the client knows where to resume
+type importErrorResponse struct {
+	errorResponse
+	Report imports.Report `json:"report"`
+}
+
+// statusFor translates an application or domain error to an HTTP status
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, catalog.ErrBookNotFound),
+		errors.Is(err, access.ErrAPIKeyNotFound),
+		errors.Is(err, imports.ErrJobNotFound),
+		errors.Is(err, webhooks.ErrSubscriptionNotFound),
+		errors.Is(err, webhooks.ErrDeliveryNotFound),
+		errors.Is(err, shared.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, catalog.ErrBookAlreadyBorrowed),
+		errors.Is(err, catalog.ErrBookNotBorrowed),
+		errors.Is(err, catalog.ErrBookOnLoan),
+		errors.Is(err, access.ErrAPIKeyRevoked),
+		errors.Is(err, access.ErrAPIKeyExpired),
+		errors.Is(err, webhooks.ErrDeliveryNotDead),
+		errors.Is(err, shared.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, catalog.ErrBookIDEmpty),
+		errors.Is(err, catalog.ErrBookIDInvalidFormat),
+		errors.Is(err, catalog.ErrBorrowerEmailRequired),
+		errors.Is(err, access.ErrAPIKeyIDInvalid),
+		errors.Is(err, access.ErrAPIKeyScopesNeeded),
+		errors.Is(err, webhooks.ErrSubscriptionIDInvalid),
+		errors.Is(err, shared.ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(err, imports.ErrUnsupportedFormat),
+		errors.Is(err, exports.ErrUnsupportedFormat):
+		return http.StatusUnsupportedMediaType
+	case errors.As(err, new(*http.MaxBytesError)):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return http.StatusUnauthorized
+	case errors.Is(err, auth.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ports.ErrTaskQueueFull),
+		errors.Is(err, ports.ErrEventFeedClosed):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondError writes err with the status it maps to. Unexpected errors
+// are logged and replaced with a generic message so internals don't leak;
+// the request ID in the body lets support find the log line.
+func respondError(c *gin.Context, err error) {
+	status, message := publicError(c, err)
+	respondStatus(c, status, message)
+}
+
+// publicError maps err to its status and the message safe to show
+func publicError(c *gin.Context, err error) (int, string) {
+	status := statusFor(err)
+	if status == http.StatusInternalServerError {
+		slog.ErrorContext(c.Request.Context(), "request failed", "error", err)
+		return status, "internal server error"
+	}
+	return status, err.Error()
+}
+
+// respondStatus writes an error body with an explicit status, for errors
+// raised by the delivery layer itself (bad JSON, bad query parameters)
+func respondStatus(c *gin.Context, status int, message string) {
+	c.JSON(status, errorResponse{
+		Error:     message,
+		RequestID: logging.RequestID(c.Request.Context()),
+	})
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+
+	"library-system/internal/application/cqrs"
+	"library-system/internal/application/ports"
+	"library-system/internal/application/queries"
+)
+
+// LastEventIDHeader is sent by EventSource clients when they reconnect
+const LastEventIDHeader = "Last-Event-ID"
+
+// EventHandler streams catalog changes over Server-Sent Events
+type EventHandler struct {
+	watchEvents cqrs.Handler[queries.WatchEventsQuery, queries.WatchEventsResult]
+	heartbeat   time.Duration
+}
+
+// NewEventHandler creates a new handler sending a comment every heartbeat
+// while the stream is idle, so proxies keep it open
+func NewEventHandler(watchEvents cqrs.Handler[queries.WatchEventsQuery, queries.WatchEventsResult], heartbeat time.Duration) *EventHandler {
+	return &EventHandler{watchEvents: watchEvents, heartbeat: heartbeat}
+}
+
+// Events handles GET /events. Each catalog change is sent with its log
+// position ("<tx_id>-<id>") as the SSE id, so a reconnecting client's
+// Last-Event-ID header (or ?last_event_id= for the first connection)
+// replays what it missed.
+// ?book_id= may be repeated to follow only some books. The stream ends
+// when the server shuts down or the client falls too far behind; clients
+// reconnect and resume.
+func (h *EventHandler) Events(c *gin.Context) {
+	var last ports.EventPosition
+	if raw := c.GetHeader(LastEventIDHeader); raw != "" || c.Query("last_event_id") != "" {
+		if raw == "" {
+			raw = c.Query("last_event_id")
+		}
+		position, err := ports.ParseEventPosition(raw)
+		if err != nil {
+			respondStatus(c, http.StatusBadRequest, "Last-Event-ID must be an event ID as sent in the stream")
+			return
+		}
+		last = position
+	}
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		slog.DebugContext(c.Request.Context(), "cannot lift write deadline for event stream", "error", err)
+	}
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	stream := &eventStream{c: c}
+	defer func() {
+		// The heartbeat must stop writing before gin reuses the context
+		cancel()
+		stream.heartbeats.Wait()
+	}()
+	_, err := h.watchEvents.Handle(ctx, queries.WatchEventsQuery{
+		LastEvent: last,
+		BookIDs:   c.QueryArray("book_id"),
+		Opened: func() {
+			stream.open()
+			stream.heartbeats.Go(func() { stream.keepAlive(ctx, h.heartbeat) })
+		},
+		Emit: stream.send,
+	})
+	switch {
+	case err != nil && !stream.opened:
+		respondError(c, err)
+	case err != nil && ctx.Err() == nil:
+		slog.WarnContext(ctx, "event stream failed", "error", err)
+	}
+}
+
+// eventStream serializes writes from the query and the heartbeat
+type eventStream struct {
+	mu         sync.Mutex
+	c          *gin.Context
+	opened     bool
+	heartbeats sync.WaitGroup
+}
+
+func (s *eventStream) open() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.opened = true
+	s.c.Header("Content-Type", sse.ContentType)
+	s.c.Header("Cache-Control", "no-cache")
+	s.c.Header("X-Accel-Buffering", "no") // keep nginx from buffering the stream
+	s.c.Status(http.StatusOK)
+	s.c.Writer.WriteHeaderNow()
+	s.c.Writer.Flush()
+}
+
+func (s *eventStream) send(e queries.CatalogEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := sse.Encode(s.c.Writer, sse.Event{
+		Id:    e.Position.String(),
+		Event: e.Name,
+		Data:  e.Data,
+	})
+	if err != nil {
+		return err
+	}
+	s.c.Writer.Flush()
+	return s.c.Request.Context().Err()
+}
+
+func (s *eventStream) keepAlive(ctx context.Context, every time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			_, _ = s.c.Writer.WriteString(": keepalive\n\n")
+			s.c.Writer.Flush()
+			s.mu.Unlock()
+		}
+	}
+}
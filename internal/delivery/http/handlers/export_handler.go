@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"library-system/internal/application/cqrs"
+	"library-system/internal/application/exports"
+	"library-system/internal/application/queries"
+)
+
+// ExportHandler handles catalog export HTTP requests
+type ExportHandler struct {
+	exportBooks cqrs.Handler[queries.ExportBooksQuery, queries.ExportBooksResult]
+}
+
+// NewExportHandler creates a new handler
+func NewExportHandler(exportBooks cqrs.Handler[queries.ExportBooksQuery, queries.ExportBooksResult]) *ExportHandler {
+	return &ExportHandler{exportBooks: exportBooks}
+}
+
+// ExportBooks handles GET /books:export?format=csv|ndjson|marcxml. The
+// catalog is streamed as it is read, so the server's write timeout is
+// lifted for this response. A failure after the first byte cannot change
+// the status any more; the connection is dropped instead so the client
+// sees a truncated transfer rather than a short but valid file.
+func (h *ExportHandler) ExportBooks(c *gin.Context) {
+	format, err := exports.ParseFormat(c.DefaultQuery("format", string(exports.FormatCSV)))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		slog.DebugContext(c.Request.Context(), "cannot lift write deadline for export", "error", err)
+	}
+
+	out := &streamWriter{c: c, format: format}
+	_, err = h.exportBooks.Handle(c.Request.Context(), queries.ExportBooksQuery{
+		Format:      format,
+		Destination: out,
+	})
+	switch {
+	case err != nil && !out.started:
+		respondError(c, err)
+	case err != nil:
+		slog.ErrorContext(c.Request.Context(), "export failed mid-stream", "error", err)
+		out.abort()
+	default:
+		out.start()
+	}
+}
+
+// streamWriter sends the response headers with the first byte written, so
+// errors raised before the export starts still get a normal error reply
+type streamWriter struct {
+	c       *gin.Context
+	format  exports.Format
+	started bool
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	w.start()
+	return w.c.Writer.Write(p)
+}
+
+func (w *streamWriter) start() {
+	if w.started {
+		return
+	}
+	w.started = true
+	w.c.Header("Content-Type", w.format.ContentType())
+	w.c.Header("Content-Disposition", `attachment; filename="catalog.`+w.format.Extension()+`"`)
+	w.c.Status(http.StatusOK)
+}
+
+// abort closes the connection without finishing the response
+func (w *streamWriter) abort() {
+	conn, _, err := http.NewResponseController(w.c.Writer).Hijack()
+	if err != nil {
+		return
+	}
+	conn.Close()
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+
+	"library-system/internal/infrastructure/health"
+)
+
+// ReadinessChecker reports the state of the service's dependencies
+type ReadinessChecker interface {
+	Check(ctx context.Context) health.Report
+}
+
+// HealthHandler serves liveness and readiness probes
+type HealthHandler struct {
+	checker ReadinessChecker
+	ready   atomic.Bool
+}
+
+// NewHealthHandler creates a handler that starts out not ready
+func NewHealthHandler(checker ReadinessChecker) *HealthHandler {
+	return &HealthHandler{checker: checker}
+}
+
+// SetReady flips readiness; it is cleared first during shutdown so load
+// balancers stop routing new requests before connections are drained
+func (h *HealthHandler) SetReady(ready bool) {
+	h.ready.Store(ready)
+}
+
+// Live handles GET /healthz. It only reports that the process is serving
+// requests, so a database outage doesn't get the pod restarted.
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Ready handles GET /readyz. A degraded cluster (replicas down, primary up)
+// is still ready since reads fall back to the primary.
+func (h *HealthHandler) Ready(c *gin.Context) {
+	if !h.ready.Load() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "not_ready"})
+		return
+	}
+
+	report := h.checker.Check(c.Request.Context())
+	status := http.StatusOK
+	if report.Status == health.StatusUnavailable {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"library-system/internal/application/commands"
+	"library-system/internal/application/cqrs"
+	"library-system/internal/application/imports"
+	"library-system/internal/application/queries"
+	"library-system/internal/infrastructure/logging"
+)
+
+// ImportHandler handles bulk import HTTP requests
+type ImportHandler struct {
+	importBooks    cqrs.Handler[commands.ImportBooksCommand, commands.ImportBooksResult]
+	startImportJob cqrs.Handler[commands.StartImportJobCommand, commands.StartImportJobResult]
+	getImportJob   cqrs.Handler[queries.GetImportJobQuery, queries.GetImportJobResult]
+	maxUploadBytes int64
+}
+
+// NewImportHandler creates a new handler accepting uploads of up to
+// maxUploadBytes
+func NewImportHandler(
+	importBooks cqrs.Handler[commands.ImportBooksCommand, commands.ImportBooksResult],
+	startImportJob cqrs.Handler[commands.StartImportJobCommand, commands.StartImportJobResult],
+	getImportJob cqrs.Handler[queries.GetImportJobQuery, queries.GetImportJobResult],
+	maxUploadBytes int64,
+) *ImportHandler {
+	return &ImportHandler{
+		importBooks:    importBooks,
+		startImportJob: startImportJob,
+		getImportJob:   getImportJob,
+		maxUploadBytes: maxUploadBytes,
+	}
+}
+
+// ImportBooks handles POST /books:import. The body is CSV or NDJSON, as
+// named by ?format= or the Content-Type. By default the import runs while
+// the client waits and the per-row report is returned, also alongside the
+// error if the import fails after storing some batches; with ?async=true
+// the upload is spooled to disk, imported in the background, and the
+// response points at the job to poll.
+func (h *ImportHandler) ImportBooks(c *gin.Context) {
+	name := c.Query("format")
+	if name == "" {
+		name = c.ContentType()
+	}
+	format, err := imports.ParseFormat(name)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	async, err := strconv.ParseBool(c.DefaultQuery("async", "false"))
+	if err != nil {
+		respondStatus(c, http.StatusBadRequest, "async must be true or false")
+		return
+	}
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadBytes)
+
+	if !async {
+		result, err := h.importBooks.Handle(c.Request.Context(), commands.ImportBooksCommand{
+			Format: format,
+			Source: body,
+		})
+		if err != nil && result.Report.Imported > 0 {
+			status, message := publicError(c, err)
+			c.JSON(status, importErrorResponse{
+				errorResponse: errorResponse{Error: message, RequestID: logging.RequestID(c.Request.Context())},
+				Report:        result.Report,
+			})
+			return
+		}
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, result.Report)
+		return
+	}
+
+	src, err := spool(body)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	result, err := h.startImportJob.Handle(c.Request.Context(), commands.StartImportJobCommand{
+		Format: format,
+		Source: src,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header("Location", "/api/v1/import-jobs/"+result.JobID)
+	c.JSON(http.StatusAccepted, result)
+}
+
+// GetImportJob handles GET /import-jobs/:id
+func (h *ImportHandler) GetImportJob(c *gin.Context) {
+	result, err := h.getImportJob.Handle(c.Request.Context(), queries.GetImportJobQuery{
+		JobID: c.Param("id"),
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// spool copies an upload to a temporary file so it can be imported after
+// the request has ended. Closing the returned file removes it.
+func spool(r io.Reader) (io.ReadCloser, error) {
+	f, err := os.CreateTemp("", "library-import-*")
+	if err != nil {
+		return nil, err
+	}
+	src := spooledFile{f}
+	if _, err := io.Copy(f, r); err != nil {
+		src.Close()
+		return nil, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		src.Close()
+		return nil, err
+	}
+	return src, nil
+}
+
+type spooledFile struct {
+	*os.File
+}
+
+func (f spooledFile) Close() error {
+	err := f.File.Close()
+	os.Remove(f.Name())
+	return err
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"library-system/internal/application/commands"
+	"library-system/internal/application/cqrs"
+	"library-system/internal/application/queries"
+	"library-system/internal/delivery/http/models"
+)
+
+// LoanHandler handles loan HTTP requests
+type LoanHandler struct {
+	batchLoans cqrs.Handler[commands.BatchLoansCommand, commands.BatchLoansResult]
+	listLoans  cqrs.Handler[queries.ListLoansQuery, queries.ListLoansResult]
+}
+
+// NewLoanHandler creates a new handler
+func NewLoanHandler(
+	batchLoans cqrs.Handler[commands.BatchLoansCommand, commands.BatchLoansResult],
+	listLoans cqrs.Handler[queries.ListLoansQuery, queries.ListLoansResult],
+) *LoanHandler {
+	return &LoanHandler{batchLoans: batchLoans, listLoans: listLoans}
+}
+
+// BatchLoans handles POST /loans:batch. The body names an action (borrow
+// or return) and up to 50 book IDs, applied in one transaction. The
+// response lists the outcome per book in request order; it is 200 when
+// the batch was applied and 409 when an all-or-nothing batch was rolled
+// back because an item failed.
+func (h *LoanHandler) BatchLoans(c *gin.Context) {
+	var req models.BatchLoansRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondStatus(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	mode := commands.BatchMode(req.Mode)
+	if mode == "" {
+		mode = commands.BatchModeAllOrNothing
+	}
+
+	result, err := h.batchLoans.Handle(c.Request.Context(), commands.BatchLoansCommand{
+		Action:     commands.LoanAction(req.Action),
+		BookIDs:    req.BookIDs,
+		OnBehalfOf: req.OnBehalfOf,
+		Mode:       mode,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	if !result.Committed {
+		status = http.StatusConflict
+	}
+	c.JSON(status, result)
+}
+
+// ListLoans handles GET /loans. Patrons see their own loans; librarians
+// may pass ?borrower=<email> to see anyone's.
+func (h *LoanHandler) ListLoans(c *gin.Context) {
+	maxStaleness, err := parseMaxStaleness(c)
+	if err != nil {
+		respondStatus(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.listLoans.Handle(c.Request.Context(), queries.ListLoansQuery{
+		BorrowerEmail: c.Query("borrower"),
+		MaxStaleness:  maxStaleness,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"library-system/internal/application/commands"
+	"library-system/internal/application/cqrs"
+	"library-system/internal/application/queries"
+	"library-system/internal/delivery/http/models"
+	"library-system/internal/domain/webhooks"
+)
+
+// WebhookHandler handles webhook administration requests
+type WebhookHandler struct {
+	create     cqrs.Handler[commands.CreateWebhookCommand, commands.CreateWebhookResult]
+	remove     cqrs.Handler[commands.DeleteWebhookCommand, commands.DeleteWebhookResult]
+	redeliver  cqrs.Handler[commands.RedeliverWebhookCommand, commands.RedeliverWebhookResult]
+	list       cqrs.Handler[queries.ListWebhooksQuery, queries.ListWebhooksResult]
+	deliveries cqrs.Handler[queries.ListWebhookDeliveriesQuery, queries.ListWebhookDeliveriesResult]
+}
+
+// NewWebhookHandler creates a new handler
+func NewWebhookHandler(
+	create cqrs.Handler[commands.CreateWebhookCommand, commands.CreateWebhookResult],
+	remove cqrs.Handler[commands.DeleteWebhookCommand, commands.DeleteWebhookResult],
+	redeliver cqrs.Handler[commands.RedeliverWebhookCommand, commands.RedeliverWebhookResult],
+	list cqrs.Handler[queries.ListWebhooksQuery, queries.ListWebhooksResult],
+	deliveries cqrs.Handler[queries.ListWebhookDeliveriesQuery, queries.ListWebhookDeliveriesResult],
+) *WebhookHandler {
+	return &WebhookHandler{create: create, remove: remove, redeliver: redeliver, list: list, deliveries: deliveries}
+}
+
+// Create handles POST /admin/webhooks. The signing secret is only in this response.
+func (h *WebhookHandler) Create(c *gin.Context) {
+	var req models.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondStatus(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.create.Handle(c.Request.Context(), commands.CreateWebhookCommand{
+		URL:         req.URL,
+		Events:      req.Events,
+		Description: req.Description,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// List handles GET /admin/webhooks
+func (h *WebhookHandler) List(c *gin.Context) {
+	result, err := h.list.Handle(c.Request.Context(), queries.ListWebhooksQuery{})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Delete handles DELETE /admin/webhooks/:id
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	if _, err := h.remove.Handle(c.Request.Context(), commands.DeleteWebhookCommand{
+		WebhookID: c.Param("id"),
+	}); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Deliveries handles GET /admin/webhooks/:id/deliveries. ?status=dead is
+// the dead-letter view.
+func (h *WebhookHandler) Deliveries(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+
+	result, err := h.deliveries.Handle(c.Request.Context(), queries.ListWebhookDeliveriesQuery{
+		WebhookID: c.Param("id"),
+		Status:    c.Query("status"),
+		Limit:     limit,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Redeliver handles POST /admin/webhooks/:id/deliveries/:delivery/redeliver
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	deliveryID, err := strconv.ParseInt(c.Param("delivery"), 10, 64)
+	if err != nil {
+		respondError(c, webhooks.ErrDeliveryNotFound)
+		return
+	}
+
+	result, err := h.redeliver.Handle(c.Request.Context(), commands.RedeliverWebhookCommand{
+		WebhookID:  c.Param("id"),
+		DeliveryID: deliveryID,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, result)
+}
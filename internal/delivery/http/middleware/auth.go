@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/infrastructure/logging"
+)
+
+// APIKeyHeader carries API keys for service-to-service clients
+const APIKeyHeader = "X-API-Key"
+
+// TokenVerifier validates a bearer token and returns the caller it identifies
+type TokenVerifier interface {
+	Verify(token string) (auth.Principal, error)
+}
+
+// APIKeyVerifier resolves an API key to the caller it identifies. Invalid
+// keys are reported as auth.ErrUnauthenticated.
+type APIKeyVerifier interface {
+	Authenticate(ctx context.Context, key string) (auth.Principal, error)
+}
+
+// Authenticate requires either an X-API-Key header or a valid
+// "Authorization: Bearer <token>" header and stores the caller's principal
+// in the request context. Requests without credentials, or with ones that
+// fail verification, are rejected with 401.
+func Authenticate(tokens TokenVerifier, keys APIKeyVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var principal auth.Principal
+		if key := c.GetHeader(APIKeyHeader); key != "" {
+			var err error
+			principal, err = keys.Authenticate(ctx, key)
+			if errors.Is(err, auth.ErrUnauthenticated) {
+				unauthorized(c, "invalid API key")
+				return
+			}
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to verify API key", "error", err)
+				abort(c, http.StatusServiceUnavailable, "authentication unavailable")
+				return
+			}
+		} else {
+			token, ok := bearerToken(c.GetHeader("Authorization"))
+			if !ok {
+				unauthorized(c, "missing bearer token or API key")
+				return
+			}
+			var err error
+			principal, err = tokens.Verify(token)
+			if err != nil {
+				unauthorized(c, "invalid bearer token")
+				return
+			}
+		}
+
+		c.Request = c.Request.WithContext(auth.WithPrincipal(ctx, principal))
+		c.Next()
+	}
+}
+
+// bearerToken extracts the token from an Authorization header value
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// unauthorized aborts with 401. The verification error is not echoed to
+// avoid helping credential guessing.
+func unauthorized(c *gin.Context, message string) {
+	c.Header("WWW-Authenticate", `Bearer realm="library"`)
+	abort(c, http.StatusUnauthorized, message)
+}
+
+// abort writes the same error body the handlers use
+func abort(c *gin.Context, status int, message string) {
+	c.AbortWithStatusJSON(status, gin.H{
+		"error":      message,
+		"request_id": logging.RequestID(c.Request.Context()),
+	})
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"library-system/internal/application/auth"
+)
+
+type stubVerifier struct{}
+
+func (stubVerifier) Verify(token string) (auth.Principal, error) {
+	if token != "good" {
+		return auth.Principal{}, errors.New("bad token")
+	}
+	return auth.Principal{Subject: "user-1", Email: "reader@example.com"}, nil
+}
+
+type stubKeys struct{}
+
+func (stubKeys) Authenticate(ctx context.Context, key string) (auth.Principal, error) {
+	switch key {
+	case "good-key":
+		return auth.Principal{Subject: "apikey:1", Roles: []string{auth.RoleLendingDesk}}, nil
+	case "db-down":
+		return auth.Principal{}, errors.New("connection refused")
+	default:
+		return auth.Principal{}, auth.ErrUnauthenticated
+	}
+}
+
+func serveWithAuth(header string) (status int, principal auth.Principal) {
+	return serve("Authorization", header)
+}
+
+func serve(name, value string) (status int, principal auth.Principal) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Authenticate(stubVerifier{}, stubKeys{}))
+	router.GET("/", func(c *gin.Context) {
+		principal, _ = auth.PrincipalFrom(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if value != "" {
+		req.Header.Set(name, value)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec.Code, principal
+}
+
+func TestAuthenticate_ValidTokenSetsPrincipal(t *testing.T) {
+	status, principal := serveWithAuth("Bearer good")
+
+	if status != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", status)
+	}
+	if principal.Email != "reader@example.com" {
+		t.Errorf("expected principal in context, got %+v", principal)
+	}
+}
+
+func TestAuthenticate_RejectsMissingOrInvalidToken(t *testing.T) {
+	for _, header := range []string{"", "Bearer", "Basic good", "Bearer bad"} {
+		if status, _ := serveWithAuth(header); status != http.StatusUnauthorized {
+			t.Errorf("header %q: expected 401, got %d", header, status)
+		}
+	}
+}
+
+func TestAuthenticate_APIKey(t *testing.T) {
+	status, principal := serve(APIKeyHeader, "good-key")
+
+	if status != http.StatusNoContent || principal.Subject != "apikey:1" {
+		t.Errorf("expected API key principal, got %d %+v", status, principal)
+	}
+	if status, _ := serve(APIKeyHeader, "bad-key"); status != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a bad key, got %d", status)
+	}
+	if status, _ := serve(APIKeyHeader, "db-down"); status != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 when keys can't be checked, got %d", status)
+	}
+}
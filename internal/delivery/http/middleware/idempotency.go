@@ -0,0 +1,192 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+
+	"library-system/internal/infrastructure/idempotency"
+)
+
+const (
+	// IdempotencyKeyHeader carries the client-chosen key for a command
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	maxIdempotencyKeyLength = 255
+	// maxMemoryBodyBytes bounds the body kept in memory for the handler
+	// after fingerprinting; larger bodies are spooled to a temporary file
+	maxMemoryBodyBytes = 1 << 20
+)
+
+// errBodyTooLarge means a body exceeded the limit given to Idempotency
+var errBodyTooLarge = errors.New("request body too large")
+
+// Idempotency makes commands carrying an Idempotency-Key safe to retry.
+// The first request reserves the key for the caller and its response is
+// stored; a retry with the same key and the same method, path, query,
+// Content-Type and body gets the stored response replayed (marked Idempotent-Replayed: true)
+// without the command running again. Reusing a key for a different
+// request is rejected with 422, and a retry while the first request is
+// still running with 409.
+//
+// Server errors are not stored, so a request that failed with 5xx can be
+// retried with the same key. Keys are scoped per principal, so the
+// middleware belongs after Authenticate. GET and HEAD requests and
+// requests without the header pass through untouched. Bodies of up to
+// maxBodyBytes are accepted, which must cover the largest upload any route
+// takes, e.g. a bulk import.
+func Idempotency(store idempotency.Store, maxBodyBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			abort(c, http.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+			return
+		}
+
+		fingerprint, body, err := fingerprintRequest(c.Request, maxBodyBytes)
+		if errors.Is(err, errBodyTooLarge) {
+			abort(c, http.StatusRequestEntityTooLarge, "request body too large for an idempotent request")
+			return
+		}
+		if err != nil {
+			slog.WarnContext(c.Request.Context(), "failed to read idempotent request body", "error", err)
+			abort(c, http.StatusBadRequest, "failed to read request body")
+			return
+		}
+		defer body.Close()
+		c.Request.Body = body
+
+		ctx := c.Request.Context()
+		scope := clientKey(c)
+
+		existing, err := store.Reserve(ctx, scope, key, fingerprint)
+		if err != nil {
+			slog.ErrorContext(ctx, "idempotency store unavailable", "error", err)
+			abort(c, http.StatusServiceUnavailable, "idempotency store unavailable, retry later")
+			return
+		}
+		if existing != nil {
+			switch {
+			case !bytes.Equal(existing.Fingerprint, fingerprint):
+				abort(c, http.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request")
+			case !existing.Completed:
+				abort(c, http.StatusConflict, "a request with this Idempotency-Key is still in progress")
+			default:
+				c.Header("Idempotent-Replayed", "true")
+				if existing.ContentType != "" {
+					c.Header("Content-Type", existing.ContentType)
+				}
+				c.Status(existing.Status)
+				_, _ = c.Writer.Write(existing.Body)
+				c.Abort()
+			}
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// The client may have gone away; record the outcome regardless
+		ctx = context.WithoutCancel(ctx)
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			if err := store.Release(ctx, scope, key); err != nil {
+				slog.WarnContext(ctx, "failed to release idempotency key", "error", err)
+			}
+			return
+		}
+		if err := store.Complete(ctx, scope, key, idempotency.Record{
+			Fingerprint: fingerprint,
+			Completed:   true,
+			Status:      status,
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		}); err != nil {
+			slog.WarnContext(ctx, "failed to store idempotent response", "error", err)
+		}
+	}
+}
+
+// fingerprintRequest identifies what a key was used for, so reuse for a
+// different request can be detected. It covers the method, path, query
+// and Content-Type, which can change what a body means (e.g. ?async= or
+// ?format= on an import), as well as the body. The body is hashed as it is
+// read and returned for the handler: in memory when small, otherwise
+// spooled to a temporary file that closing the returned body removes, so a
+// large import is never held in memory.
+func fingerprintRequest(req *http.Request, maxBytes int64) ([]byte, io.ReadCloser, error) {
+	h := sha256.New()
+	for _, part := range []string{req.Method, req.URL.Path, req.URL.RawQuery, req.Header.Get("Content-Type")} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	r := io.TeeReader(io.LimitReader(req.Body, maxBytes+1), h)
+
+	head, err := io.ReadAll(io.LimitReader(r, maxMemoryBodyBytes+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(head) <= maxMemoryBodyBytes {
+		if int64(len(head)) > maxBytes {
+			return nil, nil, errBodyTooLarge
+		}
+		return h.Sum(nil), io.NopCloser(bytes.NewReader(head)), nil
+	}
+
+	f, err := os.CreateTemp("", "library-idempotent-*")
+	if err != nil {
+		return nil, nil, err
+	}
+	body := tempBody{f}
+	n, err := io.Copy(f, io.MultiReader(bytes.NewReader(head), r))
+	if err == nil && n > maxBytes {
+		err = errBodyTooLarge
+	}
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		body.Close()
+		return nil, nil, err
+	}
+	return h.Sum(nil), body, nil
+}
+
+// tempBody is a request body spooled to a file, removed when closed
+type tempBody struct {
+	*os.File
+}
+
+func (b tempBody) Close() error {
+	err := b.File.Close()
+	os.Remove(b.Name())
+	return err
+}
+
+// responseRecorder copies the response body while writing it through
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
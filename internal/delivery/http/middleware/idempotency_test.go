@@ -0,0 +1,197 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"library-system/internal/infrastructure/idempotency"
+)
+
+// memoryIdempotencyStore is an in-memory idempotency.Store for tests
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]idempotency.Record
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: make(map[string]idempotency.Record)}
+}
+
+func (s *memoryIdempotencyStore) Reserve(_ context.Context, scope, key string, fingerprint []byte) (*idempotency.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if rec, ok := s.records[scope+"/"+key]; ok {
+		return &rec, nil
+	}
+	s.records[scope+"/"+key] = idempotency.Record{Fingerprint: fingerprint}
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(_ context.Context, scope, key string, rec idempotency.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[scope+"/"+key] = rec
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(_ context.Context, scope, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, scope+"/"+key)
+	return nil
+}
+
+type idempotentRouter struct {
+	*gin.Engine
+	calls  int
+	status int
+}
+
+func newIdempotentRouter(store idempotency.Store) *idempotentRouter {
+	gin.SetMode(gin.TestMode)
+	r := &idempotentRouter{Engine: gin.New(), status: http.StatusCreated}
+	r.Use(Idempotency(store, 4<<20))
+	r.POST("/books", func(c *gin.Context) {
+		r.calls++
+		body, _ := io.ReadAll(c.Request.Body)
+		c.JSON(r.status, gin.H{"call": r.calls, "bytes": len(body)})
+	})
+	return r
+}
+
+func (r *idempotentRouter) post(key, body string) *httptest.ResponseRecorder {
+	return r.postTo("/books", "", key, body)
+}
+
+func (r *idempotentRouter) postTo(target, contentType, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotency_ReplaysStoredResponse(t *testing.T) {
+	router := newIdempotentRouter(newMemoryIdempotencyStore())
+
+	first := router.post("key-1", `{"title":"Dune"}`)
+	retry := router.post("key-1", `{"title":"Dune"}`)
+
+	if router.calls != 1 {
+		t.Errorf("expected the command to run once, ran %d times", router.calls)
+	}
+	if retry.Code != http.StatusCreated || retry.Body.String() != first.Body.String() {
+		t.Errorf("expected replay of %d %s, got %d %s", first.Code, first.Body, retry.Code, retry.Body)
+	}
+	if retry.Header().Get("Idempotent-Replayed") != "true" || !strings.HasPrefix(retry.Header().Get("Content-Type"), "application/json") {
+		t.Errorf("expected replay headers, got %v", retry.Header())
+	}
+}
+
+func TestIdempotency_RejectsKeyReuseWithDifferentBody(t *testing.T) {
+	router := newIdempotentRouter(newMemoryIdempotencyStore())
+
+	_ = router.post("key-1", `{"title":"Dune"}`)
+	reused := router.post("key-1", `{"title":"Emma"}`)
+
+	if reused.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422, got %d", reused.Code)
+	}
+	if router.calls != 1 {
+		t.Errorf("expected the command to run once, ran %d times", router.calls)
+	}
+}
+
+func TestIdempotency_RejectsKeyReuseWithDifferentQueryOrContentType(t *testing.T) {
+	router := newIdempotentRouter(newMemoryIdempotencyStore())
+	body := "title,author\nDune,Frank Herbert\n"
+
+	_ = router.postTo("/books?async=true", "text/csv", "key-1", body)
+	blocking := router.postTo("/books", "text/csv", "key-1", body)
+	ndjson := router.postTo("/books?async=true", "application/x-ndjson", "key-1", body)
+
+	if blocking.Code != http.StatusUnprocessableEntity || ndjson.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422 for a different query and Content-Type, got %d and %d", blocking.Code, ndjson.Code)
+	}
+	if router.calls != 1 {
+		t.Errorf("expected the command to run once, ran %d times", router.calls)
+	}
+}
+
+func TestIdempotency_InProgressIsConflict(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	router := newIdempotentRouter(store)
+	fingerprint, _, _ := fingerprintRequest(httptest.NewRequest(http.MethodPost, "/books", strings.NewReader(`{}`)), 1<<20)
+	_, _ = store.Reserve(context.Background(), "ip:192.0.2.1", "key-1", fingerprint)
+
+	rec := router.post("key-1", `{}`)
+
+	if rec.Code != http.StatusConflict || router.calls != 0 {
+		t.Errorf("expected 409 without running the command, got %d after %d calls", rec.Code, router.calls)
+	}
+}
+
+func TestIdempotency_ServerErrorsCanBeRetried(t *testing.T) {
+	router := newIdempotentRouter(newMemoryIdempotencyStore())
+	router.status = http.StatusInternalServerError
+
+	_ = router.post("key-1", `{}`)
+	router.status = http.StatusCreated
+	retry := router.post("key-1", `{}`)
+
+	if retry.Code != http.StatusCreated || router.calls != 2 {
+		t.Errorf("expected retry to run the command again, got %d after %d calls", retry.Code, router.calls)
+	}
+}
+
+func TestIdempotency_WithoutKeyPassesThrough(t *testing.T) {
+	router := newIdempotentRouter(newMemoryIdempotencyStore())
+
+	_ = router.post("", `{}`)
+	_ = router.post("", `{}`)
+
+	if router.calls != 2 {
+		t.Errorf("expected both requests to run, ran %d", router.calls)
+	}
+}
+
+func TestIdempotency_LargeBodiesAreSpooledAndFingerprinted(t *testing.T) {
+	router := newIdempotentRouter(newMemoryIdempotencyStore())
+	body := strings.Repeat("a", 3<<20)
+
+	first := router.post("key-1", body)
+	replay := router.post("key-1", body)
+	reused := router.post("key-1", body[:len(body)-1]+"b")
+
+	if first.Code != http.StatusCreated || !strings.Contains(first.Body.String(), `"bytes":3145728`) {
+		t.Fatalf("expected the handler to read the whole body, got %d %s", first.Code, first.Body)
+	}
+	if replay.Header().Get("Idempotent-Replayed") != "true" || router.calls != 1 {
+		t.Errorf("expected the retry to be replayed, got %d calls", router.calls)
+	}
+	if reused.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422 for a body differing in its last byte, got %d", reused.Code)
+	}
+}
+
+func TestIdempotency_RejectsBodyOverLimit(t *testing.T) {
+	router := newIdempotentRouter(newMemoryIdempotencyStore())
+
+	rec := router.post("key-1", strings.Repeat("a", 5<<20))
+
+	if rec.Code != http.StatusRequestEntityTooLarge || router.calls != 0 {
+		t.Errorf("expected 413 without running the command, got %d after %d calls", rec.Code, router.calls)
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HTTPObserver records request metrics
+type HTTPObserver interface {
+	ObserveHTTPRequest(method, route string, status int, d time.Duration)
+}
+
+// Metrics records latency for every request, labelled by route template
+// so /books/:id is one series rather than one per book
+func Metrics(observer HTTPObserver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		observer.ObserveHTTPRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"library-system/internal/delivery/http/openapi"
+)
+
+// maxValidatedResponseBytes bounds the response body buffered for
+// validation; larger responses are not checked
+const maxValidatedResponseBytes = 1 << 20
+
+// OpenAPIValidation checks traffic against the OpenAPI document. With
+// validateRequests, requests that don't match are rejected with 400 before
+// they reach a handler. With validateResponses, JSON responses are checked
+// too; a mismatch is a bug in the server, so it is logged and the response
+// sent unchanged.
+// Requests the document doesn't describe pass through for the router to
+// answer.
+func OpenAPIValidation(spec *openapi.Spec, validateRequests, validateResponses bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		op, params := spec.Find(c.Request.Method, c.Request.URL.Path)
+		if op == nil {
+			c.Next()
+			return
+		}
+		if validateRequests {
+			if err := op.ValidateRequest(c.Request, params); err != nil {
+				var invalid *openapi.ValidationError
+				if !errors.As(err, &invalid) {
+					abort(c, http.StatusBadRequest, "failed to read request body")
+					return
+				}
+				abort(c, http.StatusBadRequest, invalid.Error())
+				return
+			}
+		}
+		if !validateResponses {
+			c.Next()
+			return
+		}
+
+		recorder := &jsonRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		if recorder.skipped {
+			return
+		}
+		status := recorder.Status()
+		if err := op.ValidateResponse(status, recorder.Header().Get("Content-Type"), recorder.body.Bytes()); err != nil {
+			slog.ErrorContext(c.Request.Context(), "response does not match OpenAPI spec",
+				"operation", op.ID,
+				"status", status,
+				"error", err,
+			)
+		}
+	}
+}
+
+// jsonRecorder copies JSON response bodies while writing them through.
+// Streams such as exports aren't JSON and are never buffered.
+type jsonRecorder struct {
+	gin.ResponseWriter
+	body    bytes.Buffer
+	skipped bool
+}
+
+func (r *jsonRecorder) Write(b []byte) (int, error) {
+	r.record(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *jsonRecorder) WriteString(s string) (int, error) {
+	r.record([]byte(s))
+	return r.ResponseWriter.WriteString(s)
+}
+
+func (r *jsonRecorder) record(b []byte) {
+	if r.skipped {
+		return
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header().Get("Content-Type"))
+	if mediaType != "application/json" || r.body.Len()+len(b) > maxValidatedResponseBytes {
+		r.skipped = true
+		r.body.Reset()
+		return
+	}
+	r.body.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the connection, e.g. for
+// streams lifting their write deadline
+func (r *jsonRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
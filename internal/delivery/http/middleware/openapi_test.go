@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"library-system/internal/delivery/http/openapi"
+)
+
+func newOpenAPIRouter(t *testing.T, validateResponses bool, respond gin.HandlerFunc) *gin.Engine {
+	t.Helper()
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(OpenAPIValidation(spec, true, validateResponses))
+	r.POST("/api/v1/books", respond)
+	r.GET("/api/v1/books/:id", respond)
+	r.GET("/unlisted", respond)
+	return r
+}
+
+func TestOpenAPIValidation_RejectsInvalidRequest(t *testing.T) {
+	called := false
+	r := newOpenAPIRouter(t, false, func(c *gin.Context) { called = true })
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/books", strings.NewReader(`{"title":"Dune"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "author") {
+		t.Errorf("expected 400 naming the missing property, got %d %s", w.Code, w.Body.String())
+	}
+	if called {
+		t.Error("expected handler not to run")
+	}
+}
+
+func TestOpenAPIValidation_PassesValidAndUndocumentedRequests(t *testing.T) {
+	var got string
+	r := newOpenAPIRouter(t, false, func(c *gin.Context) {
+		body, _ := c.GetRawData()
+		got = string(body)
+		c.Status(http.StatusCreated)
+	})
+	body := `{"title":"Dune","author":"Frank Herbert"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/books", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated || got != body {
+		t.Errorf("expected handler to read the original body, got %d %q", w.Code, got)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/unlisted", nil))
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected undocumented route to pass through, got %d", w.Code)
+	}
+}
+
+func TestOpenAPIValidation_LogsResponseMismatch(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	r := newOpenAPIRouter(t, true, func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ID": c.Param("id")})
+	})
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/books/0b4f3c1e-0000-4000-8000-000000000000", nil))
+
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "0b4f3c1e") {
+		t.Errorf("expected response to be sent unchanged, got %d %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(logs.String(), "response does not match OpenAPI spec") || !strings.Contains(logs.String(), "operation=getBook") {
+		t.Errorf("expected mismatch to be logged, got %q", logs.String())
+	}
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"library-system/internal/infrastructure/ratelimit"
+)
+
+func newRateLimitedRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RateLimit(ratelimit.NewMemoryStore(),
+		ratelimit.Limit{Rate: 1, Burst: 2},
+		ratelimit.Limit{Rate: 1, Burst: 1},
+		map[string]ratelimit.Limit{"/graphql": {Rate: 1, Burst: 3}},
+	))
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	router.GET("/books", ok)
+	router.POST("/books/:id/borrow", ok)
+	router.POST("/graphql", ok)
+	return router
+}
+
+func do(router *gin.Engine, method, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	return rec
+}
+
+func TestRateLimit_RejectsOverBudgetWithHeaders(t *testing.T) {
+	router := newRateLimitedRouter()
+
+	first := do(router, http.MethodPost, "/books/1/borrow")
+	second := do(router, http.MethodPost, "/books/2/borrow")
+
+	if first.Code != http.StatusNoContent || first.Header().Get("RateLimit-Remaining") != "0" {
+		t.Errorf("expected first write allowed with 0 remaining, got %d %q", first.Code, first.Header().Get("RateLimit-Remaining"))
+	}
+	if second.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", second.Code)
+	}
+	if second.Header().Get("Retry-After") != "1" || second.Header().Get("RateLimit-Limit") != "1" {
+		t.Errorf("expected Retry-After 1 and limit 1, got %q and %q",
+			second.Header().Get("Retry-After"), second.Header().Get("RateLimit-Limit"))
+	}
+}
+
+func TestRateLimit_ReadsAndWritesHaveSeparateBudgets(t *testing.T) {
+	router := newRateLimitedRouter()
+
+	_ = do(router, http.MethodPost, "/books/1/borrow")
+	read := do(router, http.MethodGet, "/books")
+
+	if read.Code != http.StatusNoContent || read.Header().Get("RateLimit-Limit") != "2" {
+		t.Errorf("expected read to use its own budget, got %d with limit %q", read.Code, read.Header().Get("RateLimit-Limit"))
+	}
+}
+
+func TestRateLimit_RouteBudgetOverridesMethod(t *testing.T) {
+	router := newRateLimitedRouter()
+
+	_ = do(router, http.MethodPost, "/books/1/borrow")
+	query := do(router, http.MethodPost, "/graphql")
+
+	if query.Code != http.StatusNoContent || query.Header().Get("RateLimit-Limit") != "3" {
+		t.Errorf("expected GraphQL to use its own budget, got %d with limit %q", query.Code, query.Header().Get("RateLimit-Limit"))
+	}
+}
+
+func TestRateLimitByIP_LimitsFailedAuthentication(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RateLimitByIP(ratelimit.NewMemoryStore(), ratelimit.Limit{Rate: 1, Burst: 2}))
+	router.Use(Authenticate(stubVerifier{}, stubKeys{}))
+	router.GET("/books", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	var codes []int
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/books", nil)
+		req.Header.Set("X-API-Key", "guess")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		codes = append(codes, rec.Code)
+	}
+
+	if codes[0] != http.StatusUnauthorized || codes[1] != http.StatusUnauthorized {
+		t.Errorf("expected the first guesses to be rejected as unauthorized, got %v", codes)
+	}
+	if codes[2] != http.StatusTooManyRequests {
+		t.Errorf("expected the third guess from the same IP to be rate limited, got %d", codes[2])
+	}
+}
+
+func TestRateLimitByIP_IgnoresForwardedForFromUntrustedPeers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	if err := router.SetTrustedProxies(nil); err != nil {
+		t.Fatal(err)
+	}
+	router.Use(RateLimitByIP(ratelimit.NewMemoryStore(), ratelimit.Limit{Rate: 1, Burst: 1}))
+	router.GET("/books", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	var codes []int
+	for _, forwarded := range []string{"198.51.100.1", "198.51.100.2"} {
+		req := httptest.NewRequest(http.MethodGet, "/books", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		req.Header.Set("X-Forwarded-For", forwarded)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		codes = append(codes, rec.Code)
+	}
+
+	if codes[0] != http.StatusNoContent || codes[1] != http.StatusTooManyRequests {
+		t.Errorf("expected a spoofed X-Forwarded-For to share the peer's bucket, got %v", codes)
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"library-system/internal/infrastructure/logging"
+)
+
+func serveWithRequestID(header string) (echoed, inContext string) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID())
+	router.GET("/", func(c *gin.Context) {
+		inContext = logging.RequestID(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if header != "" {
+		req.Header.Set(RequestIDHeader, header)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec.Header().Get(RequestIDHeader), inContext
+}
+
+func TestRequestID_KeepsCallerID(t *testing.T) {
+	echoed, inContext := serveWithRequestID("kiosk-42-abc")
+
+	if echoed != "kiosk-42-abc" || inContext != "kiosk-42-abc" {
+		t.Errorf("expected caller ID to be kept, got header %q and context %q", echoed, inContext)
+	}
+}
+
+func TestRequestID_GeneratesWhenMissing(t *testing.T) {
+	echoed, inContext := serveWithRequestID("")
+
+	if echoed == "" || echoed != inContext {
+		t.Errorf("expected generated ID in header and context, got %q and %q", echoed, inContext)
+	}
+}
+
+func TestRequestID_ReplacesInvalidID(t *testing.T) {
+	for _, bad := range []string{"has space", strings.Repeat("a", maxRequestIDLength+1), "line\nbreak"} {
+		echoed, _ := serveWithRequestID(bad)
+		if echoed == bad || echoed == "" {
+			t.Errorf("expected %q to be replaced, got %q", bad, echoed)
+		}
+	}
+}
@@ -6,7 +6,36 @@ type AddBookRequest struct {
 	Author string `json:"author" binding:"required"`
 }
 
-// BorrowBookRequest is the request body for borrowing a book
+// BorrowBookRequest is the optional request body for borrowing a book.
+// Without a body the caller borrows for themselves.
 type BorrowBookRequest struct {
-	BorrowerEmail string `json:"borrower_email" binding:"required,email"`
+	OnBehalfOf string `json:"on_behalf_of" binding:"omitempty,email"`
+}
+
+// BatchLoansRequest is the request body for borrowing or returning several
+// books at once
+type BatchLoansRequest struct {
+	Action     string   `json:"action" binding:"required,oneof=borrow return"`
+	BookIDs    []string `json:"book_ids" binding:"required,min=1"`
+	OnBehalfOf string   `json:"on_behalf_of" binding:"omitempty,email"`
+	Mode       string   `json:"mode" binding:"omitempty,oneof=all_or_nothing best_effort"` // default all_or_nothing
+}
+
+// IssueAPIKeyRequest is the request body for issuing an API key
+type IssueAPIKeyRequest struct {
+	Name      string   `json:"name" binding:"required"`
+	Scopes    []string `json:"scopes" binding:"required,min=1"`
+	ExpiresIn string   `json:"expires_in"` // Go duration such as "2160h"; empty means never
+}
+
+// RotateAPIKeyRequest is the optional request body for rotating an API key
+type RotateAPIKeyRequest struct {
+	Overlap string `json:"overlap"` // Go duration the old key stays valid; default 24h
+}
+
+// CreateWebhookRequest is the request body for subscribing a webhook
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" binding:"required"`
+	Events      []string `json:"events" binding:"required,min=1"` // e.g. catalog.book_borrowed
+	Description string   `json:"description"`
 }
@@ -0,0 +1,352 @@
+package openapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// maxValidatedBody bounds how much of a JSON body is read for validation;
+// larger JSON bodies are rejected
+const maxValidatedBody = 1 << 20
+
+var printer = message.NewPrinter(language.English)
+
+// ValidationError describes the first part of a request or response that
+// doesn't match the document
+type ValidationError struct {
+	Location string // e.g. "query parameter limit" or "request body"
+	Message  string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Location + ": " + e.Message
+}
+
+// Operation is one method on one path with its compiled schemas
+type Operation struct {
+	ID        string
+	params    []param
+	body      *body
+	responses map[string]*jsonschema.Schema // by status or "default"; nil when not JSON
+}
+
+type param struct {
+	name     string
+	in       string // path, query or header
+	required bool
+	typ      string // JSON type the string value is converted to
+	itemTyp  string // for arrays, the type of each repeated value
+	schema   *jsonschema.Schema
+}
+
+type body struct {
+	required     bool
+	json         *jsonschema.Schema
+	contentTypes []string
+}
+
+// ValidateRequest checks parameters and, for JSON, the body. The body is
+// restored so handlers can read it again.
+func (op *Operation) ValidateRequest(r *http.Request, pathParams map[string]string) error {
+	for _, p := range op.params {
+		var raw []string
+		switch p.in {
+		case "path":
+			if v, ok := pathParams[p.name]; ok {
+				raw = []string{v}
+			}
+		case "query":
+			raw = r.URL.Query()[p.name]
+		case "header":
+			if v := r.Header.Get(p.name); v != "" {
+				raw = []string{v}
+			}
+		}
+		location := p.in + " parameter " + p.name
+		if len(raw) == 0 {
+			if p.required {
+				return &ValidationError{Location: location, Message: "is required"}
+			}
+			continue
+		}
+		value, err := p.value(raw)
+		if err != nil {
+			return &ValidationError{Location: location, Message: err.Error()}
+		}
+		if err := p.schema.Validate(value); err != nil {
+			return &ValidationError{Location: location, Message: describe(err)}
+		}
+	}
+	return op.validateBody(r)
+}
+
+func (op *Operation) validateBody(r *http.Request) error {
+	if op.body == nil {
+		return nil
+	}
+	empty := r.Body == nil || r.Body == http.NoBody || r.ContentLength == 0
+	if empty {
+		if op.body.required {
+			return &ValidationError{Location: "request body", Message: "is required"}
+		}
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "application/json" || op.body.json == nil {
+		// Uploads such as CSV are checked by their handlers
+		return nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxValidatedBody+1))
+	if err != nil {
+		return err
+	}
+	r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(data), r.Body))
+	if len(data) > maxValidatedBody {
+		return &ValidationError{Location: "request body", Message: "is too large to validate"}
+	}
+	if len(bytes.TrimSpace(data)) == 0 && !op.body.required {
+		return nil
+	}
+	return validateJSON("request body", op.body.json, data)
+}
+
+// ValidateResponse checks a JSON response body against the schema for its
+// status, falling back to "default". Other content types are not checked.
+func (op *Operation) ValidateResponse(status int, contentType string, data []byte) error {
+	code := strconv.Itoa(status)
+	schema, ok := op.responses[code]
+	if !ok {
+		if schema, ok = op.responses["default"]; !ok {
+			return &ValidationError{Location: "response", Message: "status " + code + " is not documented"}
+		}
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if schema == nil || mediaType != "application/json" || len(data) == 0 {
+		return nil
+	}
+	return validateJSON("response body", schema, data)
+}
+
+func validateJSON(location string, schema *jsonschema.Schema, data []byte) error {
+	value, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return &ValidationError{Location: location, Message: "is not valid JSON"}
+	}
+	if err := schema.Validate(value); err != nil {
+		return &ValidationError{Location: location, Message: describe(err)}
+	}
+	return nil
+}
+
+// value converts the raw values of a parameter for validation; arrays are
+// sent as repeated parameters (form style, exploded)
+func (p param) value(raw []string) (any, error) {
+	if p.typ != "array" {
+		return convert(raw[0], p.typ)
+	}
+	items := make([]any, len(raw))
+	for i, r := range raw {
+		v, err := convert(r, p.itemTyp)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = v
+	}
+	return items, nil
+}
+
+// convert turns a parameter string into the JSON type its schema expects
+func convert(raw, typ string) (any, error) {
+	switch typ {
+	case "integer":
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, errors.New("must be an integer")
+		}
+		return n, nil
+	case "number":
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, errors.New("must be a number")
+		}
+		return f, nil
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, errors.New("must be true or false")
+		}
+		return b, nil
+	default:
+		return raw, nil
+	}
+}
+
+// describe flattens a schema error to its leaf causes, e.g.
+// "at /book_ids: minItems: got 0, want 1"
+func describe(err error) string {
+	var ve *jsonschema.ValidationError
+	if !errors.As(err, &ve) {
+		return err.Error()
+	}
+	var msgs []string
+	var walk func(*jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) == 0 {
+			msg := e.ErrorKind.LocalizedString(printer)
+			if len(e.InstanceLocation) > 0 {
+				msg = "at /" + strings.Join(e.InstanceLocation, "/") + ": " + msg
+			}
+			msgs = append(msgs, msg)
+			return
+		}
+		for _, cause := range e.Causes {
+			walk(cause)
+		}
+	}
+	walk(ve)
+	return strings.Join(msgs, "; ")
+}
+
+// builder compiles operations out of the document, following local $refs
+type builder struct {
+	doc      map[string]any
+	compiler *jsonschema.Compiler
+}
+
+func (b builder) operation(template, method string) (*Operation, error) {
+	itemPtr := "/paths/" + escape(template)
+	opPtr := itemPtr + "/" + strings.ToLower(method)
+	item, _ := b.lookup(itemPtr)
+	node, _ := b.lookup(opPtr)
+	op := &Operation{responses: map[string]*jsonschema.Schema{}}
+	op.ID, _ = node["operationId"].(string)
+
+	// Operation parameters override path-level ones of the same name
+	seen := map[string]bool{}
+	for _, list := range []struct {
+		ptr   string
+		items any
+	}{{opPtr, node["parameters"]}, {itemPtr, item["parameters"]}} {
+		items, _ := list.items.([]any)
+		for i := range items {
+			p, ptr, err := b.resolve(fmt.Sprintf("%s/parameters/%d", list.ptr, i))
+			if err != nil {
+				return nil, err
+			}
+			name, _ := p["name"].(string)
+			in, _ := p["in"].(string)
+			if seen[in+name] {
+				continue
+			}
+			seen[in+name] = true
+			schema, err := b.compile(ptr + "/schema")
+			if err != nil {
+				return nil, err
+			}
+			required, _ := p["required"].(bool)
+			s, _ := p["schema"].(map[string]any)
+			typ, _ := s["type"].(string)
+			items, _ := s["items"].(map[string]any)
+			itemTyp, _ := items["type"].(string)
+			op.params = append(op.params, param{name: name, in: in, required: required, typ: typ, itemTyp: itemTyp, schema: schema})
+		}
+	}
+
+	if _, ok := node["requestBody"]; ok {
+		rb, ptr, err := b.resolve(opPtr + "/requestBody")
+		if err != nil {
+			return nil, err
+		}
+		op.body = &body{}
+		op.body.required, _ = rb["required"].(bool)
+		content, _ := rb["content"].(map[string]any)
+		for contentType := range content {
+			op.body.contentTypes = append(op.body.contentTypes, contentType)
+		}
+		if _, ok := content["application/json"]; ok {
+			if op.body.json, err = b.compile(ptr + "/content/application~1json/schema"); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	responses, _ := node["responses"].(map[string]any)
+	for code := range responses {
+		resp, ptr, err := b.resolve(opPtr + "/responses/" + code)
+		if err != nil {
+			return nil, err
+		}
+		op.responses[code] = nil
+		content, _ := resp["content"].(map[string]any)
+		if _, ok := content["application/json"]; ok {
+			if op.responses[code], err = b.compile(ptr + "/content/application~1json/schema"); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return op, nil
+}
+
+// compile compiles the schema at a JSON pointer into the document
+func (b builder) compile(ptr string) (*jsonschema.Schema, error) {
+	return b.compiler.Compile(specURL + "#" + ptr)
+}
+
+// resolve returns the object at ptr, following a local $ref, together
+// with the pointer it was finally found at
+func (b builder) resolve(ptr string) (map[string]any, string, error) {
+	for range 8 {
+		node, ok := b.lookup(ptr)
+		if !ok {
+			return nil, "", fmt.Errorf("nothing at %s", ptr)
+		}
+		ref, isRef := node["$ref"].(string)
+		if !isRef {
+			return node, ptr, nil
+		}
+		next, ok := strings.CutPrefix(ref, "#")
+		if !ok {
+			return nil, "", fmt.Errorf("only local references are supported, got %s", ref)
+		}
+		ptr = next
+	}
+	return nil, "", fmt.Errorf("too many references at %s", ptr)
+}
+
+// lookup follows a JSON pointer through the document
+func (b builder) lookup(ptr string) (map[string]any, bool) {
+	var node any = b.doc
+	for _, token := range strings.Split(strings.TrimPrefix(ptr, "/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch n := node.(type) {
+		case map[string]any:
+			node = n[token]
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(n) {
+				return nil, false
+			}
+			node = n[i]
+		default:
+			return nil, false
+		}
+	}
+	obj, ok := node.(map[string]any)
+	return obj, ok
+}
+
+// escape encodes a path template as a JSON pointer token
+func escape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
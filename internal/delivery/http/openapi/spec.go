@@ -0,0 +1,168 @@
+// Package openapi holds the OpenAPI 3.1 contract of the HTTP API and
+// validates requests and responses against it.
+package openapi
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed openapi.yaml
+var specYAML []byte
+
+// specURL names the document when compiling schemas out of it
+const specURL = "openapi.json"
+
+var methods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// Spec is the parsed contract with a compiled validator per operation
+type Spec struct {
+	json   []byte
+	base   string
+	routes []route
+}
+
+// route is one path template, e.g. /books/{id}, split into segments
+type route struct {
+	template   string
+	segments   []string
+	operations map[string]*Operation
+}
+
+// Load parses the embedded document and compiles its schemas
+func Load() (*Spec, error) {
+	var doc map[string]any
+	if err := yaml.Unmarshal(specYAML, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert OpenAPI document to JSON: %w", err)
+	}
+
+	// Re-read through jsonschema so numbers keep their exact form
+	root, err := jsonschema.UnmarshalJSON(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	c := jsonschema.NewCompiler()
+	c.DefaultDraft(jsonschema.Draft2020)
+	c.AssertFormat()
+	if err := c.AddResource(specURL, root); err != nil {
+		return nil, err
+	}
+
+	s := &Spec{json: raw, base: serverBase(doc)}
+	b := builder{doc: root.(map[string]any), compiler: c}
+	paths, _ := b.doc["paths"].(map[string]any)
+	templates := make([]string, 0, len(paths))
+	for template := range paths {
+		templates = append(templates, template)
+	}
+	sort.Strings(templates)
+
+	for _, template := range templates {
+		item := paths[template].(map[string]any)
+		r := route{template: template, segments: split(template), operations: map[string]*Operation{}}
+		for _, method := range methods {
+			if _, ok := item[strings.ToLower(method)]; !ok {
+				continue
+			}
+			op, err := b.operation(template, method)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, template, err)
+			}
+			r.operations[method] = op
+		}
+		s.routes = append(s.routes, r)
+	}
+	return s, nil
+}
+
+// JSON returns the document as served at /openapi.json
+func (s *Spec) JSON() []byte {
+	return s.json
+}
+
+// Base is the path prefix of every operation, e.g. /api/v1
+func (s *Spec) Base() string {
+	return s.base
+}
+
+// Operations lists every "METHOD /template" in the document, templates
+// relative to Base
+func (s *Spec) Operations() []string {
+	var ops []string
+	for _, r := range s.routes {
+		for method := range r.operations {
+			ops = append(ops, method+" "+r.template)
+		}
+	}
+	sort.Strings(ops)
+	return ops
+}
+
+// Find returns the operation serving a request path and its path
+// parameters, or nil if the document has none
+func (s *Spec) Find(method, path string) (*Operation, map[string]string) {
+	rest, ok := strings.CutPrefix(path, s.base)
+	if !ok {
+		return nil, nil
+	}
+	segments := split(rest)
+	for _, r := range s.routes {
+		params, ok := match(r.segments, segments)
+		if !ok {
+			continue
+		}
+		if op := r.operations[method]; op != nil {
+			return op, params
+		}
+	}
+	return nil, nil
+}
+
+// match compares a template with a path segment by segment, collecting
+// {name} segments as parameters
+func match(template, path []string) (map[string]string, bool) {
+	if len(template) != len(path) {
+		return nil, false
+	}
+	params := map[string]string{}
+	for i, seg := range template {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			if path[i] == "" {
+				return nil, false
+			}
+			params[seg[1:len(seg)-1]] = path[i]
+			continue
+		}
+		if seg != path[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func split(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// serverBase is the path of the first server URL, e.g. /api/v1
+func serverBase(doc map[string]any) string {
+	servers, _ := doc["servers"].([]any)
+	if len(servers) == 0 {
+		return ""
+	}
+	server, _ := servers[0].(map[string]any)
+	url, _ := server["url"].(string)
+	return strings.TrimSuffix(url, "/")
+}
@@ -0,0 +1,86 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func mustLoad(t *testing.T) *Spec {
+	t.Helper()
+	spec, err := Load()
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	return spec
+}
+
+func TestFind_MatchesTemplatesAndCustomMethods(t *testing.T) {
+	spec := mustLoad(t)
+
+	op, params := spec.Find(http.MethodGet, "/api/v1/books/0b4f3c1e-0000-4000-8000-000000000000")
+	if op == nil || op.ID != "getBook" || params["id"] != "0b4f3c1e-0000-4000-8000-000000000000" {
+		t.Errorf("expected getBook with id, got %+v %v", op, params)
+	}
+	if op, _ := spec.Find(http.MethodPost, "/api/v1/books:import"); op == nil || op.ID != "importBooks" {
+		t.Errorf("expected importBooks, got %+v", op)
+	}
+	if op, _ := spec.Find(http.MethodPost, "/api/v1/books:shred"); op != nil {
+		t.Errorf("expected no operation for an unknown custom method, got %s", op.ID)
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	spec := mustLoad(t)
+	cases := []struct {
+		name, method, target, body string
+		wantErr                    string
+	}{
+		{"valid body", http.MethodPost, "/api/v1/books", `{"title":"Dune","author":"Frank Herbert"}`, ""},
+		{"missing property", http.MethodPost, "/api/v1/books", `{"title":"Dune"}`, "author"},
+		{"missing body", http.MethodPost, "/api/v1/books", ``, "request body: is required"},
+		{"optional body", http.MethodPost, "/api/v1/books/0b4f3c1e-0000-4000-8000-000000000000/borrow", ``, ""},
+		{"bad email", http.MethodPost, "/api/v1/books/0b4f3c1e-0000-4000-8000-000000000000/borrow", `{"on_behalf_of":"nope"}`, "on_behalf_of"},
+		{"bad path id", http.MethodGet, "/api/v1/books/42", ``, "path parameter id"},
+		{"bad integer", http.MethodGet, "/api/v1/books?limit=ten", ``, "must be an integer"},
+		{"repeated query", http.MethodGet, "/api/v1/events?book_id=0b4f3c1e-0000-4000-8000-000000000000&book_id=0b4f3c1e-0000-4000-8000-000000000001", ``, ""},
+		{"bad array item", http.MethodGet, "/api/v1/events?book_id=42", ``, "query parameter book_id"},
+		{"bad enum", http.MethodGet, "/api/v1/books:export?format=pdf", ``, "query parameter format"},
+		{"too many items", http.MethodPost, "/api/v1/loans:batch", `{"action":"borrow","book_ids":[` + strings.Repeat(`"x",`, 50) + `"x"]}`, "book_ids"},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+		if tc.body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		op, params := spec.Find(req.Method, req.URL.Path)
+		if op == nil {
+			t.Fatalf("%s: no operation for %s %s", tc.name, tc.method, tc.target)
+		}
+
+		err := op.ValidateRequest(req, params)
+
+		switch {
+		case tc.wantErr == "" && err != nil:
+			t.Errorf("%s: expected valid, got %v", tc.name, err)
+		case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+			t.Errorf("%s: expected error mentioning %q, got %v", tc.name, tc.wantErr, err)
+		}
+	}
+}
+
+func TestValidateResponse(t *testing.T) {
+	op, _ := mustLoad(t).Find(http.MethodGet, "/api/v1/books/0b4f3c1e-0000-4000-8000-000000000000")
+
+	valid := `{"ID":"0b4f3c1e-0000-4000-8000-000000000000","Title":"Dune","Author":"Frank Herbert","IsBorrowed":false,"BorrowedAt":null,"ReturnDueDate":null}`
+	if err := op.ValidateResponse(http.StatusOK, "application/json; charset=utf-8", []byte(valid)); err != nil {
+		t.Errorf("expected valid response, got %v", err)
+	}
+	if err := op.ValidateResponse(http.StatusOK, "application/json", []byte(`{"ID":"x"}`)); err == nil {
+		t.Error("expected an incomplete book to be rejected")
+	}
+	if err := op.ValidateResponse(http.StatusNotFound, "application/json", []byte(`{"error":"book not found"}`)); err != nil {
+		t.Errorf("expected error body to match default response, got %v", err)
+	}
+}
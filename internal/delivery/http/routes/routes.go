@@ -1,14 +1,29 @@
 package routes
 
 import (
+	"net/http"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 
 	"library-system/internal/delivery/http/handlers"
 )
 
-// Setup configures all routes
-func Setup(router *gin.Engine, bookHandler *handlers.BookHandler) {
-	api := router.Group("/api/v1")
+// Setup configures all routes. apiMiddleware (authentication, rate
+// limiting) runs for everything under /api/v1; probes, metrics and the API
+// docs stay anonymous and unlimited.
+func Setup(router *gin.Engine, bookHandler *handlers.BookHandler, loanHandler *handlers.LoanHandler, apiKeyHandler *handlers.APIKeyHandler, webhookHandler *handlers.WebhookHandler, importHandler *handlers.ImportHandler, exportHandler *handlers.ExportHandler, eventHandler *handlers.EventHandler, graphqlHandler http.Handler, docsHandler *handlers.DocsHandler, healthHandler *handlers.HealthHandler, metricsHandler http.Handler, apiMiddleware ...gin.HandlerFunc) {
+	router.GET("/healthz", healthHandler.Live)
+	router.GET("/readyz", healthHandler.Ready)
+	router.GET("/metrics", gin.WrapH(metricsHandler))
+	router.GET("/openapi.json", docsHandler.Spec)
+	router.GET("/docs", docsHandler.UI)
+
+	// GraphQL is versioned by its schema rather than the path, but is
+	// authenticated and limited like the REST API
+	router.Group("/graphql", apiMiddleware...).POST("", gin.WrapH(graphqlHandler))
+
+	api := router.Group("/api/v1", apiMiddleware...)
 	{
 		books := api.Group("/books")
 		{
@@ -16,7 +31,52 @@ func Setup(router *gin.Engine, bookHandler *handlers.BookHandler) {
 			books.GET("", bookHandler.ListBooks)
 			books.GET("/:id", bookHandler.GetBook)
 			books.POST("/:id/borrow", bookHandler.BorrowBook)
+			books.DELETE("/:id", bookHandler.RemoveBook)
 			books.POST("/:id/return", bookHandler.ReturnBook)
 		}
+		api.POST("/books:method", customMethods(map[string]gin.HandlerFunc{
+			"import": importHandler.ImportBooks,
+		}))
+		api.GET("/books:method", customMethods(map[string]gin.HandlerFunc{
+			"export": exportHandler.ExportBooks,
+		}))
+		api.GET("/import-jobs/:id", importHandler.GetImportJob)
+		api.GET("/events", eventHandler.Events)
+		api.GET("/loans", loanHandler.ListLoans)
+		api.POST("/loans:method", customMethods(map[string]gin.HandlerFunc{
+			"batch": loanHandler.BatchLoans,
+		}))
+
+		keys := api.Group("/admin/api-keys")
+		{
+			keys.POST("", apiKeyHandler.Issue)
+			keys.GET("", apiKeyHandler.List)
+			keys.POST("/:id/rotate", apiKeyHandler.Rotate)
+			keys.DELETE("/:id", apiKeyHandler.Revoke)
+		}
+
+		hooks := api.Group("/admin/webhooks")
+		{
+			hooks.POST("", webhookHandler.Create)
+			hooks.GET("", webhookHandler.List)
+			hooks.DELETE("/:id", webhookHandler.Delete)
+			hooks.GET("/:id/deliveries", webhookHandler.Deliveries)
+			hooks.POST("/:id/deliveries/:delivery/redeliver", webhookHandler.Redeliver)
+		}
+	}
+}
+
+// customMethods serves collection-level custom methods such as
+// POST /books:import. Gin cannot match a literal colon inside a path
+// segment, so the ":import" suffix arrives as the method parameter and is
+// dispatched here.
+func customMethods(methods map[string]gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		handle, ok := methods[strings.TrimPrefix(c.Param("method"), ":")]
+		if !ok {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		handle(c)
 	}
 }
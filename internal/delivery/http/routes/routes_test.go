@@ -0,0 +1,86 @@
+package routes
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"library-system/internal/delivery/http/openapi"
+)
+
+var (
+	ginParam  = regexp.MustCompile(`:(\w+)`)
+	specParam = regexp.MustCompile(`\{\w+\}`)
+)
+
+// newRouter wires every route to nil handlers: a request that reaches one
+// panics and is answered 500, which is enough to tell it was routed
+func newRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, _ any) {
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}))
+	Setup(router, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	return router
+}
+
+func TestSpecOperationsAreRouted(t *testing.T) {
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	router := newRouter()
+
+	for _, op := range spec.Operations() {
+		method, template, _ := strings.Cut(op, " ")
+		path := spec.Base() + strings.ReplaceAll(template, "{id}", "0b4f3c1e-0000-4000-8000-000000000000")
+		path = specParam.ReplaceAllString(path, "1")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+
+		if w.Code == http.StatusNotFound || w.Code == http.StatusMethodNotAllowed {
+			t.Errorf("%s is documented but not routed (got %d)", op, w.Code)
+		}
+	}
+}
+
+func TestRoutesAreDocumented(t *testing.T) {
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	documented := map[string]bool{}
+	for _, op := range spec.Operations() {
+		documented[op] = true
+	}
+
+	for _, r := range newRouter().Routes() {
+		template, ok := strings.CutPrefix(r.Path, spec.Base())
+		if !ok {
+			continue
+		}
+		// Custom methods share one gin route, e.g. /books:method; each
+		// needs at least one documented /books:<name>
+		if collection, ok := strings.CutSuffix(template, ":method"); ok {
+			found := false
+			for op := range documented {
+				found = found || strings.HasPrefix(op, r.Method+" "+collection+":")
+			}
+			if !found {
+				t.Errorf("%s %s has no documented custom method", r.Method, r.Path)
+			}
+			continue
+		}
+		op := r.Method + " " + ginParam.ReplaceAllString(template, "{$1}")
+		if !documented[op] {
+			t.Errorf("%s is routed but not documented", op)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package access
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"library-system/internal/domain/shared"
+)
+
+// TokenPrefix marks a string as one of our API keys, which makes leaked
+// keys easy to spot in logs and secret scanners
+const TokenPrefix = "lib_"
+
+const (
+	prefixBytes = 6  // 12 hex characters, used to look the key up
+	secretBytes = 32 // never stored, only its SHA-256
+)
+
+// --- Value Objects ---
+
+// APIKeyID identifies an API key
+type APIKeyID struct {
+	value string
+}
+
+// ParseAPIKeyID validates and creates an APIKeyID from a string
+func ParseAPIKeyID(value string) (APIKeyID, error) {
+	if _, err := uuid.Parse(value); err != nil {
+		return APIKeyID{}, ErrAPIKeyIDInvalid
+	}
+	return APIKeyID{value: value}, nil
+}
+
+func (id APIKeyID) String() string {
+	return id.value
+}
+
+// Scope limits what an API key may do
+type Scope string
+
+const (
+	ScopeCatalogRead Scope = "catalog:read" // browse the catalog only
+	ScopeLending     Scope = "lending"      // borrow and return on behalf of patrons
+	ScopeAdmin       Scope = "admin"        // everything, including key management
+)
+
+// ParseScope validates a scope name
+func ParseScope(value string) (Scope, error) {
+	switch s := Scope(value); s {
+	case ScopeCatalogRead, ScopeLending, ScopeAdmin:
+		return s, nil
+	default:
+		return "", shared.ValidationError{
+			Field:   "Scopes",
+			Message: "unknown scope " + value + "; expected catalog:read, lending or admin",
+		}
+	}
+}
+
+// --- Entity ---
+
+// APIKey is a long-lived credential for a non-interactive client. Only a
+// hash of its secret is kept; the plaintext is shown once, at issue time.
+type APIKey struct {
+	id         APIKeyID
+	name       string
+	prefix     string
+	hash       []byte
+	scopes     []Scope
+	createdAt  time.Time
+	expiresAt  *time.Time
+	revokedAt  *time.Time
+	lastUsedAt *time.Time
+	rotatedTo  *APIKeyID
+}
+
+// IssueAPIKey creates a key and returns it with its plaintext token
+func IssueAPIKey(name string, scopes []Scope, now time.Time, expiresAt *time.Time) (*APIKey, string, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, "", shared.ValidationError{Field: "Name", Message: "API key name cannot be empty"}
+	}
+	if len(name) > 100 {
+		return nil, "", shared.ValidationError{Field: "Name", Message: "API key name cannot exceed 100 characters"}
+	}
+	if len(scopes) == 0 {
+		return nil, "", ErrAPIKeyScopesNeeded
+	}
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, "", shared.ValidationError{Field: "ExpiresAt", Message: "API key expiry must be in the future"}
+	}
+
+	prefix := make([]byte, prefixBytes)
+	secret := make([]byte, secretBytes)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, "", err
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+	encodedPrefix := hex.EncodeToString(prefix)
+	encodedSecret := base64.RawURLEncoding.EncodeToString(secret)
+
+	key := &APIKey{
+		id:        APIKeyID{value: uuid.New().String()},
+		name:      name,
+		prefix:    encodedPrefix,
+		hash:      hashSecret(encodedSecret),
+		scopes:    scopes,
+		createdAt: now,
+		expiresAt: expiresAt,
+	}
+	return key, TokenPrefix + encodedPrefix + "." + encodedSecret, nil
+}
+
+// ReconstructAPIKey rebuilds an APIKey from persistence (used by repositories only)
+func ReconstructAPIKey(
+	id APIKeyID,
+	name string,
+	prefix string,
+	hash []byte,
+	scopes []Scope,
+	createdAt time.Time,
+	expiresAt *time.Time,
+	revokedAt *time.Time,
+	lastUsedAt *time.Time,
+	rotatedTo *APIKeyID,
+) *APIKey {
+	return &APIKey{
+		id:         id,
+		name:       name,
+		prefix:     prefix,
+		hash:       hash,
+		scopes:     scopes,
+		createdAt:  createdAt,
+		expiresAt:  expiresAt,
+		revokedAt:  revokedAt,
+		lastUsedAt: lastUsedAt,
+		rotatedTo:  rotatedTo,
+	}
+}
+
+// ParseToken splits a plaintext token into its lookup prefix and secret
+func ParseToken(token string) (prefix, secret string, err error) {
+	rest, ok := strings.CutPrefix(token, TokenPrefix)
+	if !ok {
+		return "", "", ErrAPIKeyMalformed
+	}
+	prefix, secret, ok = strings.Cut(rest, ".")
+	if !ok || len(prefix) != 2*prefixBytes || secret == "" {
+		return "", "", ErrAPIKeyMalformed
+	}
+	return prefix, secret, nil
+}
+
+// Getters
+func (k *APIKey) ID() APIKeyID {
+	return k.id
+}
+func (k *APIKey) Name() string {
+	return k.name
+}
+func (k *APIKey) Prefix() string {
+	return k.prefix
+}
+func (k *APIKey) Hash() []byte {
+	return k.hash
+}
+func (k *APIKey) Scopes() []Scope {
+	return k.scopes
+}
+func (k *APIKey) CreatedAt() time.Time {
+	return k.createdAt
+}
+func (k *APIKey) ExpiresAt() *time.Time {
+	return k.expiresAt
+}
+func (k *APIKey) RevokedAt() *time.Time {
+	return k.revokedAt
+}
+func (k *APIKey) LastUsedAt() *time.Time {
+	return k.lastUsedAt
+}
+func (k *APIKey) RotatedTo() *APIKeyID {
+	return k.rotatedTo
+}
+
+// Matches reports whether secret is this key's secret, in constant time
+func (k *APIKey) Matches(secret string) bool {
+	return subtle.ConstantTimeCompare(k.hash, hashSecret(secret)) == 1
+}
+
+// ActiveAt reports whether the key may authenticate requests at t
+func (k *APIKey) ActiveAt(t time.Time) bool {
+	if k.revokedAt != nil && !t.Before(*k.revokedAt) {
+		return false
+	}
+	return k.expiresAt == nil || t.Before(*k.expiresAt)
+}
+
+// Revoke disables the key immediately
+func (k *APIKey) Revoke(now time.Time) error {
+	if k.revokedAt != nil {
+		return ErrAPIKeyRevoked
+	}
+	k.revokedAt = &now
+	return nil
+}
+
+// Rotate issues a successor with the same name and scopes. The old key
+// keeps working for overlap so clients can switch without downtime, then
+// expires; an earlier existing expiry is kept.
+func (k *APIKey) Rotate(now time.Time, overlap time.Duration) (*APIKey, string, error) {
+	if k.revokedAt != nil {
+		return nil, "", ErrAPIKeyRevoked
+	}
+	if !k.ActiveAt(now) {
+		return nil, "", ErrAPIKeyExpired
+	}
+	if overlap < 0 {
+		return nil, "", shared.ValidationError{Field: "Overlap", Message: "rotation overlap cannot be negative"}
+	}
+
+	successor, token, err := IssueAPIKey(k.name, k.scopes, now, k.expiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+
+	retireAt := now.Add(overlap)
+	if k.expiresAt == nil || retireAt.Before(*k.expiresAt) {
+		k.expiresAt = &retireAt
+	}
+	k.rotatedTo = &successor.id
+	return successor, token, nil
+}
+
+func hashSecret(secret string) []byte {
+	sum := sha256.Sum256([]byte(secret))
+	return sum[:]
+}
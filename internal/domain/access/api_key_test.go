@@ -0,0 +1,121 @@
+package access
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"library-system/internal/domain/shared"
+)
+
+func issueTestKey(t *testing.T, now time.Time) (*APIKey, string) {
+	t.Helper()
+	key, token, err := IssueAPIKey("kiosk-1", []Scope{ScopeLending}, now, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, token
+}
+
+func TestIssueAPIKey_TokenMatchesStoredHash(t *testing.T) {
+	key, token := issueTestKey(t, time.Now())
+
+	prefix, secret, err := ParseToken(token)
+
+	if err != nil {
+		t.Fatalf("expected token to parse, got %v", err)
+	}
+	if !strings.HasPrefix(token, TokenPrefix) || prefix != key.Prefix() {
+		t.Errorf("expected token to carry prefix %s, got %s", key.Prefix(), token)
+	}
+	if !key.Matches(secret) {
+		t.Error("expected secret to match the stored hash")
+	}
+	if key.Matches(secret + "x") {
+		t.Error("expected a different secret not to match")
+	}
+	if strings.Contains(string(key.Hash()), secret) {
+		t.Error("expected the plaintext secret not to be stored")
+	}
+}
+
+func TestIssueAPIKey_Validation(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+
+	if _, _, err := IssueAPIKey("", []Scope{ScopeAdmin}, now, nil); !errors.Is(err, shared.ErrValidation) {
+		t.Errorf("expected validation error for empty name, got %v", err)
+	}
+	if _, _, err := IssueAPIKey("kiosk", nil, now, nil); err != ErrAPIKeyScopesNeeded {
+		t.Errorf("expected ErrAPIKeyScopesNeeded, got %v", err)
+	}
+	if _, _, err := IssueAPIKey("kiosk", []Scope{ScopeAdmin}, now, &past); !errors.Is(err, shared.ErrValidation) {
+		t.Errorf("expected validation error for past expiry, got %v", err)
+	}
+}
+
+func TestParseScope(t *testing.T) {
+	if s, err := ParseScope("catalog:read"); err != nil || s != ScopeCatalogRead {
+		t.Errorf("expected catalog:read, got %v, %v", s, err)
+	}
+	if _, err := ParseScope("root"); !errors.Is(err, shared.ErrValidation) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+func TestParseToken_Malformed(t *testing.T) {
+	for _, token := range []string{"", "abc", "lib_short.secret", "lib_0123456789ab", "lib_0123456789ab."} {
+		if _, _, err := ParseToken(token); err != ErrAPIKeyMalformed {
+			t.Errorf("%q: expected ErrAPIKeyMalformed, got %v", token, err)
+		}
+	}
+}
+
+func TestAPIKey_Revoke(t *testing.T) {
+	now := time.Now()
+	key, _ := issueTestKey(t, now)
+
+	if err := key.Revoke(now); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if key.ActiveAt(now) {
+		t.Error("expected revoked key to be inactive")
+	}
+	if err := key.Revoke(now); err != ErrAPIKeyRevoked {
+		t.Errorf("expected ErrAPIKeyRevoked, got %v", err)
+	}
+}
+
+func TestAPIKey_RotateKeepsOldKeyDuringOverlap(t *testing.T) {
+	now := time.Now()
+	key, _ := issueTestKey(t, now)
+
+	successor, token, err := key.Rotate(now, time.Hour)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if token == "" || successor.Name() != key.Name() || successor.Scopes()[0] != ScopeLending {
+		t.Errorf("expected successor with same name and scopes, got %+v", successor)
+	}
+	if key.RotatedTo() == nil || *key.RotatedTo() != successor.ID() {
+		t.Error("expected old key to point at its successor")
+	}
+	if !key.ActiveAt(now.Add(59*time.Minute)) || key.ActiveAt(now.Add(time.Hour)) {
+		t.Error("expected old key to work only during the overlap")
+	}
+	if !successor.ActiveAt(now.Add(2 * time.Hour)) {
+		t.Error("expected successor to stay active")
+	}
+}
+
+func TestAPIKey_RotateRevokedKey(t *testing.T) {
+	now := time.Now()
+	key, _ := issueTestKey(t, now)
+	_ = key.Revoke(now)
+
+	if _, _, err := key.Rotate(now, time.Hour); err != ErrAPIKeyRevoked {
+		t.Errorf("expected ErrAPIKeyRevoked, got %v", err)
+	}
+}
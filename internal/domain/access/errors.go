@@ -0,0 +1,12 @@
+package access
+
+import "errors"
+
+var (
+	ErrAPIKeyNotFound     = errors.New("API key not found")
+	ErrAPIKeyRevoked      = errors.New("API key is revoked")
+	ErrAPIKeyExpired      = errors.New("API key has expired")
+	ErrAPIKeyIDInvalid    = errors.New("API key ID must be a valid UUID")
+	ErrAPIKeyMalformed    = errors.New("API key is malformed")
+	ErrAPIKeyScopesNeeded = errors.New("API key needs at least one scope")
+)
@@ -0,0 +1,19 @@
+package access
+
+import (
+	"context"
+	"time"
+)
+
+// APIKeyRepository defines persistence operations for API keys
+type APIKeyRepository interface {
+	Add(ctx context.Context, key *APIKey) error
+	GetByID(ctx context.Context, id APIKeyID) (*APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (*APIKey, error)
+	List(ctx context.Context) ([]*APIKey, error)
+	Update(ctx context.Context, key *APIKey) error
+
+	// TouchLastUsed records that the key authenticated a request at the
+	// given time. Implementations may coarsen updates to limit writes.
+	TouchLastUsed(ctx context.Context, id APIKeyID, at time.Time) error
+}
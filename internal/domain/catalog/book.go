@@ -1,6 +1,7 @@
 package catalog
 
 import (
+	"fmt"
 	"library-system/internal/domain/shared"
 	"time"
 
@@ -85,6 +86,7 @@ type Book struct {
 	title         Title
 	author        Author
 	isBorrowed    bool
+	borrowerEmail string
 	borrowedAt    *time.Time
 	returnDueDate *time.Time
 
@@ -92,12 +94,17 @@ type Book struct {
 	version int
 }
 
-// NewBook creates a new book
+// NewBook creates a new book, raising BookAdded
 func NewBook(id BookID, title Title, author Author) *Book {
 	return &Book{
 		id:     id,
 		title:  title,
 		author: author,
+		events: []shared.DomainEvent{BookAdded{
+			BookID: id.String(),
+			Title:  title.String(),
+			Author: author.String(),
+		}},
 	}
 }
 
@@ -107,6 +114,7 @@ func ReconstructBook(
 	title Title,
 	author Author,
 	isBorrowed bool,
+	borrowerEmail string,
 	borrowedAt *time.Time,
 	returnDueDate *time.Time,
 	version int,
@@ -116,6 +124,7 @@ func ReconstructBook(
 		title:         title,
 		author:        author,
 		isBorrowed:    isBorrowed,
+		borrowerEmail: borrowerEmail,
 		borrowedAt:    borrowedAt,
 		returnDueDate: returnDueDate,
 		version:       version,
@@ -135,12 +144,26 @@ func (b *Book) Author() Author {
 func (b *Book) IsBorrowed() bool {
 	return b.isBorrowed
 }
+func (b *Book) BorrowerEmail() string {
+	return b.borrowerEmail
+}
+func (b *Book) BorrowedAt() *time.Time {
+	return b.borrowedAt
+}
+func (b *Book) ReturnDueDate() *time.Time {
+	return b.returnDueDate
+}
 func (b *Book) Version() int {
 	return b.version
 }
 
-// Borrow marks the book as borrowed.
+// Borrow marks the book as borrowed under the default loan policy.
 func (b *Book) Borrow(borrowerEmail string, borrowedAt time.Time) error {
+	return b.BorrowWithPolicy(borrowerEmail, borrowedAt, DefaultLoanPolicy())
+}
+
+// BorrowWithPolicy marks the book as borrowed, due back according to policy.
+func (b *Book) BorrowWithPolicy(borrowerEmail string, borrowedAt time.Time, policy LoanPolicy) error {
 	if b.isBorrowed {
 		return ErrBookAlreadyBorrowed
 	}
@@ -148,18 +171,15 @@ func (b *Book) Borrow(borrowerEmail string, borrowedAt time.Time) error {
 		return ErrBorrowerEmailRequired
 	}
 
-	b.isBorrowed = true
-	b.borrowedAt = &borrowedAt
-	dueDate := borrowedAt.AddDate(0, 0, 14)
-	b.returnDueDate = &dueDate
-
-	b.events = append(b.events, BookBorrowed{
+	event := BookBorrowed{
 		BookID:        b.id.String(),
 		Title:         b.title.String(),
 		BorrowedAt:    borrowedAt,
-		ReturnDate:    dueDate,
+		ReturnDate:    policy.DueDate(borrowedAt),
 		BorrowerEmail: borrowerEmail,
-	})
+	}
+	b.lend(event)
+	b.events = append(b.events, event)
 
 	return nil
 }
@@ -170,17 +190,65 @@ func (b *Book) Return() error {
 		return ErrBookNotBorrowed
 	}
 
-	b.isBorrowed = false
-	b.borrowedAt = nil
-	b.returnDueDate = nil
-
-	b.events = append(b.events, BookReturned{
+	event := BookReturned{
 		BookID: b.id.String(),
-	})
+	}
+	b.release()
+	b.events = append(b.events, event)
+
+	return nil
+}
 
+// Apply replays an event the book raised before, advancing its version.
+// BookAdded starts a book at version 0 and every later event adds one, so
+// a book rebuilt from its events, or from a snapshot and the events after
+// it, ends at the version a state-based repository would have stored.
+// Replayed events are not recorded again.
+func (b *Book) Apply(event shared.DomainEvent) error {
+	switch e := event.(type) {
+	case BookAdded:
+		id, err := ParseBookID(e.BookID)
+		if err != nil {
+			return err
+		}
+		title, err := NewTitle(e.Title)
+		if err != nil {
+			return err
+		}
+		author, err := NewAuthor(e.Author)
+		if err != nil {
+			return err
+		}
+		*b = Book{id: id, title: title, author: author}
+		return nil
+	case BookBorrowed:
+		b.lend(e)
+	case BookReturned:
+		b.release()
+	default:
+		return fmt.Errorf("cannot apply %s to a book", event.EventName())
+	}
+	b.version++
 	return nil
 }
 
+// lend puts the book on loan as described by a BookBorrowed event
+func (b *Book) lend(e BookBorrowed) {
+	borrowedAt, dueDate := e.BorrowedAt, e.ReturnDate
+	b.isBorrowed = true
+	b.borrowerEmail = e.BorrowerEmail
+	b.borrowedAt = &borrowedAt
+	b.returnDueDate = &dueDate
+}
+
+// release ends the current loan
+func (b *Book) release() {
+	b.isBorrowed = false
+	b.borrowerEmail = ""
+	b.borrowedAt = nil
+	b.returnDueDate = nil
+}
+
 // Events methods
 func (b *Book) GetEvents() []shared.DomainEvent {
 	return b.events
@@ -109,6 +109,9 @@ func TestNewBook(t *testing.T) {
 	if book.IsBorrowed() {
 		t.Error("new book should not be borrowed")
 	}
+	if events := book.GetEvents(); len(events) != 1 || events[0].EventName() != "catalog.book_added" {
+		t.Errorf("expected a BookAdded event, got %v", events)
+	}
 }
 
 func TestBook_Borrow_Success(t *testing.T) {
@@ -123,6 +126,9 @@ func TestBook_Borrow_Success(t *testing.T) {
 	if !book.IsBorrowed() {
 		t.Error("book should be borrowed")
 	}
+	if book.BorrowerEmail() != "john@example.com" {
+		t.Errorf("expected borrower to be recorded, got %q", book.BorrowerEmail())
+	}
 	if len(book.GetEvents()) != 1 {
 		t.Errorf("expected 1 event, got %d", len(book.GetEvents()))
 	}
@@ -149,6 +155,29 @@ func TestBook_Borrow_EmptyEmail(t *testing.T) {
 	}
 }
 
+func TestBook_BorrowWithPolicy_SetsDueDate(t *testing.T) {
+	book := createTestBook()
+	borrowedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	policy, _ := NewLoanPolicy(7)
+
+	err := book.BorrowWithPolicy("john@example.com", borrowedAt, policy)
+
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	want := time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC)
+	if book.ReturnDueDate() == nil || !book.ReturnDueDate().Equal(want) {
+		t.Errorf("expected due date %v, got %v", want, book.ReturnDueDate())
+	}
+}
+
+func TestNewLoanPolicy_Invalid(t *testing.T) {
+	_, err := NewLoanPolicy(0)
+	if err == nil {
+		t.Error("expected error for zero-day loan period")
+	}
+}
+
 func TestBook_Return_Success(t *testing.T) {
 	book := createTestBook()
 	_ = book.Borrow("john@example.com", time.Now())
@@ -162,6 +191,9 @@ func TestBook_Return_Success(t *testing.T) {
 	if book.IsBorrowed() {
 		t.Error("book should not be borrowed")
 	}
+	if book.BorrowerEmail() != "" {
+		t.Errorf("expected borrower to be cleared, got %q", book.BorrowerEmail())
+	}
 	if len(book.GetEvents()) != 1 {
 		t.Errorf("expected 1 event, got %d", len(book.GetEvents()))
 	}
@@ -177,11 +209,76 @@ func TestBook_Return_NotBorrowed(t *testing.T) {
 	}
 }
 
+func TestBook_Apply_ReplaysEvents(t *testing.T) {
+	original := NewBook(GenerateBookID(), mustTitle(t, "Dune"), mustAuthor(t, "Frank Herbert"))
+	_ = original.Borrow("john@example.com", time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC))
+	_ = original.Return()
+	_ = original.Borrow("jane@example.com", time.Date(2025, 3, 20, 9, 0, 0, 0, time.UTC))
+
+	book := &Book{}
+	for _, e := range original.GetEvents() {
+		if err := book.Apply(e); err != nil {
+			t.Fatalf("Apply(%s) error = %v", e.EventName(), err)
+		}
+	}
+
+	if book.ID() != original.ID() || book.Title() != original.Title() || book.Author() != original.Author() {
+		t.Errorf("expected identity to be replayed, got %s %q by %q", book.ID(), book.Title(), book.Author())
+	}
+	if !book.IsBorrowed() || book.BorrowerEmail() != "jane@example.com" {
+		t.Errorf("expected the last loan to be replayed, got borrowed=%v by %q", book.IsBorrowed(), book.BorrowerEmail())
+	}
+	if !book.ReturnDueDate().Equal(*original.ReturnDueDate()) {
+		t.Errorf("expected due date %v, got %v", original.ReturnDueDate(), book.ReturnDueDate())
+	}
+	if book.Version() != 3 {
+		t.Errorf("expected version 3 after three changes, got %d", book.Version())
+	}
+	if len(book.GetEvents()) != 0 {
+		t.Errorf("expected replayed events not to be recorded, got %d", len(book.GetEvents()))
+	}
+}
+
+func TestBook_Apply_UnknownEvent(t *testing.T) {
+	book := createTestBook()
+
+	if err := book.Apply(unknownEvent{}); err == nil {
+		t.Error("expected an error for an event books don't raise")
+	}
+	if book.Version() != 0 {
+		t.Errorf("expected version to stay 0, got %d", book.Version())
+	}
+}
+
 // --- Test Helpers ---
 
+type unknownEvent struct{}
+
+func (unknownEvent) EventName() string { return "catalog.unknown" }
+
+func mustTitle(t *testing.T, value string) Title {
+	t.Helper()
+	title, err := NewTitle(value)
+	if err != nil {
+		t.Fatalf("NewTitle(%q) error = %v", value, err)
+	}
+	return title
+}
+
+func mustAuthor(t *testing.T, value string) Author {
+	t.Helper()
+	author, err := NewAuthor(value)
+	if err != nil {
+		t.Fatalf("NewAuthor(%q) error = %v", value, err)
+	}
+	return author
+}
+
 func createTestBook() *Book {
 	id := GenerateBookID()
 	title, _ := NewTitle("Test Book")
 	author, _ := NewAuthor("Test Author")
-	return NewBook(id, title, author)
+	book := NewBook(id, title, author)
+	book.ClearEvents() // Tests count only the events they raise
+	return book
 }
@@ -1,12 +1,21 @@
 package catalog
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"library-system/internal/domain/shared"
+)
 
 var (
 	ErrBookNotFound          = errors.New("book not found")
 	ErrBookAlreadyBorrowed   = errors.New("book is already borrowed")
 	ErrBookNotBorrowed       = errors.New("book is not borrowed")
+	ErrBookOnLoan            = errors.New("book is on loan and cannot be removed")
 	ErrBorrowerEmailRequired = errors.New("borrower email is required")
 	ErrBookIDEmpty           = errors.New("book ID cannot be empty")
 	ErrBookIDInvalidFormat   = errors.New("book ID must be a valid UUID")
+	// ErrBookVersionConflict means the book changed since it was loaded,
+	// so the change was made against stale state and must be retried
+	ErrBookVersionConflict = fmt.Errorf("%w: book was changed concurrently", shared.ErrConflict)
 )
@@ -0,0 +1,44 @@
+package catalog
+
+import (
+	"time"
+
+	"library-system/internal/domain/shared"
+)
+
+// DefaultLoanDays is how long a book may be kept when no policy is configured
+const DefaultLoanDays = 14
+
+// LoanPolicy decides when a borrowed book is due back
+type LoanPolicy struct {
+	loanDays int
+}
+
+// NewLoanPolicy creates a policy lending books for the given number of days
+func NewLoanPolicy(loanDays int) (LoanPolicy, error) {
+	if loanDays <= 0 {
+		return LoanPolicy{}, shared.ValidationError{
+			Field:   "LoanDays",
+			Message: "Loan period must be at least one day",
+		}
+	}
+	return LoanPolicy{loanDays: loanDays}, nil
+}
+
+// DefaultLoanPolicy returns the standard 14-day policy
+func DefaultLoanPolicy() LoanPolicy {
+	return LoanPolicy{loanDays: DefaultLoanDays}
+}
+
+// LoanDays returns the loan period in days
+func (p LoanPolicy) LoanDays() int {
+	if p.loanDays == 0 {
+		return DefaultLoanDays
+	}
+	return p.loanDays
+}
+
+// DueDate returns when a book borrowed at borrowedAt must be returned
+func (p LoanPolicy) DueDate(borrowedAt time.Time) time.Time {
+	return borrowedAt.AddDate(0, 0, p.LoanDays())
+}
@@ -1,12 +1,30 @@
 package catalog
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // BookRepository defines persistence operations for books
 type BookRepository interface {
 	Add(ctx context.Context, book *Book) error
+	AddAll(ctx context.Context, books []*Book) error
 	GetByID(ctx context.Context, id BookID) (*Book, error)
+	// GetByIDs fetches several books in one round trip, skipping IDs that
+	// don't exist; the order of the result is unspecified
+	GetByIDs(ctx context.Context, ids []BookID) ([]*Book, error)
 	List(ctx context.Context, limit, offset int) ([]*Book, error)
+	// ListBorrowedBy fetches the books on loan to a borrower, matching the
+	// email case-insensitively
+	ListBorrowedBy(ctx context.Context, borrowerEmail string) ([]*Book, error)
+	// ListBorrowedByAny fetches the books on loan to any of several
+	// borrowers in one round trip, matching emails as ListBorrowedBy does
+	ListBorrowedByAny(ctx context.Context, borrowerEmails []string) ([]*Book, error)
+	// ListDueBefore fetches every book on loan that is due before the given
+	// time, overdue ones included, soonest due first
+	ListDueBefore(ctx context.Context, before time.Time) ([]*Book, error)
+	// Each calls fn for every book, oldest first, stopping at the first error
+	Each(ctx context.Context, fn func(*Book) error) error
 	Count(ctx context.Context) (int, error)
 	Update(ctx context.Context, book *Book) error
 	Remove(ctx context.Context, id BookID) error
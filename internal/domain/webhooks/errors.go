@@ -0,0 +1,10 @@
+package webhooks
+
+import "errors"
+
+var (
+	ErrSubscriptionNotFound  = errors.New("webhook not found")
+	ErrSubscriptionIDInvalid = errors.New("webhook ID must be a valid UUID")
+	ErrDeliveryNotFound      = errors.New("webhook delivery not found")
+	ErrDeliveryNotDead       = errors.New("only dead webhook deliveries can be redelivered")
+)
@@ -0,0 +1,23 @@
+package webhooks
+
+import "context"
+
+// SubscriptionRepository defines persistence operations for subscriptions
+type SubscriptionRepository interface {
+	Add(ctx context.Context, sub *Subscription) error
+	GetByID(ctx context.Context, id SubscriptionID) (*Subscription, error)
+	List(ctx context.Context) ([]*Subscription, error)
+	Remove(ctx context.Context, id SubscriptionID) error
+}
+
+// DeliveryRepository defines persistence operations for deliveries. They
+// are created and attempted by the dispatcher; these are for operators.
+type DeliveryRepository interface {
+	GetByID(ctx context.Context, id int64) (*Delivery, error)
+	// ListBySubscription returns up to limit deliveries, newest first; an
+	// empty status means any
+	ListBySubscription(ctx context.Context, id SubscriptionID, status DeliveryStatus, limit int) ([]*Delivery, error)
+	// Attempts returns the attempts at each delivery, oldest first
+	Attempts(ctx context.Context, deliveryIDs []int64) (map[int64][]Attempt, error)
+	Update(ctx context.Context, d *Delivery) error
+}
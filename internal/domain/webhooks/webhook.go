@@ -0,0 +1,260 @@
+package webhooks
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"library-system/internal/domain/catalog"
+	"library-system/internal/domain/shared"
+)
+
+// SecretPrefix marks a webhook signing secret
+const SecretPrefix = "whsec_"
+
+const (
+	secretBytes       = 32
+	maxURLLength      = 2048
+	maxDescriptionLen = 255
+)
+
+// EventNames lists the events a subscription can ask for
+var EventNames = []string{
+	catalog.BookAdded{}.EventName(),
+	catalog.BookBorrowed{}.EventName(),
+	catalog.BookReturned{}.EventName(),
+}
+
+// --- Value Objects ---
+
+// SubscriptionID identifies a webhook subscription
+type SubscriptionID struct {
+	value string
+}
+
+// ParseSubscriptionID validates and creates a SubscriptionID from a string
+func ParseSubscriptionID(value string) (SubscriptionID, error) {
+	if _, err := uuid.Parse(value); err != nil {
+		return SubscriptionID{}, ErrSubscriptionIDInvalid
+	}
+	return SubscriptionID{value: value}, nil
+}
+
+func (id SubscriptionID) String() string {
+	return id.value
+}
+
+// --- Entity ---
+
+// Subscription asks for some catalog events to be POSTed to a URL, signed
+// with a secret shared with the receiver
+type Subscription struct {
+	id          SubscriptionID
+	url         string
+	events      []string
+	description string
+	secret      string
+	createdAt   time.Time
+}
+
+// NewSubscription validates the endpoint and events and generates a
+// signing secret
+func NewSubscription(rawURL string, events []string, description string, now time.Time) (*Subscription, error) {
+	if err := validateURL(rawURL); err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, shared.ValidationError{Field: "Events", Message: "at least one event is required"}
+	}
+	var wanted []string
+	for _, name := range events {
+		if !slices.Contains(EventNames, name) {
+			return nil, shared.ValidationError{Field: "Events", Message: "unknown event " + name + "; expected one of " + strings.Join(EventNames, ", ")}
+		}
+		if !slices.Contains(wanted, name) {
+			wanted = append(wanted, name)
+		}
+	}
+	if len(description) > maxDescriptionLen {
+		return nil, shared.ValidationError{Field: "Description", Message: "webhook description cannot exceed 255 characters"}
+	}
+
+	secret := make([]byte, secretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return &Subscription{
+		id:          SubscriptionID{value: uuid.New().String()},
+		url:         rawURL,
+		events:      wanted,
+		description: description,
+		secret:      SecretPrefix + base64.RawURLEncoding.EncodeToString(secret),
+		createdAt:   now,
+	}, nil
+}
+
+// ReconstructSubscription rebuilds a Subscription from persistence (used by repositories only)
+func ReconstructSubscription(id SubscriptionID, url string, events []string, description, secret string, createdAt time.Time) *Subscription {
+	return &Subscription{
+		id:          id,
+		url:         url,
+		events:      events,
+		description: description,
+		secret:      secret,
+		createdAt:   createdAt,
+	}
+}
+
+func validateURL(raw string) error {
+	if len(raw) > maxURLLength {
+		return shared.ValidationError{Field: "URL", Message: "webhook URL cannot exceed 2048 characters"}
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return shared.ValidationError{Field: "URL", Message: "webhook URL must be an absolute http or https URL"}
+	}
+	if u.User != nil {
+		return shared.ValidationError{Field: "URL", Message: "webhook URL cannot contain credentials"}
+	}
+	return nil
+}
+
+// Getters
+func (s *Subscription) ID() SubscriptionID {
+	return s.id
+}
+func (s *Subscription) URL() string {
+	return s.url
+}
+func (s *Subscription) Events() []string {
+	return s.events
+}
+func (s *Subscription) Description() string {
+	return s.description
+}
+func (s *Subscription) Secret() string {
+	return s.secret
+}
+func (s *Subscription) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// --- Deliveries ---
+
+// DeliveryStatus is where a delivery is in its life
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"   // waiting for its next attempt
+	DeliverySucceeded DeliveryStatus = "succeeded" // the receiver answered 2xx
+	DeliveryDead      DeliveryStatus = "dead"      // out of attempts; see the dead-letter view
+)
+
+// ParseDeliveryStatus validates a status name
+func ParseDeliveryStatus(value string) (DeliveryStatus, error) {
+	switch s := DeliveryStatus(value); s {
+	case DeliveryPending, DeliverySucceeded, DeliveryDead:
+		return s, nil
+	default:
+		return "", shared.ValidationError{Field: "Status", Message: "unknown status " + value + "; expected pending, succeeded or dead"}
+	}
+}
+
+// Attempt is one try at a delivery. StatusCode is 0 when no response came
+// back.
+type Attempt struct {
+	At         time.Time
+	StatusCode int
+	Error      string
+	Duration   time.Duration
+}
+
+// Delivery is one event on its way to one subscription
+type Delivery struct {
+	id             int64
+	subscriptionID SubscriptionID
+	eventID        int64
+	eventName      string
+	status         DeliveryStatus
+	attempts       int
+	nextAttemptAt  time.Time
+	lastError      string
+	createdAt      time.Time
+	deliveredAt    *time.Time
+}
+
+// ReconstructDelivery rebuilds a Delivery from persistence (used by repositories only)
+func ReconstructDelivery(
+	id int64,
+	subscriptionID SubscriptionID,
+	eventID int64,
+	eventName string,
+	status DeliveryStatus,
+	attempts int,
+	nextAttemptAt time.Time,
+	lastError string,
+	createdAt time.Time,
+	deliveredAt *time.Time,
+) *Delivery {
+	return &Delivery{
+		id:             id,
+		subscriptionID: subscriptionID,
+		eventID:        eventID,
+		eventName:      eventName,
+		status:         status,
+		attempts:       attempts,
+		nextAttemptAt:  nextAttemptAt,
+		lastError:      lastError,
+		createdAt:      createdAt,
+		deliveredAt:    deliveredAt,
+	}
+}
+
+// Getters
+func (d *Delivery) ID() int64 {
+	return d.id
+}
+func (d *Delivery) SubscriptionID() SubscriptionID {
+	return d.subscriptionID
+}
+func (d *Delivery) EventID() int64 {
+	return d.eventID
+}
+func (d *Delivery) EventName() string {
+	return d.eventName
+}
+func (d *Delivery) Status() DeliveryStatus {
+	return d.status
+}
+func (d *Delivery) Attempts() int {
+	return d.attempts
+}
+func (d *Delivery) NextAttemptAt() time.Time {
+	return d.nextAttemptAt
+}
+func (d *Delivery) LastError() string {
+	return d.lastError
+}
+func (d *Delivery) CreatedAt() time.Time {
+	return d.createdAt
+}
+func (d *Delivery) DeliveredAt() *time.Time {
+	return d.deliveredAt
+}
+
+// Redeliver puts a dead delivery back in the queue with a fresh set of
+// attempts, e.g. once the receiver is fixed
+func (d *Delivery) Redeliver(now time.Time) error {
+	if d.status != DeliveryDead {
+		return ErrDeliveryNotDead
+	}
+	d.status = DeliveryPending
+	d.attempts = 0
+	d.nextAttemptAt = now
+	return nil
+}
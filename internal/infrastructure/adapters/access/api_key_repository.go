@@ -0,0 +1,192 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"library-system/internal/domain/access"
+	"library-system/internal/infrastructure/external"
+)
+
+// lastUsedResolution limits last_used_at writes to one per key per
+// interval, so a busy kiosk doesn't turn every request into an UPDATE
+const lastUsedResolution = time.Minute
+
+const selectColumns = `id, name, prefix, secret_hash, scopes, created_at, expires_at, revoked_at, last_used_at, rotated_to`
+
+// apiKeyRow represents an api_keys row in the database
+type apiKeyRow struct {
+	ID         string
+	Name       string
+	Prefix     string
+	SecretHash []byte
+	Scopes     []string
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time
+	RevokedAt  *time.Time
+	LastUsedAt *time.Time
+	RotatedTo  *string
+}
+
+// APIKeyRepository implements access.APIKeyRepository. Every statement
+// runs on the primary: a key must work as soon as it is issued and stop
+// working as soon as it is revoked, which replica lag would undermine.
+type APIKeyRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAPIKeyRepository creates a new repository on the primary pool
+func NewAPIKeyRepository(pool *pgxpool.Pool) *APIKeyRepository {
+	return &APIKeyRepository{pool: pool}
+}
+
+// Add inserts a new key
+func (r *APIKeyRepository) Add(ctx context.Context, key *access.APIKey) error {
+	_, err := external.Conn(ctx, r.pool).Exec(ctx, `
+		INSERT INTO api_keys (`+selectColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`, key.ID().String(), key.Name(), key.Prefix(), key.Hash(), scopeStrings(key.Scopes()),
+		key.CreatedAt(), key.ExpiresAt(), key.RevokedAt(), key.LastUsedAt(), idString(key.RotatedTo()))
+	if err != nil {
+		return err
+	}
+	slog.DebugContext(ctx, "API key inserted", "key_id", key.ID().String())
+	return nil
+}
+
+// GetByID fetches a key by ID, locking it inside a unit of work
+func (r *APIKeyRepository) GetByID(ctx context.Context, id access.APIKeyID) (*access.APIKey, error) {
+	query := `SELECT ` + selectColumns + ` FROM api_keys WHERE id = $1`
+	if _, ok := external.TxFromContext(ctx); ok {
+		query += ` FOR UPDATE`
+	}
+	return r.getOne(ctx, query, id.String())
+}
+
+// GetByPrefix fetches a key by the public part of its token
+func (r *APIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*access.APIKey, error) {
+	return r.getOne(ctx, `SELECT `+selectColumns+` FROM api_keys WHERE prefix = $1`, prefix)
+}
+
+// List fetches every key, newest first
+func (r *APIKeyRepository) List(ctx context.Context) ([]*access.APIKey, error) {
+	rows, err := external.Conn(ctx, r.pool).Query(ctx, `SELECT `+selectColumns+` FROM api_keys ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*access.APIKey
+	for rows.Next() {
+		row, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		key, err := rowToAPIKey(row)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// Update persists expiry, revocation and rotation changes
+func (r *APIKeyRepository) Update(ctx context.Context, key *access.APIKey) error {
+	_, err := external.Conn(ctx, r.pool).Exec(ctx, `
+		UPDATE api_keys SET expires_at = $2, revoked_at = $3, rotated_to = $4
+		WHERE id = $1
+	`, key.ID().String(), key.ExpiresAt(), key.RevokedAt(), idString(key.RotatedTo()))
+	if err != nil {
+		return err
+	}
+	slog.DebugContext(ctx, "API key updated", "key_id", key.ID().String())
+	return nil
+}
+
+// TouchLastUsed records a use, skipping the write if one was recorded
+// within lastUsedResolution
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id access.APIKeyID, at time.Time) error {
+	_, err := external.Conn(ctx, r.pool).Exec(ctx, `
+		UPDATE api_keys SET last_used_at = $2
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < $3)
+	`, id.String(), at, at.Add(-lastUsedResolution))
+	return err
+}
+
+func (r *APIKeyRepository) getOne(ctx context.Context, query string, arg any) (*access.APIKey, error) {
+	row, err := scanAPIKey(external.Conn(ctx, r.pool).QueryRow(ctx, query, arg))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rowToAPIKey(row)
+}
+
+// scanAPIKey reads one row in selectColumns order
+func scanAPIKey(row pgx.Row) (apiKeyRow, error) {
+	var k apiKeyRow
+	err := row.Scan(&k.ID, &k.Name, &k.Prefix, &k.SecretHash, &k.Scopes,
+		&k.CreatedAt, &k.ExpiresAt, &k.RevokedAt, &k.LastUsedAt, &k.RotatedTo)
+	return k, err
+}
+
+// rowToAPIKey converts a database row to a domain entity
+func rowToAPIKey(row apiKeyRow) (*access.APIKey, error) {
+	id, err := access.ParseAPIKeyID(row.ID)
+	if err != nil {
+		return nil, err
+	}
+	scopes := make([]access.Scope, 0, len(row.Scopes))
+	for _, s := range row.Scopes {
+		scope, err := access.ParseScope(s)
+		if err != nil {
+			return nil, err
+		}
+		scopes = append(scopes, scope)
+	}
+	var rotatedTo *access.APIKeyID
+	if row.RotatedTo != nil {
+		next, err := access.ParseAPIKeyID(*row.RotatedTo)
+		if err != nil {
+			return nil, err
+		}
+		rotatedTo = &next
+	}
+
+	return access.ReconstructAPIKey(
+		id,
+		row.Name,
+		row.Prefix,
+		row.SecretHash,
+		scopes,
+		row.CreatedAt,
+		row.ExpiresAt,
+		row.RevokedAt,
+		row.LastUsedAt,
+		rotatedTo,
+	), nil
+}
+
+func scopeStrings(scopes []access.Scope) []string {
+	out := make([]string, len(scopes))
+	for i, s := range scopes {
+		out[i] = string(s)
+	}
+	return out
+}
+
+func idString(id *access.APIKeyID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
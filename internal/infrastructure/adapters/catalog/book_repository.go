@@ -3,12 +3,17 @@ package catalog
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"library-system/internal/domain/catalog"
+	"library-system/internal/infrastructure/eventlog"
+	"library-system/internal/infrastructure/external"
 )
 
 // bookRow represents a book row in the database
@@ -17,21 +22,28 @@ type bookRow struct {
 	Title         string
 	Author        string
 	IsBorrowed    bool
+	BorrowerEmail *string
 	BorrowedAt    *time.Time
 	ReturnDueDate *time.Time
 	Version       int
 }
 
+// ReaderFunc picks the pool to use for a read. It is called per query so
+// reads follow replica failover and honor any staleness budget in ctx.
+type ReaderFunc func(ctx context.Context) *pgxpool.Pool
+
 // BookRepository implements catalog.BookRepository with read/write splitting.
+// Inside a unit of work every statement, reads included, runs on the
+// transaction bound to the context.
 type BookRepository struct {
 	writer *pgxpool.Pool // Primary for writes
-	reader *pgxpool.Pool // Replica for reads
+	reader ReaderFunc    // Replica for reads
 }
 
 // NewBookRepository creates a new repository.
 // writer: pool for write operations (primary)
-// reader: pool for read operations (replica, can be same as writer if no replicas)
-func NewBookRepository(writer, reader *pgxpool.Pool) *BookRepository {
+// reader: picks a pool for read operations (replica, can return writer if no replicas)
+func NewBookRepository(writer *pgxpool.Pool, reader ReaderFunc) *BookRepository {
 	return &BookRepository{
 		writer: writer,
 		reader: reader,
@@ -40,23 +52,51 @@ func NewBookRepository(writer, reader *pgxpool.Pool) *BookRepository {
 
 // Add inserts a new book (WRITE → Primary)
 func (r *BookRepository) Add(ctx context.Context, book *catalog.Book) error {
-	_, err := r.writer.Exec(
-		ctx, `INSERT INTO books (id, title, author, is_borrowed, borrowed_at, return_due_date, version)
-  		VALUES ($1, $2, $3, $4, $5, $6, $7)`, book.ID().String(), book.Title().String(), book.Author().String(), book.IsBorrowed(), nil, nil, book.Version(),
+	_, err := external.Conn(ctx, r.writer).Exec(
+		ctx, `INSERT INTO books (id, title, author, is_borrowed, borrower_email, borrowed_at, return_due_date, version)
+  		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`, book.ID().String(), book.Title().String(), book.Author().String(), book.IsBorrowed(), nullableString(book.BorrowerEmail()), book.BorrowedAt(), book.ReturnDueDate(), book.Version(),
+	)
+	if err != nil {
+		return err
+	}
+	slog.DebugContext(ctx, "book inserted", "book_id", book.ID().String())
+	return r.appendEvents(ctx, bookVersion, book)
+}
+
+// AddAll inserts books in bulk with COPY (WRITE → Primary). It is meant
+// for imports, where one INSERT per book would dominate the run time.
+func (r *BookRepository) AddAll(ctx context.Context, books []*catalog.Book) error {
+	n, err := external.Conn(ctx, r.writer).CopyFrom(ctx,
+		pgx.Identifier{"books"},
+		[]string{"id", "title", "author", "is_borrowed", "borrower_email", "borrowed_at", "return_due_date", "version"},
+		pgx.CopyFromSlice(len(books), func(i int) ([]any, error) {
+			b := books[i]
+			return []any{b.ID().String(), b.Title().String(), b.Author().String(), b.IsBorrowed(),
+				nullableString(b.BorrowerEmail()), b.BorrowedAt(), b.ReturnDueDate(), b.Version()}, nil
+		}),
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	slog.DebugContext(ctx, "books copied", "count", n)
+	return r.appendEvents(ctx, bookVersion, books...)
 }
 
-// GetByID fetches a book by ID (READ → Replica)
+// GetByID fetches a book by ID (READ → Replica).
+// Inside a unit of work the row is read from the transaction and locked,
+// so a concurrent borrow of the same book waits instead of overwriting.
 func (r *BookRepository) GetByID(ctx context.Context, id catalog.BookID) (*catalog.Book, error) {
-	var row bookRow
-	err := r.reader.QueryRow(ctx, `
-		SELECT id, title, author, is_borrowed, borrowed_at, return_due_date, version
+	query := `
+		SELECT id, title, author, is_borrowed, borrower_email, borrowed_at, return_due_date, version
 		FROM books WHERE id = $1
-	`, id.String()).Scan(
-		&row.ID, &row.Title, &row.Author,
-		&row.IsBorrowed, &row.BorrowedAt, &row.ReturnDueDate, &row.Version,
-	)
+	`
+	var conn external.Querier = r.reader(ctx)
+	if tx, ok := external.TxFromContext(ctx); ok {
+		conn = tx
+		query += ` FOR UPDATE`
+	}
+
+	row, err := scanBook(conn.QueryRow(ctx, query, id.String()))
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil
 	}
@@ -66,10 +106,28 @@ func (r *BookRepository) GetByID(ctx context.Context, id catalog.BookID) (*catal
 	return rowToBook(row)
 }
 
+// GetByIDs fetches several books at once (READ → Replica). It backs
+// batched lookups such as the GraphQL loaders, so unlike GetByID it never
+// locks rows.
+func (r *BookRepository) GetByIDs(ctx context.Context, ids []catalog.BookID) ([]*catalog.Book, error) {
+	raw := make([]string, len(ids))
+	for i, id := range ids {
+		raw[i] = id.String()
+	}
+	rows, err := external.Conn(ctx, r.reader(ctx)).Query(ctx, `
+		SELECT id, title, author, is_borrowed, borrower_email, borrowed_at, return_due_date, version
+		FROM books WHERE id = ANY($1)
+	`, raw)
+	if err != nil {
+		return nil, err
+	}
+	return collectBooks(rows)
+}
+
 // List fetches books with pagination (READ → Replica)
 func (r *BookRepository) List(ctx context.Context, limit, offset int) ([]*catalog.Book, error) {
-	rows, err := r.reader.Query(ctx, `
-		SELECT id, title, author, is_borrowed, borrowed_at, return_due_date, version
+	rows, err := external.Conn(ctx, r.reader(ctx)).Query(ctx, `
+		SELECT id, title, author, is_borrowed, borrower_email, borrowed_at, return_due_date, version
 		FROM books
 		ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2
@@ -77,48 +135,194 @@ func (r *BookRepository) List(ctx context.Context, limit, offset int) ([]*catalo
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	return collectBooks(rows)
+}
 
-	var books []*catalog.Book
-	for rows.Next() {
-		var row bookRow
-		if err := rows.Scan(
-			&row.ID, &row.Title, &row.Author,
-			&row.IsBorrowed, &row.BorrowedAt, &row.ReturnDueDate, &row.Version,
-		); err != nil {
-			return nil, err
+// ListBorrowedBy fetches the books currently on loan to a borrower,
+// soonest due first (READ → Replica). Emails compare case-insensitively,
+// as in auth.Principal.Owns.
+func (r *BookRepository) ListBorrowedBy(ctx context.Context, borrowerEmail string) ([]*catalog.Book, error) {
+	rows, err := external.Conn(ctx, r.reader(ctx)).Query(ctx, `
+		SELECT id, title, author, is_borrowed, borrower_email, borrowed_at, return_due_date, version
+		FROM books
+		WHERE lower(borrower_email) = lower($1) AND is_borrowed
+		ORDER BY return_due_date
+	`, borrowerEmail)
+	if err != nil {
+		return nil, err
+	}
+	return collectBooks(rows)
+}
+
+// ListBorrowedByAny fetches the books currently on loan to any of several
+// borrowers, soonest due first (READ → Replica). It backs the GraphQL
+// borrower loader.
+func (r *BookRepository) ListBorrowedByAny(ctx context.Context, borrowerEmails []string) ([]*catalog.Book, error) {
+	rows, err := external.Conn(ctx, r.reader(ctx)).Query(ctx, `
+		SELECT id, title, author, is_borrowed, borrower_email, borrowed_at, return_due_date, version
+		FROM books
+		WHERE lower(borrower_email) = ANY(ARRAY(SELECT lower(e) FROM unnest($1::text[]) AS e)) AND is_borrowed
+		ORDER BY return_due_date
+	`, borrowerEmails)
+	if err != nil {
+		return nil, err
+	}
+	return collectBooks(rows)
+}
+
+// ListDueBefore fetches the books on loan due before a time, soonest due
+// first (READ → Replica)
+func (r *BookRepository) ListDueBefore(ctx context.Context, before time.Time) ([]*catalog.Book, error) {
+	rows, err := external.Conn(ctx, r.reader(ctx)).Query(ctx, `
+		SELECT id, title, author, is_borrowed, borrower_email, borrowed_at, return_due_date, version
+		FROM books
+		WHERE is_borrowed AND return_due_date < $1
+		ORDER BY return_due_date
+	`, before)
+	if err != nil {
+		return nil, err
+	}
+	return collectBooks(rows)
+}
+
+// exportFetchSize is how many rows Each fetches from its cursor at a time
+const exportFetchSize = 1000
+
+// Each calls fn for every book, oldest first (READ → Replica). Rows are
+// pulled through a server-side cursor exportFetchSize at a time, so memory
+// stays flat however large the catalog is, and all of them come from one
+// repeatable-read snapshot.
+func (r *BookRepository) Each(ctx context.Context, fn func(*catalog.Book) error) error {
+	tx, err := r.reader(ctx).BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.WithoutCancel(ctx))
+
+	if _, err := tx.Exec(ctx, `
+		DECLARE books_cursor NO SCROLL CURSOR FOR
+		SELECT id, title, author, is_borrowed, borrower_email, borrowed_at, return_due_date, version
+		FROM books
+		ORDER BY created_at, id
+	`); err != nil {
+		return err
+	}
+	for {
+		rows, err := tx.Query(ctx, `FETCH FORWARD `+strconv.Itoa(exportFetchSize)+` FROM books_cursor`)
+		if err != nil {
+			return err
 		}
-		book, err := rowToBook(row)
+		books, err := collectBooks(rows)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if len(books) == 0 {
+			return nil
+		}
+		for _, book := range books {
+			if err := fn(book); err != nil {
+				return err
+			}
 		}
-		books = append(books, book)
 	}
-	return books, rows.Err()
 }
 
 // Count returns total number of books (READ → Replica)
 func (r *BookRepository) Count(ctx context.Context) (int, error) {
 	var count int
-	err := r.reader.QueryRow(ctx, `SELECT COUNT(*) FROM books`).Scan(&count)
+	err := external.Conn(ctx, r.reader(ctx)).QueryRow(ctx, `SELECT COUNT(*) FROM books`).Scan(&count)
 	return count, err
 }
 
 // Update updates an existing book (WRITE → Primary)
 func (r *BookRepository) Update(ctx context.Context, book *catalog.Book) error {
-	_, err := r.writer.Exec(ctx, `
+	var version int
+	err := external.Conn(ctx, r.writer).QueryRow(ctx, `
 		UPDATE books
-		SET title = $2, author = $3, is_borrowed = $4, borrowed_at = $5, return_due_date = $6, version = version + 1
+		SET title = $2, author = $3, is_borrowed = $4, borrower_email = $5, borrowed_at = $6, return_due_date = $7, version = version + 1
 		WHERE id = $1
+		RETURNING version
 	`, book.ID().String(), book.Title().String(), book.Author().String(),
-		book.IsBorrowed(), nil, nil)
-	return err
+		book.IsBorrowed(), nullableString(book.BorrowerEmail()), book.BorrowedAt(), book.ReturnDueDate()).Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return catalog.ErrBookNotFound
+	}
+	if err != nil {
+		return err
+	}
+	slog.DebugContext(ctx, "book updated", "book_id", book.ID().String())
+	return r.appendEvents(ctx, func(*catalog.Book, int) int { return version }, book)
+}
+
+// appendEvents logs the events the books raised alongside their rows and
+// clears them, so saving a book twice doesn't log them twice. version
+// gives the book's version as stored after its i-th pending event.
+func (r *BookRepository) appendEvents(ctx context.Context, version func(b *catalog.Book, i int) int, books ...*catalog.Book) error {
+	var entries []eventlog.Entry
+	for _, b := range books {
+		for i, e := range b.GetEvents() {
+			entries = append(entries, eventlog.Entry{BookID: b.ID().String(), Version: version(b, i), Event: e})
+		}
+	}
+	if err := eventlog.Append(ctx, r.writer, entries); err != nil {
+		return fmt.Errorf("failed to log book events: %w", err)
+	}
+	for _, b := range books {
+		b.ClearEvents()
+	}
+	return nil
 }
 
 // Remove deletes a book (WRITE → Primary)
 func (r *BookRepository) Remove(ctx context.Context, id catalog.BookID) error {
-	_, err := r.writer.Exec(ctx, `DELETE FROM books WHERE id = $1`, id.String())
-	return err
+	_, err := external.Conn(ctx, r.writer).Exec(ctx, `DELETE FROM books WHERE id = $1`, id.String())
+	if err != nil {
+		return err
+	}
+	slog.DebugContext(ctx, "book deleted", "book_id", id.String())
+	return nil
+}
+
+// bookVersion is the version of a book whose row is stored as is
+func bookVersion(b *catalog.Book, _ int) int {
+	return b.Version()
+}
+
+// scanBook reads one row in the column order of the SELECT statements above
+func scanBook(row pgx.Row) (bookRow, error) {
+	var b bookRow
+	err := row.Scan(
+		&b.ID, &b.Title, &b.Author,
+		&b.IsBorrowed, &b.BorrowerEmail, &b.BorrowedAt, &b.ReturnDueDate, &b.Version,
+	)
+	return b, err
+}
+
+// collectBooks scans and converts every row, closing rows when done
+func collectBooks(rows pgx.Rows) ([]*catalog.Book, error) {
+	defer rows.Close()
+
+	var books []*catalog.Book
+	for rows.Next() {
+		row, err := scanBook(rows)
+		if err != nil {
+			return nil, err
+		}
+		book, err := rowToBook(row)
+		if err != nil {
+			return nil, err
+		}
+		books = append(books, book)
+	}
+	return books, rows.Err()
+}
+
+// nullableString stores empty strings as NULL
+func nullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
 }
 
 // rowToBook converts a database row to a domain entity
@@ -136,11 +340,17 @@ func rowToBook(row bookRow) (*catalog.Book, error) {
 		return nil, err
 	}
 
+	var borrowerEmail string
+	if row.BorrowerEmail != nil {
+		borrowerEmail = *row.BorrowerEmail
+	}
+
 	return catalog.ReconstructBook(
 		bookID,
 		title,
 		author,
 		row.IsBorrowed,
+		borrowerEmail,
 		row.BorrowedAt,
 		row.ReturnDueDate,
 		row.Version,
@@ -0,0 +1,333 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"library-system/internal/domain/catalog"
+	"library-system/internal/domain/shared"
+	"library-system/internal/infrastructure/external"
+)
+
+// EventSourcedBookRepository implements catalog.BookRepository by storing
+// each book as its stream of events in book_events and rebuilding it with
+// Book.Apply. Every event is appended at the next version of its book, so
+// a change made against a stale copy fails with
+// catalog.ErrBookVersionConflict instead of overwriting another one.
+//
+// Every snapshotEvery versions the book's state is saved to book_snapshots
+// and loading replays only the events after it. The books table is kept
+// in the same transaction as a projection, which serves the lists, counts
+// and exports the embedded BookRepository answers.
+type EventSourcedBookRepository struct {
+	*BookRepository
+	snapshotEvery int
+}
+
+// NewEventSourcedBookRepository creates a new repository. writer and
+// reader are as for NewBookRepository; snapshotEvery of 0 disables
+// snapshots.
+func NewEventSourcedBookRepository(writer *pgxpool.Pool, reader ReaderFunc, snapshotEvery int) *EventSourcedBookRepository {
+	return &EventSourcedBookRepository{
+		BookRepository: NewBookRepository(writer, reader),
+		snapshotEvery:  snapshotEvery,
+	}
+}
+
+// bookSnapshot is the state of a book as stored in book_snapshots
+type bookSnapshot struct {
+	Title         string     `json:"title"`
+	Author        string     `json:"author"`
+	IsBorrowed    bool       `json:"is_borrowed"`
+	BorrowerEmail string     `json:"borrower_email,omitempty"`
+	BorrowedAt    *time.Time `json:"borrowed_at,omitempty"`
+	ReturnDueDate *time.Time `json:"return_due_date,omitempty"`
+}
+
+// eventDecoders decode the payload of each event a book raises, by name
+var eventDecoders = map[string]func([]byte) (shared.DomainEvent, error){
+	catalog.BookAdded{}.EventName():    decodeEvent[catalog.BookAdded],
+	catalog.BookBorrowed{}.EventName(): decodeEvent[catalog.BookBorrowed],
+	catalog.BookReturned{}.EventName(): decodeEvent[catalog.BookReturned],
+}
+
+func decodeEvent[E shared.DomainEvent](payload []byte) (shared.DomainEvent, error) {
+	var e E
+	err := json.Unmarshal(payload, &e)
+	return e, err
+}
+
+// Add starts the book's stream (WRITE → Primary)
+func (r *EventSourcedBookRepository) Add(ctx context.Context, book *catalog.Book) error {
+	return r.AddAll(ctx, []*catalog.Book{book})
+}
+
+// AddAll starts a stream for each book (WRITE → Primary). A book that
+// already has one is a conflict.
+func (r *EventSourcedBookRepository) AddAll(ctx context.Context, books []*catalog.Book) error {
+	conn := external.Conn(ctx, r.writer)
+	if err := r.appendStreams(ctx, conn, books, 0); err != nil {
+		return err
+	}
+	if _, err := conn.CopyFrom(ctx,
+		pgx.Identifier{"books"},
+		[]string{"id", "title", "author", "is_borrowed", "borrower_email", "borrowed_at", "return_due_date", "version"},
+		pgx.CopyFromSlice(len(books), func(i int) ([]any, error) {
+			b := books[i]
+			return []any{b.ID().String(), b.Title().String(), b.Author().String(), b.IsBorrowed(),
+				nullableString(b.BorrowerEmail()), b.BorrowedAt(), b.ReturnDueDate(), max(len(b.GetEvents())-1, 0)}, nil
+		}),
+	); err != nil {
+		return err
+	}
+	for _, b := range books {
+		if len(b.GetEvents()) == 0 {
+			continue
+		}
+		if err := r.snapshot(ctx, conn, b, -1, len(b.GetEvents())-1, false); err != nil {
+			return err
+		}
+	}
+	slog.DebugContext(ctx, "book streams started", "count", len(books))
+	return r.appendEvents(ctx, streamVersion(0), books...)
+}
+
+// GetByID rebuilds a book from its latest snapshot and the events after it
+// (READ → Replica). Inside a unit of work it reads from the transaction and
+// locks the book's projected row first, so concurrent changes to one book
+// wait for each other, as with the state-based repository, rather than
+// failing on a version conflict.
+//
+// A book with no stream was stored by the state-based repository; its
+// projected row stands in for a snapshot until it is next saved.
+func (r *EventSourcedBookRepository) GetByID(ctx context.Context, id catalog.BookID) (*catalog.Book, error) {
+	var conn external.Querier = r.reader(ctx)
+	if tx, ok := external.TxFromContext(ctx); ok {
+		conn = tx
+		if _, err := tx.Exec(ctx, `SELECT 1 FROM books WHERE id = $1 FOR UPDATE`, id.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	book := &catalog.Book{}
+	from := -1
+	var version int
+	var state []byte
+	err := conn.QueryRow(ctx, `SELECT version, state FROM book_snapshots WHERE book_id = $1`, id.String()).Scan(&version, &state)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+	case err != nil:
+		return nil, err
+	default:
+		if book, err = restoreSnapshot(id, version, state); err != nil {
+			return nil, fmt.Errorf("invalid snapshot of book %s: %w", id, err)
+		}
+		from = version
+	}
+
+	rows, err := conn.Query(ctx, `
+		SELECT version, name, payload FROM book_events
+		WHERE book_id = $1 AND version > $2
+		ORDER BY version
+	`, id.String(), from)
+	if err != nil {
+		return nil, err
+	}
+	replayed, err := replay(book, rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay book %s: %w", id, err)
+	}
+
+	if from < 0 && replayed == 0 {
+		row, err := scanBook(conn.QueryRow(ctx, `
+			SELECT id, title, author, is_borrowed, borrower_email, borrowed_at, return_due_date, version
+			FROM books WHERE id = $1
+		`, id.String()))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return rowToBook(row)
+	}
+	return book, nil
+}
+
+// Update appends the book's new events after the version it was loaded at
+// (WRITE → Primary)
+func (r *EventSourcedBookRepository) Update(ctx context.Context, book *catalog.Book) error {
+	events := book.GetEvents()
+	if len(events) == 0 {
+		return nil
+	}
+	expected := book.Version()
+	version := expected + len(events)
+	conn := external.Conn(ctx, r.writer)
+
+	var head int
+	if err := conn.QueryRow(ctx, `SELECT COALESCE(MAX(version), -1) FROM book_events WHERE book_id = $1`,
+		book.ID().String()).Scan(&head); err != nil {
+		return err
+	}
+	if head > expected {
+		return catalog.ErrBookVersionConflict
+	}
+	tag, err := conn.Exec(ctx, `
+		UPDATE books
+		SET title = $2, author = $3, is_borrowed = $4, borrower_email = $5, borrowed_at = $6, return_due_date = $7, version = $8
+		WHERE id = $1
+	`, book.ID().String(), book.Title().String(), book.Author().String(),
+		book.IsBorrowed(), nullableString(book.BorrowerEmail()), book.BorrowedAt(), book.ReturnDueDate(), version)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return catalog.ErrBookNotFound
+	}
+	if err := r.appendStreams(ctx, conn, []*catalog.Book{book}, expected+1); err != nil {
+		return err
+	}
+
+	// A book stored by the state-based repository has no events up to
+	// expected, so it can only be rebuilt from a snapshot
+	if err := r.snapshot(ctx, conn, book, expected, version, head < expected); err != nil {
+		return err
+	}
+	slog.DebugContext(ctx, "book events appended", "book_id", book.ID().String(), "version", version)
+	return r.appendEvents(ctx, streamVersion(expected+1), book)
+}
+
+// Remove deletes a book with its stream and snapshot (WRITE → Primary)
+func (r *EventSourcedBookRepository) Remove(ctx context.Context, id catalog.BookID) error {
+	conn := external.Conn(ctx, r.writer)
+	for _, query := range []string{
+		`DELETE FROM book_events WHERE book_id = $1`,
+		`DELETE FROM book_snapshots WHERE book_id = $1`,
+		`DELETE FROM books WHERE id = $1`,
+	} {
+		if _, err := conn.Exec(ctx, query, id.String()); err != nil {
+			return err
+		}
+	}
+	slog.DebugContext(ctx, "book stream deleted", "book_id", id.String())
+	return nil
+}
+
+// appendStreams writes the books' pending events to their streams, the
+// first one of each book at version first. Another writer having taken a
+// version already is a conflict.
+func (r *EventSourcedBookRepository) appendStreams(ctx context.Context, conn external.Querier, books []*catalog.Book, first int) error {
+	now := time.Now()
+	var rows [][]any
+	for _, b := range books {
+		for i, e := range b.GetEvents() {
+			payload, err := json.Marshal(e)
+			if err != nil {
+				return fmt.Errorf("failed to encode %s: %w", e.EventName(), err)
+			}
+			rows = append(rows, []any{b.ID().String(), first + i, e.EventName(), payload, now})
+		}
+	}
+
+	var err error
+	if len(rows) == 1 {
+		_, err = conn.Exec(ctx, `INSERT INTO book_events (book_id, version, name, payload, occurred_at) VALUES ($1, $2, $3, $4, $5)`, rows[0]...)
+	} else {
+		_, err = conn.CopyFrom(ctx, pgx.Identifier{"book_events"}, []string{"book_id", "version", "name", "payload", "occurred_at"}, pgx.CopyFromRows(rows))
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+		return catalog.ErrBookVersionConflict
+	}
+	return err
+}
+
+// snapshot saves the book's state at version when that passed a multiple
+// of snapshotEvery since previous, or when forced
+func (r *EventSourcedBookRepository) snapshot(ctx context.Context, conn external.Querier, book *catalog.Book, previous, version int, force bool) error {
+	if !force && (r.snapshotEvery <= 0 || version/r.snapshotEvery == previous/r.snapshotEvery) {
+		return nil
+	}
+	state, err := json.Marshal(bookSnapshot{
+		Title:         book.Title().String(),
+		Author:        book.Author().String(),
+		IsBorrowed:    book.IsBorrowed(),
+		BorrowerEmail: book.BorrowerEmail(),
+		BorrowedAt:    book.BorrowedAt(),
+		ReturnDueDate: book.ReturnDueDate(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = conn.Exec(ctx, `
+		INSERT INTO book_snapshots (book_id, version, state, taken_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (book_id) DO UPDATE SET version = EXCLUDED.version, state = EXCLUDED.state, taken_at = EXCLUDED.taken_at
+		WHERE book_snapshots.version < EXCLUDED.version
+	`, book.ID().String(), version, state, time.Now())
+	return err
+}
+
+// streamVersion numbers each book's pending events from first, for the
+// event log
+func streamVersion(first int) func(*catalog.Book, int) int {
+	return func(_ *catalog.Book, i int) int { return first + i }
+}
+
+// restoreSnapshot rebuilds a book from a snapshot taken at version
+func restoreSnapshot(id catalog.BookID, version int, state []byte) (*catalog.Book, error) {
+	var s bookSnapshot
+	if err := json.Unmarshal(state, &s); err != nil {
+		return nil, err
+	}
+	return rowToBook(bookRow{
+		ID:            id.String(),
+		Title:         s.Title,
+		Author:        s.Author,
+		IsBorrowed:    s.IsBorrowed,
+		BorrowerEmail: nullableString(s.BorrowerEmail),
+		BorrowedAt:    s.BorrowedAt,
+		ReturnDueDate: s.ReturnDueDate,
+		Version:       version,
+	})
+}
+
+// replay applies the stream's events to book in order, checking that they
+// follow on from its version, and returns how many there were
+func replay(book *catalog.Book, rows pgx.Rows) (int, error) {
+	defer rows.Close()
+
+	n := 0
+	for rows.Next() {
+		var version int
+		var name string
+		var payload []byte
+		if err := rows.Scan(&version, &name, &payload); err != nil {
+			return n, err
+		}
+		decode, ok := eventDecoders[name]
+		if !ok {
+			return n, fmt.Errorf("unknown event %s at version %d", name, version)
+		}
+		event, err := decode(payload)
+		if err != nil {
+			return n, fmt.Errorf("invalid %s at version %d: %w", name, version, err)
+		}
+		if err := book.Apply(event); err != nil {
+			return n, err
+		}
+		if book.Version() != version {
+			return n, fmt.Errorf("stream has a gap before version %d", version)
+		}
+		n++
+	}
+	return n, rows.Err()
+}
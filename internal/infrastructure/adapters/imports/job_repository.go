@@ -0,0 +1,93 @@
+package imports
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"library-system/internal/application/imports"
+)
+
+// JobRepository implements imports.JobRepository on the primary. It never
+// joins a unit of work: progress written while an import's transaction is
+// open must be visible to status polls straight away.
+type JobRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewJobRepository creates a new repository on the primary pool
+func NewJobRepository(pool *pgxpool.Pool) *JobRepository {
+	return &JobRepository{pool: pool}
+}
+
+// Add inserts a new job
+func (r *JobRepository) Add(ctx context.Context, job *imports.Job) error {
+	report, err := json.Marshal(job.Report)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO import_jobs (id, format, status, submitted_by, report, error, created_at, started_at, finished_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, job.ID, string(job.Format), string(job.Status), job.SubmittedBy, report, nullableString(job.Error),
+		job.CreatedAt, job.StartedAt, job.FinishedAt)
+	return err
+}
+
+// Get fetches a job by ID
+func (r *JobRepository) Get(ctx context.Context, id string) (*imports.Job, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, nil
+	}
+
+	var (
+		job            imports.Job
+		format, status string
+		report         []byte
+		jobErr         *string
+	)
+	err := r.pool.QueryRow(ctx, `
+		SELECT id, format, status, submitted_by, report, error, created_at, started_at, finished_at
+		FROM import_jobs WHERE id = $1
+	`, id).Scan(&job.ID, &format, &status, &job.SubmittedBy, &report, &jobErr,
+		&job.CreatedAt, &job.StartedAt, &job.FinishedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(report, &job.Report); err != nil {
+		return nil, err
+	}
+	job.Format = imports.Format(format)
+	job.Status = imports.Status(status)
+	if jobErr != nil {
+		job.Error = *jobErr
+	}
+	return &job, nil
+}
+
+// Update persists the job's status, progress and outcome
+func (r *JobRepository) Update(ctx context.Context, job *imports.Job) error {
+	report, err := json.Marshal(job.Report)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `
+		UPDATE import_jobs SET status = $2, report = $3, error = $4, started_at = $5, finished_at = $6
+		WHERE id = $1
+	`, job.ID, string(job.Status), report, nullableString(job.Error), job.StartedAt, job.FinishedAt)
+	return err
+}
+
+func nullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,57 @@
+package reminders
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"library-system/internal/application/reminders"
+)
+
+// Ledger implements reminders.Ledger on the primary, so every instance
+// sweeping loans sees the same claims
+type Ledger struct {
+	pool *pgxpool.Pool
+}
+
+// NewLedger creates a new ledger on the primary pool
+func NewLedger(pool *pgxpool.Pool) *Ledger {
+	return &Ledger{pool: pool}
+}
+
+// Claim inserts the reminder, reporting false if it was already there
+func (l *Ledger) Claim(ctx context.Context, s reminders.Sent) (bool, error) {
+	tag, err := l.pool.Exec(ctx, `
+		INSERT INTO reminders_sent (book_id, borrowed_at, kind, borrower_email, sent_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT DO NOTHING
+	`, s.BookID, s.BorrowedAt, string(s.Kind), s.BorrowerEmail, s.At)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// Release deletes a claimed reminder
+func (l *Ledger) Release(ctx context.Context, s reminders.Sent) error {
+	_, err := l.pool.Exec(ctx, `
+		DELETE FROM reminders_sent WHERE book_id = $1 AND borrowed_at = $2 AND kind = $3
+	`, s.BookID, s.BorrowedAt, string(s.Kind))
+	return err
+}
+
+// Forget deletes reminders sent before sentBefore whose loan has been
+// returned
+func (l *Ledger) Forget(ctx context.Context, sentBefore time.Time) (int64, error) {
+	tag, err := l.pool.Exec(ctx, `
+		DELETE FROM reminders_sent r
+		WHERE r.sent_at < $1 AND NOT EXISTS (
+			SELECT 1 FROM books b WHERE b.id = r.book_id AND b.is_borrowed AND b.borrowed_at = r.borrowed_at
+		)
+	`, sentBefore)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,156 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"library-system/internal/domain/webhooks"
+	"library-system/internal/infrastructure/external"
+)
+
+const deliveryColumns = `id, subscription_id, event_id, event_name, status, attempts, next_attempt_at, last_error, created_at, delivered_at`
+
+// DeliveryRepository implements webhooks.DeliveryRepository on the primary,
+// where the dispatcher records its attempts
+type DeliveryRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewDeliveryRepository creates a new repository on the primary pool
+func NewDeliveryRepository(pool *pgxpool.Pool) *DeliveryRepository {
+	return &DeliveryRepository{pool: pool}
+}
+
+// GetByID fetches a delivery by ID, locking it inside a unit of work so an
+// operator's change can't interleave with an attempt being recorded
+func (r *DeliveryRepository) GetByID(ctx context.Context, id int64) (*webhooks.Delivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE id = $1`
+	if _, ok := external.TxFromContext(ctx); ok {
+		query += ` FOR UPDATE`
+	}
+	d, err := scanDelivery(external.Conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return d, err
+}
+
+// ListBySubscription fetches up to limit deliveries, newest first
+func (r *DeliveryRepository) ListBySubscription(ctx context.Context, id webhooks.SubscriptionID, status webhooks.DeliveryStatus, limit int) ([]*webhooks.Delivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE subscription_id = $1 ORDER BY id DESC LIMIT $2`
+	args := []any{id.String(), limit}
+	if status != "" {
+		query = `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE subscription_id = $1 AND status = $3 ORDER BY id DESC LIMIT $2`
+		args = append(args, string(status))
+	}
+	rows, err := external.Conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*webhooks.Delivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+// Attempts fetches the attempts at each delivery, oldest first
+func (r *DeliveryRepository) Attempts(ctx context.Context, deliveryIDs []int64) (map[int64][]webhooks.Attempt, error) {
+	attempts := make(map[int64][]webhooks.Attempt, len(deliveryIDs))
+	if len(deliveryIDs) == 0 {
+		return attempts, nil
+	}
+	rows, err := external.Conn(ctx, r.pool).Query(ctx, `
+		SELECT delivery_id, attempted_at, status_code, error, duration_ms
+		FROM webhook_attempts WHERE delivery_id = ANY($1) ORDER BY id
+	`, deliveryIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			deliveryID int64
+			a          webhooks.Attempt
+			statusCode *int
+			attemptErr *string
+			durationMs int64
+		)
+		if err := rows.Scan(&deliveryID, &a.At, &statusCode, &attemptErr, &durationMs); err != nil {
+			return nil, err
+		}
+		if statusCode != nil {
+			a.StatusCode = *statusCode
+		}
+		if attemptErr != nil {
+			a.Error = *attemptErr
+		}
+		a.Duration = time.Duration(durationMs) * time.Millisecond
+		attempts[deliveryID] = append(attempts[deliveryID], a)
+	}
+	return attempts, rows.Err()
+}
+
+// Update persists a delivery's status and schedule, releasing any lease the
+// dispatcher held on it
+func (r *DeliveryRepository) Update(ctx context.Context, d *webhooks.Delivery) error {
+	_, err := external.Conn(ctx, r.pool).Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5, delivered_at = $6, locked_until = NULL
+		WHERE id = $1
+	`, d.ID(), string(d.Status()), d.Attempts(), d.NextAttemptAt(), nullableString(d.LastError()), d.DeliveredAt())
+	if err != nil {
+		return err
+	}
+	slog.DebugContext(ctx, "webhook delivery updated", "delivery_id", d.ID(), "status", string(d.Status()))
+	return nil
+}
+
+// scanDelivery reads one row in deliveryColumns order
+func scanDelivery(row pgx.Row) (*webhooks.Delivery, error) {
+	var (
+		id, eventID                 int64
+		rawSubID, eventName, status string
+		attempts                    int
+		nextAttemptAt, createdAt    time.Time
+		lastError                   *string
+		deliveredAt                 *time.Time
+	)
+	if err := row.Scan(&id, &rawSubID, &eventID, &eventName, &status, &attempts,
+		&nextAttemptAt, &lastError, &createdAt, &deliveredAt); err != nil {
+		return nil, err
+	}
+	subID, err := webhooks.ParseSubscriptionID(rawSubID)
+	if err != nil {
+		return nil, err
+	}
+	deliveryStatus, err := webhooks.ParseDeliveryStatus(status)
+	if err != nil {
+		return nil, err
+	}
+	var lastErr string
+	if lastError != nil {
+		lastErr = *lastError
+	}
+	return webhooks.ReconstructDelivery(id, subID, eventID, eventName, deliveryStatus, attempts,
+		nextAttemptAt, lastErr, createdAt, deliveredAt), nil
+}
+
+func nullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,100 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"library-system/internal/domain/webhooks"
+	"library-system/internal/infrastructure/external"
+)
+
+const subscriptionColumns = `id, url, events, description, secret, created_at`
+
+// SubscriptionRepository implements webhooks.SubscriptionRepository on the
+// primary, which the dispatcher also reads, so a new subscription is sent
+// the next event committed after it.
+type SubscriptionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSubscriptionRepository creates a new repository on the primary pool
+func NewSubscriptionRepository(pool *pgxpool.Pool) *SubscriptionRepository {
+	return &SubscriptionRepository{pool: pool}
+}
+
+// Add inserts a new subscription
+func (r *SubscriptionRepository) Add(ctx context.Context, sub *webhooks.Subscription) error {
+	_, err := external.Conn(ctx, r.pool).Exec(ctx, `
+		INSERT INTO webhook_subscriptions (`+subscriptionColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, sub.ID().String(), sub.URL(), sub.Events(), sub.Description(), sub.Secret(), sub.CreatedAt())
+	if err != nil {
+		return err
+	}
+	slog.DebugContext(ctx, "webhook subscription inserted", "webhook_id", sub.ID().String())
+	return nil
+}
+
+// GetByID fetches a subscription by ID, locking it inside a unit of work
+func (r *SubscriptionRepository) GetByID(ctx context.Context, id webhooks.SubscriptionID) (*webhooks.Subscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM webhook_subscriptions WHERE id = $1`
+	if _, ok := external.TxFromContext(ctx); ok {
+		query += ` FOR UPDATE`
+	}
+	sub, err := scanSubscription(external.Conn(ctx, r.pool).QueryRow(ctx, query, id.String()))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return sub, err
+}
+
+// List fetches every subscription, newest first
+func (r *SubscriptionRepository) List(ctx context.Context) ([]*webhooks.Subscription, error) {
+	rows, err := external.Conn(ctx, r.pool).Query(ctx, `SELECT `+subscriptionColumns+` FROM webhook_subscriptions ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []*webhooks.Subscription
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
+
+// Remove deletes a subscription along with its deliveries and attempts
+func (r *SubscriptionRepository) Remove(ctx context.Context, id webhooks.SubscriptionID) error {
+	_, err := external.Conn(ctx, r.pool).Exec(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id.String())
+	if err != nil {
+		return err
+	}
+	slog.DebugContext(ctx, "webhook subscription deleted", "webhook_id", id.String())
+	return nil
+}
+
+// scanSubscription reads one row in subscriptionColumns order
+func scanSubscription(row pgx.Row) (*webhooks.Subscription, error) {
+	var (
+		rawID, url, description, secret string
+		events                          []string
+		createdAt                       time.Time
+	)
+	if err := row.Scan(&rawID, &url, &events, &description, &secret, &createdAt); err != nil {
+		return nil, err
+	}
+	id, err := webhooks.ParseSubscriptionID(rawID)
+	if err != nil {
+		return nil, err
+	}
+	return webhooks.ReconstructSubscription(id, url, events, description, secret, createdAt), nil
+}
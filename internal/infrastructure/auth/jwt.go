@@ -0,0 +1,175 @@
+// Package auth verifies bearer tokens and maps their claims to the
+// application's Principal.
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	appauth "library-system/internal/application/auth"
+	"library-system/internal/config"
+)
+
+// ErrInvalidToken is returned for tokens that are malformed, expired,
+// signed with an unknown key or missing required claims.
+var ErrInvalidToken = errors.New("invalid bearer token")
+
+// JWTVerifier validates HS256 and RS256 tokens.
+type JWTVerifier struct {
+	secret     []byte                    // HS256 shared secret, nil if disabled
+	keys       map[string]*rsa.PublicKey // RS256 keys by kid
+	parser     *jwt.Parser
+	rolesClaim string
+}
+
+// NewJWTVerifier builds a verifier from configuration, loading the JWKS
+// file if one is configured.
+func NewJWTVerifier(cfg config.AuthConfig) (*JWTVerifier, error) {
+	v := &JWTVerifier{rolesClaim: cfg.RolesClaim}
+
+	var methods []string
+	if cfg.HS256Secret != "" {
+		v.secret = []byte(cfg.HS256Secret.Reveal())
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if cfg.JWKSFile != "" {
+		keys, err := loadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		v.keys = keys
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("no HS256 secret or JWKS file configured")
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(cfg.ClockSkew),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	v.parser = jwt.NewParser(opts...)
+
+	return v, nil
+}
+
+// Verify validates a raw token and returns the caller it identifies.
+func (v *JWTVerifier) Verify(raw string) (appauth.Principal, error) {
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(raw, claims, v.key); err != nil {
+		return appauth.Principal{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return appauth.Principal{}, fmt.Errorf("%w: missing sub claim", ErrInvalidToken)
+	}
+	email, _ := claims["email"].(string)
+
+	return appauth.Principal{
+		Subject: sub,
+		Email:   email,
+		Roles:   rolesFrom(claims[v.rolesClaim]),
+	}, nil
+}
+
+// key selects the verification key for a token based on its alg and kid.
+func (v *JWTVerifier) key(token *jwt.Token) (any, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return v.secret, nil
+	case jwt.SigningMethodRS256.Alg():
+		kid, _ := token.Header["kid"].(string)
+		if key, ok := v.keys[kid]; ok {
+			return key, nil
+		}
+		if kid == "" && len(v.keys) == 1 {
+			for _, key := range v.keys {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	default:
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+}
+
+// rolesFrom accepts either a JSON array of strings or a space-separated
+// string, the two shapes identity providers commonly use.
+func rolesFrom(claim any) []string {
+	switch v := claim.(type) {
+	case string:
+		return strings.Fields(v)
+	case []any:
+		roles := make([]string, 0, len(v))
+		for _, r := range v {
+			if s, ok := r.(string); ok && s != "" {
+				roles = append(roles, s)
+			}
+		}
+		return roles
+	default:
+		return nil
+	}
+}
+
+type jwks struct {
+	Keys []struct {
+		Kid string `json:"kid"`
+		Kty string `json:"kty"`
+		Use string `json:"use"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+// loadJWKS reads RSA signing keys from a JWKS file. Keys of other types
+// or for other uses are skipped.
+func loadJWKS(path string) (map[string]*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+	var set jwks
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS file: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus for key %q: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent for key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no RSA signing keys in %s", path)
+	}
+	return keys, nil
+}
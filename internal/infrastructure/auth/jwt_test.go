@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"library-system/internal/config"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func testConfig() config.AuthConfig {
+	cfg := config.Default().Auth
+	cfg.HS256Secret = testSecret
+	cfg.Issuer = "library-test"
+	return cfg
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	raw, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   "user-1",
+		"email": "reader@example.com",
+		"iss":   "library-test",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{"patron", "librarian"},
+	}
+}
+
+func TestVerify_HS256(t *testing.T) {
+	v, err := NewJWTVerifier(testConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := v.Verify(sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", validClaims()))
+
+	if err != nil {
+		t.Fatalf("expected valid token, got %v", err)
+	}
+	if p.Subject != "user-1" || p.Email != "reader@example.com" {
+		t.Errorf("unexpected principal %+v", p)
+	}
+	if !p.HasRole("librarian") || !p.HasRole("patron") {
+		t.Errorf("expected roles from claim, got %v", p.Roles)
+	}
+}
+
+func TestVerify_RejectsInvalidTokens(t *testing.T) {
+	v, err := NewJWTVerifier(testConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expired := validClaims()
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+	wrongIssuer := validClaims()
+	wrongIssuer["iss"] = "someone-else"
+	noExpiry := validClaims()
+	delete(noExpiry, "exp")
+	noSubject := validClaims()
+	delete(noSubject, "sub")
+
+	tests := map[string]string{
+		"wrong secret": sign(t, jwt.SigningMethodHS256, []byte("another-secret-entirely"), "", validClaims()),
+		"expired":      sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", expired),
+		"wrong issuer": sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", wrongIssuer),
+		"no expiry":    sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", noExpiry),
+		"no subject":   sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", noSubject),
+		"alg none":     sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", validClaims()),
+		"malformed":    "not-a-token",
+	}
+	for name, raw := range tests {
+		if _, err := v.Verify(raw); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%s: expected ErrInvalidToken, got %v", name, err)
+		}
+	}
+}
+
+func TestVerify_RS256FromJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	jwksJSON := fmt.Sprintf(`{"keys":[{"kty":"RSA","use":"sig","kid":"k1","n":%q,"e":%q}]}`,
+		base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()))
+	if err := os.WriteFile(path, []byte(jwksJSON), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := testConfig()
+	cfg.HS256Secret = ""
+	cfg.JWKSFile = path
+	v, err := NewJWTVerifier(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims := validClaims()
+	claims["roles"] = "patron librarian"
+	p, err := v.Verify(sign(t, jwt.SigningMethodRS256, key, "k1", claims))
+
+	if err != nil {
+		t.Fatalf("expected valid token, got %v", err)
+	}
+	if len(p.Roles) != 2 {
+		t.Errorf("expected space-separated roles to be split, got %v", p.Roles)
+	}
+	if _, err := v.Verify(sign(t, jwt.SigningMethodRS256, key, "unknown", claims)); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected unknown kid to be rejected, got %v", err)
+	}
+	if _, err := v.Verify(sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims)); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected HS256 to be rejected when only JWKS is configured, got %v", err)
+	}
+}
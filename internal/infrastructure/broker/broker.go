@@ -0,0 +1,11 @@
+// Package broker implements ports.EventPublisher for NATS and for Kafka
+// or Kafka-compatible brokers
+package broker
+
+// Headers sent with every event besides the envelope itself
+const (
+	contentTypeHeader = "Content-Type"
+	specVersionHeader = "Event-Spec-Version"
+	eventTypeHeader   = "Event-Type"
+	contentType       = "application/json"
+)
@@ -0,0 +1,64 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"library-system/internal/application/ports"
+)
+
+// KafkaPublisher publishes events to one topic on Kafka or a compatible
+// broker such as Redpanda. Records are keyed by aggregate ID, so every
+// event of a book lands on the same partition in order; the event type is
+// also sent as a header for consumers that filter without decoding.
+type KafkaPublisher struct {
+	client *kgo.Client
+}
+
+// NewKafkaPublisher creates a publisher producing to topic through the
+// given seed brokers. The producer is idempotent, so its own retries don't
+// duplicate records.
+func NewKafkaPublisher(brokers []string, topic string, timeout time.Duration) (*KafkaPublisher, error) {
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(brokers...),
+		kgo.DefaultProduceTopic(topic),
+		kgo.ClientID("library-system"),
+		kgo.RecordDeliveryTimeout(timeout),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka client: %w", err)
+	}
+	return &KafkaPublisher{client: client}, nil
+}
+
+// Publish produces the events and waits for every one to be acknowledged
+func (p *KafkaPublisher) Publish(ctx context.Context, events []ports.EventEnvelope) error {
+	records := make([]*kgo.Record, len(events))
+	for i, e := range events {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		records[i] = &kgo.Record{
+			Key:   []byte(e.AggregateID),
+			Value: data,
+			Headers: []kgo.RecordHeader{
+				{Key: eventTypeHeader, Value: []byte(e.Type)},
+				{Key: contentTypeHeader, Value: []byte(contentType)},
+				{Key: specVersionHeader, Value: []byte(strconv.Itoa(e.SpecVersion))},
+			},
+		}
+	}
+	return p.client.ProduceSync(ctx, records...).FirstErr()
+}
+
+// Close flushes pending records and closes the client
+func (p *KafkaPublisher) Close() error {
+	p.client.Close()
+	return nil
+}
@@ -0,0 +1,78 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"library-system/internal/application/ports"
+)
+
+func TestKafkaPublisherProducesKeyedRecords(t *testing.T) {
+	const topic = "library.catalog.events"
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.SeedTopics(3, topic))
+	if err != nil {
+		t.Fatalf("failed to start Kafka cluster: %v", err)
+	}
+	defer cluster.Close()
+
+	publisher, err := NewKafkaPublisher(cluster.ListenAddrs(), topic, 5*time.Second)
+	if err != nil {
+		t.Fatalf("NewKafkaPublisher() error = %v", err)
+	}
+	defer publisher.Close()
+	events := testEvents()
+	if err := publisher.Publish(context.Background(), events); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	consumer, err := kgo.NewClient(kgo.SeedBrokers(cluster.ListenAddrs()...), kgo.ConsumeTopics(topic),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()))
+	if err != nil {
+		t.Fatalf("failed to create consumer: %v", err)
+	}
+	defer consumer.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var records []*kgo.Record
+	for len(records) < len(events) {
+		fetches := consumer.PollFetches(ctx)
+		if err := ctx.Err(); err != nil {
+			t.Fatalf("received %d of %d records", len(records), len(events))
+		}
+		records = append(records, fetches.Records()...)
+	}
+
+	// Both events are of one book, so they share a partition and keep
+	// their order
+	for i, want := range events {
+		r := records[i]
+		if string(r.Key) != want.AggregateID {
+			t.Errorf("record %d key = %q, want %q", i, r.Key, want.AggregateID)
+		}
+		if r.Partition != records[0].Partition {
+			t.Errorf("record %d partition = %d, want %d", i, r.Partition, records[0].Partition)
+		}
+		var eventType string
+		for _, h := range r.Headers {
+			if h.Key == eventTypeHeader {
+				eventType = string(h.Value)
+			}
+		}
+		if eventType != want.Type {
+			t.Errorf("record %d %s = %q, want %q", i, eventTypeHeader, eventType, want.Type)
+		}
+		var got ports.EventEnvelope
+		if err := json.Unmarshal(r.Value, &got); err != nil {
+			t.Fatalf("invalid envelope: %v", err)
+		}
+		if got.ID != want.ID || got.Type != want.Type || !got.OccurredAt.Equal(want.OccurredAt) {
+			t.Errorf("record %d envelope = %+v, want %+v", i, got, want)
+		}
+	}
+}
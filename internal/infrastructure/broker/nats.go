@@ -0,0 +1,70 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"library-system/internal/application/ports"
+)
+
+// NATSPublisher publishes each event to the subject <prefix>.<type>, e.g.
+// library.catalog.book_borrowed, so consumers can subscribe to
+// library.catalog.> or to single event types. The event ID is sent as
+// Nats-Msg-Id, which lets a JetStream stream over these subjects drop
+// republished events within its duplicate window.
+type NATSPublisher struct {
+	conn    *nats.Conn
+	prefix  string
+	timeout time.Duration
+}
+
+// NewNATSPublisher connects to the NATS server at url. Like the Kafka
+// client it keeps retrying in the background when the server is down,
+// so the API starts without it; Publish fails until it is back.
+func NewNATSPublisher(url, subjectPrefix string, timeout time.Duration) (*NATSPublisher, error) {
+	conn, err := nats.Connect(url,
+		nats.Name("library-system"),
+		nats.Timeout(timeout),
+		nats.MaxReconnects(-1),
+		nats.RetryOnFailedConnect(true),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS at %s: %w", url, err)
+	}
+	return &NATSPublisher{conn: conn, prefix: subjectPrefix, timeout: timeout}, nil
+}
+
+// Publish sends the events in order and waits for the server to receive
+// them, for at most the connection timeout when ctx has no deadline
+func (p *NATSPublisher) Publish(ctx context.Context, events []ports.EventEnvelope) error {
+	for _, e := range events {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		msg := nats.NewMsg(p.prefix + "." + e.Type)
+		msg.Data = data
+		msg.Header.Set(nats.MsgIdHdr, strconv.FormatInt(e.ID, 10))
+		msg.Header.Set(contentTypeHeader, contentType)
+		msg.Header.Set(specVersionHeader, strconv.Itoa(e.SpecVersion))
+		if err := p.conn.PublishMsg(msg); err != nil {
+			return fmt.Errorf("failed to publish event %d: %w", e.ID, err)
+		}
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+	return p.conn.FlushWithContext(ctx)
+}
+
+// Close flushes pending events and closes the connection
+func (p *NATSPublisher) Close() error {
+	return p.conn.Drain()
+}
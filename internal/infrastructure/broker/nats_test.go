@@ -0,0 +1,86 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+
+	"library-system/internal/application/ports"
+)
+
+// startNATS runs an embedded NATS server on a free port
+func startNATS(t *testing.T) string {
+	t.Helper()
+	ns, err := server.NewServer(&server.Options{Host: "127.0.0.1", Port: -1, NoLog: true, NoSigs: true})
+	if err != nil {
+		t.Fatalf("failed to create NATS server: %v", err)
+	}
+	go ns.Start()
+	t.Cleanup(ns.Shutdown)
+	if !ns.ReadyForConnections(5 * time.Second) {
+		t.Fatal("NATS server did not start")
+	}
+	return ns.ClientURL()
+}
+
+func testEvents() []ports.EventEnvelope {
+	at := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	return []ports.EventEnvelope{
+		{SpecVersion: ports.EnvelopeSpecVersion, ID: 7, Type: "catalog.book_added", OccurredAt: at,
+			AggregateType: "book", AggregateID: "book-1", AggregateVersion: 1, Data: json.RawMessage(`{"title":"Dune"}`)},
+		{SpecVersion: ports.EnvelopeSpecVersion, ID: 8, Type: "catalog.book_borrowed", OccurredAt: at,
+			AggregateType: "book", AggregateID: "book-1", AggregateVersion: 2, Data: json.RawMessage(`{"borrower_email":"a@example.com"}`)},
+	}
+}
+
+func TestNATSPublisherPublishesToTypedSubjects(t *testing.T) {
+	url := startNATS(t)
+	sub, err := nats.Connect(url)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer sub.Close()
+	msgs := make(chan *nats.Msg, 10)
+	if _, err := sub.ChanSubscribe("library.catalog.>", msgs); err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	if err := sub.Flush(); err != nil {
+		t.Fatalf("failed to flush subscription: %v", err)
+	}
+
+	publisher, err := NewNATSPublisher(url, "library", time.Second)
+	if err != nil {
+		t.Fatalf("NewNATSPublisher() error = %v", err)
+	}
+	defer publisher.Close()
+	events := testEvents()
+	if err := publisher.Publish(context.Background(), events); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	for _, want := range events {
+		select {
+		case msg := <-msgs:
+			if msg.Subject != "library."+want.Type {
+				t.Errorf("subject = %q, want %q", msg.Subject, "library."+want.Type)
+			}
+			if id := msg.Header.Get(nats.MsgIdHdr); id != strconv.FormatInt(want.ID, 10) {
+				t.Errorf("%s = %q, want %d", nats.MsgIdHdr, id, want.ID)
+			}
+			var got ports.EventEnvelope
+			if err := json.Unmarshal(msg.Data, &got); err != nil {
+				t.Fatalf("invalid envelope: %v", err)
+			}
+			if got.ID != want.ID || got.AggregateVersion != want.AggregateVersion || string(got.Data) != string(want.Data) {
+				t.Errorf("envelope = %+v, want %+v", got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("event %d not received", want.ID)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package eventlog
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"library-system/internal/application/ports"
+)
+
+const (
+	// pollBatch is how many events one poll reads at most
+	pollBatch = 500
+	// subscriberBuffer is how many events a subscriber may fall behind
+	// before it is dropped
+	subscriberBuffer = 256
+)
+
+// Source is the part of the log a Feed follows
+type Source interface {
+	After(ctx context.Context, after ports.EventPosition, bookIDs []string, limit int) ([]ports.LoggedEvent, error)
+	Latest(ctx context.Context) (ports.EventPosition, error)
+}
+
+// Feed polls the log and fans new events out to subscribers in commit
+// order. The source only returns transactions older than every one still
+// running, so the feed never passes an event that commits later; a long
+// transaction holds back what commits after it until it finishes.
+type Feed struct {
+	source   Source
+	interval time.Duration
+
+	mu     sync.Mutex
+	cursor ports.EventPosition
+	subs   map[chan ports.LoggedEvent]struct{}
+	ready  chan struct{}
+	once   sync.Once
+	closed bool
+}
+
+// NewFeed creates a feed polling source every interval. It delivers
+// nothing until Run has started.
+func NewFeed(source Source, interval time.Duration) *Feed {
+	return &Feed{
+		source:   source,
+		interval: interval,
+		subs:     make(map[chan ports.LoggedEvent]struct{}),
+		ready:    make(chan struct{}),
+	}
+}
+
+// Subscribe implements ports.EventFeed. It waits for the feed to find the
+// end of the log when called before that.
+func (f *Feed) Subscribe(ctx context.Context) (<-chan ports.LoggedEvent, ports.EventPosition, func(), error) {
+	select {
+	case <-f.ready:
+	case <-ctx.Done():
+		return nil, ports.EventPosition{}, nil, ctx.Err()
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return nil, ports.EventPosition{}, nil, ports.ErrEventFeedClosed
+	}
+	ch := make(chan ports.LoggedEvent, subscriberBuffer)
+	f.subs[ch] = struct{}{}
+	cancel := func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.drop(ch)
+	}
+	return ch, f.cursor, cancel, nil
+}
+
+// Close ends every subscription and refuses new ones, so open streams
+// finish while the HTTP server drains.
+func (f *Feed) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	for ch := range f.subs {
+		f.drop(ch)
+	}
+	f.once.Do(func() { close(f.ready) })
+}
+
+// Run starts at the end of the log and polls every interval until ctx is
+// cancelled.
+func (f *Feed) Run(ctx context.Context) error {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		if f.start(ctx) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := f.poll(ctx); err != nil && ctx.Err() == nil {
+				slog.WarnContext(ctx, "failed to poll event log", "error", err)
+			}
+		}
+	}
+}
+
+// start positions the cursor at the newest event, reporting whether it
+// succeeded
+func (f *Feed) start(ctx context.Context) bool {
+	latest, err := f.source.Latest(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.WarnContext(ctx, "failed to find end of event log", "error", err)
+		}
+		return false
+	}
+	f.mu.Lock()
+	f.cursor = latest
+	f.mu.Unlock()
+	f.once.Do(func() { close(f.ready) })
+	return true
+}
+
+// poll delivers everything committed since the last poll
+func (f *Feed) poll(ctx context.Context) error {
+	for {
+		f.mu.Lock()
+		cursor := f.cursor
+		f.mu.Unlock()
+
+		events, err := f.source.After(ctx, cursor, nil, pollBatch)
+		if err != nil {
+			return err
+		}
+
+		f.mu.Lock()
+		f.deliver(events)
+		f.mu.Unlock()
+		if len(events) < pollBatch {
+			return nil
+		}
+	}
+}
+
+// deliver sends events to every subscriber. f.mu must be held.
+func (f *Feed) deliver(events []ports.LoggedEvent) {
+	for _, e := range events {
+		f.cursor = e.Position
+		for ch := range f.subs {
+			select {
+			case ch <- e:
+			default:
+				// Too far behind; it resumes from the log on reconnect
+				f.drop(ch)
+			}
+		}
+	}
+}
+
+// drop ends a subscription. f.mu must be held.
+func (f *Feed) drop(ch chan ports.LoggedEvent) {
+	if _, ok := f.subs[ch]; ok {
+		delete(f.subs, ch)
+		close(ch)
+	}
+}
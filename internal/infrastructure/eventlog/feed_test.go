@@ -0,0 +1,216 @@
+package eventlog
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"library-system/internal/application/ports"
+	"library-system/internal/domain/catalog"
+)
+
+// memorySource is an in-memory log. Like Store, it hides every event from
+// transactions that are still running or started after the oldest one
+// still running.
+type memorySource struct {
+	mu      sync.Mutex
+	events  []ports.LoggedEvent
+	running map[uint64]bool
+}
+
+// write logs events with the given IDs for transaction tx, which stays
+// running until committed
+func (s *memorySource) write(tx uint64, ids ...int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running == nil {
+		s.running = make(map[uint64]bool)
+	}
+	s.running[tx] = true
+	for _, id := range ids {
+		s.events = append(s.events, ports.LoggedEvent{Position: ports.EventPosition{TxID: tx, ID: id}, Event: catalog.BookReturned{}})
+	}
+}
+
+func (s *memorySource) commitTx(tx uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, tx)
+}
+
+// commit logs and commits one event per ID, each in its own transaction
+// numbered after the ID
+func (s *memorySource) commit(ids ...int64) {
+	for _, id := range ids {
+		s.write(uint64(id), id)
+		s.commitTx(uint64(id))
+	}
+}
+
+// visible reports whether events of tx are past the snapshot xmin. s.mu
+// must be held.
+func (s *memorySource) visible(tx uint64) bool {
+	for running := range s.running {
+		if running <= tx {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *memorySource) After(_ context.Context, after ports.EventPosition, _ []string, limit int) ([]ports.LoggedEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []ports.LoggedEvent
+	for _, e := range s.events {
+		if after.Less(e.Position) && s.visible(e.Position.TxID) {
+			out = append(out, e)
+		}
+	}
+	slices.SortFunc(out, func(a, b ports.LoggedEvent) int {
+		return cmp.Or(cmp.Compare(a.Position.TxID, b.Position.TxID), cmp.Compare(a.Position.ID, b.Position.ID))
+	})
+	return out[:min(limit, len(out))], nil
+}
+
+func (s *memorySource) Latest(context.Context) (ports.EventPosition, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var latest ports.EventPosition
+	for _, e := range s.events {
+		if s.visible(e.Position.TxID) && latest.Less(e.Position) {
+			latest = e.Position
+		}
+	}
+	return latest, nil
+}
+
+func newStartedFeed(t *testing.T, source *memorySource) *Feed {
+	t.Helper()
+	f := NewFeed(source, time.Hour)
+	if !f.start(context.Background()) {
+		t.Fatal("expected feed to start")
+	}
+	return f
+}
+
+func received(ch <-chan ports.LoggedEvent) []int64 {
+	var ids []int64
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				return ids
+			}
+			ids = append(ids, e.Position.ID)
+		default:
+			return ids
+		}
+	}
+}
+
+func TestFeed_DeliversNewEventsAfterCursor(t *testing.T) {
+	source := &memorySource{}
+	source.commit(1, 2)
+	f := newStartedFeed(t, source)
+	ch, cursor, cancel, err := f.Subscribe(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cancel()
+
+	source.commit(3, 4)
+	if err := f.poll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if cursor != (ports.EventPosition{TxID: 2, ID: 2}) {
+		t.Errorf("expected cursor at the end of the log, got %v", cursor)
+	}
+	if got := received(ch); len(got) != 2 || got[0] != 3 || got[1] != 4 {
+		t.Errorf("expected events 3 and 4, got %v", got)
+	}
+}
+
+func TestFeed_HoldsLaterCommitsBehindRunningTransaction(t *testing.T) {
+	source := &memorySource{}
+	f := newStartedFeed(t, source)
+	ch, _, cancel, _ := f.Subscribe(context.Background())
+	defer cancel()
+
+	// Transaction 10 writes 1 and keeps running while 11 writes 2 and commits
+	source.write(10, 1)
+	source.write(11, 2)
+	source.commitTx(11)
+	_ = f.poll(context.Background())
+	if got := received(ch); len(got) != 0 {
+		t.Fatalf("expected the feed to wait for transaction 10, got %v", got)
+	}
+
+	source.commitTx(10)
+	_ = f.poll(context.Background())
+	if got := received(ch); len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("expected 1 then 2 once transaction 10 committed, got %v", got)
+	}
+}
+
+func TestFeed_DeliversLowerIDCommittedLate(t *testing.T) {
+	source := &memorySource{}
+	f := newStartedFeed(t, source)
+	ch, _, cancel, _ := f.Subscribe(context.Background())
+	defer cancel()
+
+	// Transaction 20 started first but logged its event, 5, after the
+	// longer transaction 21 logged 3; 20 commits and is delivered first
+	source.write(20, 5)
+	source.commitTx(20)
+	source.write(21, 3)
+	_ = f.poll(context.Background())
+	if got := received(ch); len(got) != 1 || got[0] != 5 {
+		t.Fatalf("expected 5 first, got %v", got)
+	}
+
+	source.commitTx(21)
+	_ = f.poll(context.Background())
+	if got := received(ch); len(got) != 1 || got[0] != 3 {
+		t.Errorf("expected 3 to be delivered after 5 despite its lower ID, got %v", got)
+	}
+}
+
+func TestFeed_DropsSlowSubscriber(t *testing.T) {
+	source := &memorySource{}
+	f := newStartedFeed(t, source)
+	slow, _, cancelSlow, _ := f.Subscribe(context.Background())
+	defer cancelSlow()
+
+	for id := int64(1); id <= subscriberBuffer+1; id++ {
+		source.commit(id)
+	}
+	_ = f.poll(context.Background())
+
+	if got := received(slow); len(got) != subscriberBuffer {
+		t.Errorf("expected %d buffered events before the drop, got %d", subscriberBuffer, len(got))
+	}
+	if _, ok := <-slow; ok {
+		t.Error("expected slow subscriber's channel to be closed")
+	}
+}
+
+func TestFeed_CloseEndsSubscriptions(t *testing.T) {
+	f := newStartedFeed(t, &memorySource{})
+	ch, _, cancel, _ := f.Subscribe(context.Background())
+	defer cancel()
+
+	f.Close()
+
+	if _, ok := <-ch; ok {
+		t.Error("expected channel to be closed")
+	}
+	if _, _, _, err := f.Subscribe(context.Background()); !errors.Is(err, ports.ErrEventFeedClosed) {
+		t.Errorf("expected ErrEventFeedClosed, got %v", err)
+	}
+}
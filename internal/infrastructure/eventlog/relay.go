@@ -0,0 +1,128 @@
+package eventlog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"library-system/internal/application/ports"
+)
+
+// Relay publishes the log to a message broker in commit order, keeping its
+// position in event_publisher_cursor.
+//
+// Like webhook fan-out it orders events by the transaction that wrote them
+// and only reads transactions older than every one still running, so none
+// is skipped. The cursor row stays locked while a batch is published, so
+// only one instance publishes at a time, and it only moves once the broker
+// has accepted the batch.
+type Relay struct {
+	pool      *pgxpool.Pool
+	publisher ports.EventPublisher
+	interval  time.Duration
+	batchSize int
+	timeout   time.Duration
+}
+
+// NewRelay creates a relay polling the log on pool, which must be the
+// primary, every interval and publishing up to batchSize events at a time,
+// each batch within timeout
+func NewRelay(pool *pgxpool.Pool, publisher ports.EventPublisher, interval time.Duration, batchSize int, timeout time.Duration) *Relay {
+	return &Relay{pool: pool, publisher: publisher, interval: interval, batchSize: batchSize, timeout: timeout}
+}
+
+// Run publishes new events every interval until ctx is cancelled
+func (r *Relay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			for ctx.Err() == nil {
+				n, err := r.publishBatch(ctx)
+				if err != nil {
+					if ctx.Err() == nil {
+						slog.WarnContext(ctx, "failed to publish events", "error", err)
+					}
+					break
+				}
+				if n < r.batchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// publishBatch publishes the next batch and returns how many events it held
+func (r *Relay) publishBatch(ctx context.Context) (int, error) {
+	var published int
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var cursorTx string
+		var cursorID int64
+		err := tx.QueryRow(ctx, `SELECT tx_id::text, event_id FROM event_publisher_cursor WHERE id = 1 FOR UPDATE SKIP LOCKED`).
+			Scan(&cursorTx, &cursorID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, `
+			SELECT id, tx_id::text, name, book_id, aggregate_version, payload, occurred_at FROM events
+			WHERE (tx_id, id) > ($1::text::xid8, $2) AND tx_id < pg_snapshot_xmin(pg_current_snapshot())
+			ORDER BY tx_id, id LIMIT $3
+		`, cursorTx, cursorID, r.batchSize)
+		if err != nil {
+			return err
+		}
+		var events []ports.EventEnvelope
+		for rows.Next() {
+			e := ports.EventEnvelope{SpecVersion: ports.EnvelopeSpecVersion, AggregateType: "book"}
+			var payload []byte
+			if err := rows.Scan(&e.ID, &cursorTx, &e.Type, &e.AggregateID, &e.AggregateVersion, &payload, &e.OccurredAt); err != nil {
+				rows.Close()
+				return err
+			}
+			if e.TxID, err = strconv.ParseUint(cursorTx, 10, 64); err != nil {
+				rows.Close()
+				return err
+			}
+			e.Data = json.RawMessage(payload)
+			events = append(events, e)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		publishCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+		if err := r.publisher.Publish(publishCtx, events); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `UPDATE event_publisher_cursor SET tx_id = $1::text::xid8, event_id = $2 WHERE id = 1`,
+			cursorTx, events[len(events)-1].ID); err != nil {
+			return err
+		}
+		published = len(events)
+		return nil
+	})
+	if published > 0 {
+		slog.DebugContext(ctx, "events published", "count", published)
+	}
+	return published, err
+}
@@ -0,0 +1,179 @@
+// Package eventlog keeps domain events in the events table, written in the
+// same transaction as the change that raised them, and follows the table
+// as it grows.
+package eventlog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"library-system/internal/application/ports"
+	"library-system/internal/domain/catalog"
+	"library-system/internal/domain/shared"
+	"library-system/internal/infrastructure/external"
+)
+
+// Entry is an event waiting to be appended, with the book that raised it
+// and the book's version once the change is saved
+type Entry struct {
+	BookID  string
+	Version int
+	Event   shared.DomainEvent
+}
+
+// Append records entries on the primary. Inside a unit of work they are
+// written by its transaction, so they commit or roll back with the change
+// that raised them.
+func Append(ctx context.Context, pool *pgxpool.Pool, entries []Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	rows := make([][]any, len(entries))
+	for i, e := range entries {
+		payload, err := json.Marshal(e.Event)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", e.Event.EventName(), err)
+		}
+		rows[i] = []any{e.Event.EventName(), e.BookID, e.Version, payload}
+	}
+
+	conn := external.Conn(ctx, pool)
+	if len(rows) == 1 {
+		_, err := conn.Exec(ctx, `INSERT INTO events (name, book_id, aggregate_version, payload) VALUES ($1, $2, $3, $4)`, rows[0]...)
+		return err
+	}
+	// Imports raise one event per book, so bulk appends use COPY
+	_, err := conn.CopyFrom(ctx, pgx.Identifier{"events"}, []string{"name", "book_id", "aggregate_version", "payload"}, pgx.CopyFromRows(rows))
+	return err
+}
+
+// Store reads the log and purges entries past their retention
+type Store struct {
+	pool      *pgxpool.Pool
+	retention time.Duration
+}
+
+// NewStore creates a store reading from pool, which must be the primary:
+// replicas apply commits in the same order but may lag behind the feed.
+func NewStore(pool *pgxpool.Pool, retention time.Duration) *Store {
+	return &Store{pool: pool, retention: retention}
+}
+
+// After implements ports.EventLog. Like webhook fan-out and the relay it
+// only reads transactions older than every one still running, so an event
+// can never commit behind a position already returned.
+func (s *Store) After(ctx context.Context, after ports.EventPosition, bookIDs []string, limit int) ([]ports.LoggedEvent, error) {
+	query := `
+		SELECT id, tx_id::text, name, book_id, payload, occurred_at FROM events
+		WHERE (tx_id, id) > ($1::text::xid8, $2) AND tx_id < pg_snapshot_xmin(pg_current_snapshot())
+		ORDER BY tx_id, id LIMIT $3`
+	args := []any{strconv.FormatUint(after.TxID, 10), after.ID, limit}
+	if len(bookIDs) > 0 {
+		query = `
+			SELECT id, tx_id::text, name, book_id, payload, occurred_at FROM events
+			WHERE (tx_id, id) > ($1::text::xid8, $2) AND tx_id < pg_snapshot_xmin(pg_current_snapshot()) AND book_id = ANY($4)
+			ORDER BY tx_id, id LIMIT $3`
+		args = append(args, bookIDs)
+	}
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []ports.LoggedEvent
+	for rows.Next() {
+		var e ports.LoggedEvent
+		var txID, name string
+		var payload []byte
+		if err := rows.Scan(&e.Position.ID, &txID, &name, &e.BookID, &payload, &e.OccurredAt); err != nil {
+			return nil, err
+		}
+		if e.Position.TxID, err = strconv.ParseUint(txID, 10, 64); err != nil {
+			return nil, fmt.Errorf("event %d: %w", e.Position.ID, err)
+		}
+		if e.Event, err = decode(name, payload); err != nil {
+			return nil, fmt.Errorf("event %d: %w", e.Position.ID, err)
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// Latest returns the position of the newest event After can return, or
+// the zero position if there is none.
+func (s *Store) Latest(ctx context.Context) (ports.EventPosition, error) {
+	var p ports.EventPosition
+	var txID string
+	err := s.pool.QueryRow(ctx, `
+		SELECT tx_id::text, id FROM events
+		WHERE tx_id < pg_snapshot_xmin(pg_current_snapshot())
+		ORDER BY tx_id DESC, id DESC LIMIT 1
+	`).Scan(&txID, &p.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.EventPosition{}, nil
+	}
+	if err != nil {
+		return ports.EventPosition{}, err
+	}
+	p.TxID, err = strconv.ParseUint(txID, 10, 64)
+	return p, err
+}
+
+// Run purges events older than the retention every hour until ctx is
+// cancelled. Streams resuming from a purged event continue with the oldest
+// one left.
+func (s *Store) Run(ctx context.Context) error {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			tag, err := s.pool.Exec(ctx, `DELETE FROM events WHERE occurred_at < $1`, time.Now().Add(-s.retention))
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				slog.WarnContext(ctx, "failed to purge old events", "error", err)
+				continue
+			}
+			if n := tag.RowsAffected(); n > 0 {
+				slog.DebugContext(ctx, "purged old events", "count", n)
+			}
+		}
+	}
+}
+
+// decode rebuilds a domain event from its logged name and payload
+func decode(name string, payload []byte) (shared.DomainEvent, error) {
+	var event shared.DomainEvent
+	var err error
+	switch name {
+	case catalog.BookAdded{}.EventName():
+		var e catalog.BookAdded
+		err = json.Unmarshal(payload, &e)
+		event = e
+	case catalog.BookBorrowed{}.EventName():
+		var e catalog.BookBorrowed
+		err = json.Unmarshal(payload, &e)
+		event = e
+	case catalog.BookReturned{}.EventName():
+		var e catalog.BookReturned
+		err = json.Unmarshal(payload, &e)
+		event = e
+	default:
+		return nil, fmt.Errorf("unknown event %q", name)
+	}
+	return event, err
+}
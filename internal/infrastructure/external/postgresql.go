@@ -2,44 +2,126 @@ package external
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"library-system/internal/application/consistency"
+	"library-system/internal/config"
+	"library-system/internal/infrastructure/tracing"
 )
 
+// replicationLagQuery reports how far a replica is behind the primary, in seconds.
+// A replica streaming from the primary that has replayed everything it
+// received is considered caught up, otherwise an idle primary would make
+// every replica look increasingly stale. One whose WAL receiver is not
+// streaming falls behind without receiving anything, so its lag is the age
+// of the last transaction it replayed, or NULL (unknown) if it has replayed
+// none.
+const replicationLagQuery = `
+	WITH receiver AS (
+		SELECT EXISTS (SELECT 1 FROM pg_stat_wal_receiver WHERE status = 'streaming') AS streaming
+	)
+	SELECT CASE
+		WHEN NOT pg_is_in_recovery() THEN 0
+		WHEN streaming AND pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		WHEN streaming THEN COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+		ELSE EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp())
+	END::float8
+	FROM receiver
+`
+
+// ReplicaStatus is a point-in-time view of a replica's health.
+type ReplicaStatus struct {
+	Name        string
+	Healthy     bool
+	Lag         time.Duration
+	LastError   string
+	LastChecked time.Time
+}
+
+// replica wraps a replica pool with its last observed health.
+type replica struct {
+	name string
+	pool *pgxpool.Pool
+
+	healthy    atomic.Bool
+	lag        atomic.Int64 // nanoseconds
+	measuredAt atomic.Int64 // when lag was last measured, unix nanoseconds
+
+	mu          sync.Mutex
+	lastError   string
+	lastChecked time.Time
+}
+
+func (r *replica) status() ReplicaStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return ReplicaStatus{
+		Name:        r.name,
+		Healthy:     r.healthy.Load(),
+		Lag:         time.Duration(r.lag.Load()),
+		LastError:   r.lastError,
+		LastChecked: r.lastChecked,
+	}
+}
+
 // DBCluster manages primary and replica connections with read/write splitting.
+// Replicas are probed periodically; unhealthy or lagging replicas stop
+// receiving reads until they recover.
 type DBCluster struct {
-	primary  *pgxpool.Pool
-	replicas []*pgxpool.Pool
-	counter  uint64
+	primary     *pgxpool.Pool
+	replicas    []*replica
+	counter     uint64
+	healthCheck config.HealthCheckConfig
 }
 
 // NewDBCluster creates a database cluster with primary and optional replicas.
-func NewDBCluster(ctx context.Context, primaryURL string, replicaURLs []string) (*DBCluster, error) {
-	primary, err := newPool(ctx, primaryURL, 100, 150)
+// The primary must be reachable. Replicas that cannot be reached at startup
+// are kept but marked unhealthy, and are reinstated once a probe succeeds.
+func NewDBCluster(ctx context.Context, cfg config.DatabaseConfig) (*DBCluster, error) {
+	primary, err := newPool(ctx, "primary", "primary", cfg.PrimaryURL, cfg.Primary, cfg.ConnectTimeout)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to primary: %w", err)
 	}
+	if err := ping(ctx, primary, cfg.ConnectTimeout); err != nil {
+		primary.Close()
+		return nil, fmt.Errorf("failed to connect to primary: failed to ping database: %w", err)
+	}
 
-	var replicas []*pgxpool.Pool
-	for i, url := range replicaURLs {
-		replica, err := newPool(ctx, url, 200, 300)
+	var replicas []*replica
+	for i, url := range cfg.ReplicaURLs {
+		name := fmt.Sprintf("replica-%d", i+1)
+		pool, err := newPool(ctx, name, "replica", url, cfg.Replica, cfg.ConnectTimeout)
 		if err != nil {
-			// Close already created pools
+			// A malformed URL is a configuration error, not an outage
 			primary.Close()
 			for _, r := range replicas {
-				r.Close()
+				r.pool.Close()
 			}
-			return nil, fmt.Errorf("failed to connect to replica %d: %w", i+1, err)
+			return nil, fmt.Errorf("failed to configure replica %d: %w", i+1, err)
 		}
-		replicas = append(replicas, replica)
+
+		r := &replica{name: name, pool: pool}
+		if err := ping(ctx, pool, cfg.ConnectTimeout); err != nil {
+			slog.Warn("replica unavailable at startup", "replica", r.name, "error", err)
+			r.lastError = err.Error()
+		} else {
+			r.healthy.Store(true)
+		}
+		r.lastChecked = time.Now()
+		replicas = append(replicas, r)
 	}
 
 	return &DBCluster{
-		primary:  primary,
-		replicas: replicas,
+		primary:     primary,
+		replicas:    replicas,
+		healthCheck: cfg.HealthCheck,
 	}, nil
 }
 
@@ -48,40 +130,180 @@ func (c *DBCluster) Primary() *pgxpool.Pool {
 	return c.primary
 }
 
-// Replica returns a replica pool for read operations (round-robin).
-// Falls back to primary if no replicas are configured.
+// Replica returns a healthy replica pool for read operations (round-robin).
+// Falls back to primary if no replicas are configured or none are healthy.
 func (c *DBCluster) Replica() *pgxpool.Pool {
-	if len(c.replicas) == 0 {
+	return c.pick(func(r *replica) bool { return r.healthy.Load() })
+}
+
+// ReplicaFor returns a pool for a read made with ctx. If ctx carries a
+// staleness budget, only healthy replicas whose lag (as of their last probe)
+// fits the budget are eligible; otherwise the read goes to the primary.
+// A lag measured longer ago than a probe interval plus its timeout is no
+// longer known, as the probes have stopped or keep failing, so it fits no
+// budget.
+func (c *DBCluster) ReplicaFor(ctx context.Context) *pgxpool.Pool {
+	budget, ok := consistency.MaxStaleness(ctx)
+	if !ok {
+		return c.Replica()
+	}
+
+	if budget == 0 {
+		return c.primary
+	}
+	measuredSince := time.Now().Add(-(c.healthCheck.Interval + c.healthCheck.Timeout)).UnixNano()
+	return c.pick(func(r *replica) bool {
+		return r.healthy.Load() && r.measuredAt.Load() >= measuredSince && time.Duration(r.lag.Load()) <= budget
+	})
+}
+
+// pick returns the next eligible replica in round-robin order, or the primary.
+func (c *DBCluster) pick(eligible func(*replica) bool) *pgxpool.Pool {
+	n := uint64(len(c.replicas))
+	if n == 0 {
 		return c.primary
 	}
-	idx := atomic.AddUint64(&c.counter, 1) % uint64(len(c.replicas))
-	return c.replicas[idx]
+	start := atomic.AddUint64(&c.counter, 1)
+	for i := uint64(0); i < n; i++ {
+		r := c.replicas[(start+i)%n]
+		if eligible(r) {
+			return r.pool
+		}
+	}
+	return c.primary
+}
+
+// NamedPool identifies a pool in the cluster for monitoring.
+type NamedPool struct {
+	Name string // "primary", "replica-1", ...
+	Role string // "primary" or "replica"
+	Pool *pgxpool.Pool
+}
+
+// Pools returns the primary followed by every replica.
+func (c *DBCluster) Pools() []NamedPool {
+	pools := []NamedPool{{Name: "primary", Role: "primary", Pool: c.primary}}
+	for _, r := range c.replicas {
+		pools = append(pools, NamedPool{Name: r.name, Role: "replica", Pool: r.pool})
+	}
+	return pools
+}
+
+// ReplicaStatuses returns the last observed health of every replica.
+func (c *DBCluster) ReplicaStatuses() []ReplicaStatus {
+	statuses := make([]ReplicaStatus, len(c.replicas))
+	for i, r := range c.replicas {
+		statuses[i] = r.status()
+	}
+	return statuses
+}
+
+// RunHealthChecks probes replicas until ctx is cancelled. It is meant to be
+// run as a background worker and must be stopped before Close.
+func (c *DBCluster) RunHealthChecks(ctx context.Context) error {
+	if len(c.replicas) == 0 {
+		<-ctx.Done()
+		return nil
+	}
+	cfg := c.healthCheck
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			c.checkReplicas(ctx, cfg)
+		}
+	}
+}
+
+// checkReplicas probes all replicas concurrently so one hung replica
+// doesn't delay the others.
+func (c *DBCluster) checkReplicas(ctx context.Context, cfg config.HealthCheckConfig) {
+	var wg sync.WaitGroup
+	for _, r := range c.replicas {
+		wg.Add(1)
+		go func(r *replica) {
+			defer wg.Done()
+			c.checkReplica(ctx, r, cfg)
+		}(r)
+	}
+	wg.Wait()
+}
+
+func (c *DBCluster) checkReplica(ctx context.Context, r *replica, cfg config.HealthCheckConfig) {
+	probeCtx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	var lagSeconds *float64
+	err := r.pool.QueryRow(probeCtx, replicationLagQuery).Scan(&lagSeconds)
+	if err == nil && lagSeconds == nil {
+		err = errors.New("replication lag unknown: WAL receiver is not streaming")
+	}
+	var lag time.Duration
+	if err == nil {
+		lag = time.Duration(*lagSeconds * float64(time.Second))
+		r.lag.Store(int64(lag))
+		r.measuredAt.Store(time.Now().UnixNano())
+		if cfg.MaxReplicationLag > 0 && lag > cfg.MaxReplicationLag {
+			err = fmt.Errorf("replication lag %s exceeds %s", lag.Round(time.Millisecond), cfg.MaxReplicationLag)
+		}
+	}
+
+	r.mu.Lock()
+	r.lastChecked = time.Now()
+	if err != nil {
+		r.lastError = err.Error()
+	} else {
+		r.lastError = ""
+	}
+	r.mu.Unlock()
+
+	healthy := err == nil
+	if was := r.healthy.Swap(healthy); was != healthy {
+		if healthy {
+			slog.Info("replica reinstated", "replica", r.name, "lag_ms", lag.Milliseconds())
+		} else {
+			slog.Warn("replica ejected", "replica", r.name, "error", err)
+		}
+	}
 }
 
 // Close closes all connections in the cluster.
 func (c *DBCluster) Close() {
 	c.primary.Close()
 	for _, r := range c.replicas {
-		r.Close()
+		r.pool.Close()
 	}
 }
 
-func newPool(ctx context.Context, connString string, minConns, maxConns int32) (*pgxpool.Pool, error) {
-	config, err := pgxpool.ParseConfig(connString)
+// newPool builds a pool without connecting; callers decide whether
+// an unreachable database is fatal. Queries are traced with the pool's
+// name and role.
+func newPool(ctx context.Context, name, role, connString string, cfg config.PoolConfig, connectTimeout time.Duration) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(connString)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
 
-	config.MinConns = minConns
-	config.MaxConns = maxConns
-	config.MaxConnIdleTime = 30 * time.Second
+	poolConfig.MinConns = cfg.MinConns
+	poolConfig.MaxConns = cfg.MaxConns
+	poolConfig.MaxConnIdleTime = cfg.MaxConnIdleTime
+	poolConfig.ConnConfig.ConnectTimeout = connectTimeout
+	poolConfig.ConnConfig.Tracer = tracing.NewQueryTracer(name, role)
 
-	pool, err := pgxpool.NewWithConfig(ctx, config)
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create pool: %w", err)
 	}
-	if err := pool.Ping(ctx); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
-	}
 	return pool, nil
 }
+
+func ping(ctx context.Context, pool *pgxpool.Pool, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return pool.Ping(ctx)
+}
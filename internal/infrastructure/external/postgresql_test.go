@@ -0,0 +1,119 @@
+package external
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"library-system/internal/application/consistency"
+	"library-system/internal/config"
+)
+
+func newTestCluster(healthy ...bool) *DBCluster {
+	cluster := &DBCluster{
+		primary:     &pgxpool.Pool{},
+		healthCheck: config.HealthCheckConfig{Interval: 5 * time.Second, Timeout: 2 * time.Second},
+	}
+	for _, h := range healthy {
+		r := &replica{pool: &pgxpool.Pool{}}
+		r.healthy.Store(h)
+		r.measuredAt.Store(time.Now().UnixNano())
+		cluster.replicas = append(cluster.replicas, r)
+	}
+	return cluster
+}
+
+func TestReplica_NoReplicasFallsBackToPrimary(t *testing.T) {
+	cluster := newTestCluster()
+
+	if cluster.Replica() != cluster.primary {
+		t.Error("expected primary when no replicas are configured")
+	}
+}
+
+func TestReplica_SkipsUnhealthyReplicas(t *testing.T) {
+	cluster := newTestCluster(false, true, false)
+
+	for i := 0; i < 6; i++ {
+		if got := cluster.Replica(); got != cluster.replicas[1].pool {
+			t.Fatalf("expected only the healthy replica to be returned")
+		}
+	}
+}
+
+func TestReplica_AllUnhealthyFallsBackToPrimary(t *testing.T) {
+	cluster := newTestCluster(false, false)
+
+	if cluster.Replica() != cluster.primary {
+		t.Error("expected primary when every replica is unhealthy")
+	}
+}
+
+func TestReplica_RoundRobinsHealthyReplicas(t *testing.T) {
+	cluster := newTestCluster(true, true)
+
+	seen := map[*pgxpool.Pool]bool{}
+	for i := 0; i < 4; i++ {
+		seen[cluster.Replica()] = true
+	}
+	if len(seen) != 2 {
+		t.Errorf("expected reads spread over 2 replicas, got %d", len(seen))
+	}
+}
+
+func TestReplicaFor_NoBudgetUsesAnyHealthyReplica(t *testing.T) {
+	cluster := newTestCluster(true)
+	cluster.replicas[0].lag.Store(int64(time.Minute))
+
+	if cluster.ReplicaFor(context.Background()) != cluster.replicas[0].pool {
+		t.Error("expected replica when no staleness budget is set")
+	}
+}
+
+func TestReplicaFor_ZeroBudgetUsesPrimary(t *testing.T) {
+	cluster := newTestCluster(true)
+	ctx := consistency.WithMaxStaleness(context.Background(), 0)
+
+	if cluster.ReplicaFor(ctx) != cluster.primary {
+		t.Error("expected primary for a zero staleness budget")
+	}
+}
+
+func TestReplicaFor_SkipsReplicasOverBudget(t *testing.T) {
+	cluster := newTestCluster(true, true)
+	cluster.replicas[0].lag.Store(int64(10 * time.Second))
+	cluster.replicas[1].lag.Store(int64(100 * time.Millisecond))
+	ctx := consistency.WithMaxStaleness(context.Background(), time.Second)
+
+	for i := 0; i < 4; i++ {
+		if cluster.ReplicaFor(ctx) != cluster.replicas[1].pool {
+			t.Fatal("expected only the replica within budget to be returned")
+		}
+	}
+}
+
+func TestReplicaFor_NoReplicaFreshEnoughUsesPrimary(t *testing.T) {
+	cluster := newTestCluster(true)
+	cluster.replicas[0].lag.Store(int64(5 * time.Second))
+	ctx := consistency.WithMaxStaleness(context.Background(), time.Second)
+
+	if cluster.ReplicaFor(ctx) != cluster.primary {
+		t.Error("expected primary when no replica is fresh enough")
+	}
+}
+
+func TestReplicaFor_StaleLagMeasurementUsesPrimary(t *testing.T) {
+	cluster := newTestCluster(true)
+	cluster.replicas[0].lag.Store(int64(100 * time.Millisecond))
+	cluster.replicas[0].measuredAt.Store(time.Now().Add(-time.Minute).UnixNano())
+	ctx := consistency.WithMaxStaleness(context.Background(), time.Second)
+
+	if cluster.ReplicaFor(ctx) != cluster.primary {
+		t.Error("expected primary when the replica's lag was last measured a minute ago")
+	}
+	if cluster.ReplicaFor(context.Background()) != cluster.replicas[0].pool {
+		t.Error("expected reads without a budget to still use the replica")
+	}
+}
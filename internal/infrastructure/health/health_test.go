@@ -0,0 +1,30 @@
+package health
+
+import "testing"
+
+func TestOverallStatus(t *testing.T) {
+	ok := MigrationReport{Status: StatusOK}
+	behind := MigrationReport{Status: StatusUnavailable}
+	primary := PoolReport{Role: "primary", Status: StatusOK}
+	primaryDown := PoolReport{Role: "primary", Status: StatusUnavailable}
+	replica := PoolReport{Role: "replica", Status: StatusOK}
+	replicaDown := PoolReport{Role: "replica", Status: StatusUnavailable}
+
+	tests := []struct {
+		name       string
+		pools      []PoolReport
+		migrations MigrationReport
+		want       Status
+	}{
+		{"all healthy", []PoolReport{primary, replica, replica}, ok, StatusOK},
+		{"one replica down", []PoolReport{primary, replica, replicaDown}, ok, StatusDegraded},
+		{"all replicas down", []PoolReport{primary, replicaDown, replicaDown}, ok, StatusDegraded},
+		{"primary down", []PoolReport{primaryDown, replica}, ok, StatusUnavailable},
+		{"schema behind", []PoolReport{primary, replica}, behind, StatusUnavailable},
+	}
+	for _, tt := range tests {
+		if got := overallStatus(tt.pools, tt.migrations); got != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, got)
+		}
+	}
+}
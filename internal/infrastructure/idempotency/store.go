@@ -0,0 +1,143 @@
+// Package idempotency stores Idempotency-Key reservations and the
+// responses they produced, so retried commands can be answered without
+// running them twice.
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Record is a stored request. Completed is false while the first request
+// holding the key is still running.
+type Record struct {
+	Fingerprint []byte
+	Completed   bool
+	Status      int
+	ContentType string
+	Body        []byte
+}
+
+// Store persists idempotency keys.
+type Store interface {
+	// Reserve claims key for the caller in scope. It returns nil if the key
+	// was free (or expired, or abandoned) and is now held, otherwise the
+	// existing record.
+	Reserve(ctx context.Context, scope, key string, fingerprint []byte) (*Record, error)
+	// Complete stores the response for a held key.
+	Complete(ctx context.Context, scope, key string, rec Record) error
+	// Release drops a held key so the request can be retried, e.g. after a
+	// server error.
+	Release(ctx context.Context, scope, key string) error
+}
+
+// PostgresStore keeps keys in the idempotency_keys table on the primary.
+type PostgresStore struct {
+	pool        *pgxpool.Pool
+	ttl         time.Duration
+	lockTimeout time.Duration
+}
+
+// NewPostgresStore creates a store whose keys expire after ttl. A
+// reservation without a response after lockTimeout is treated as
+// abandoned (its request crashed) and may be claimed again.
+func NewPostgresStore(pool *pgxpool.Pool, ttl, lockTimeout time.Duration) *PostgresStore {
+	return &PostgresStore{pool: pool, ttl: ttl, lockTimeout: lockTimeout}
+}
+
+// Reserve implements Store.
+func (s *PostgresStore) Reserve(ctx context.Context, scope, key string, fingerprint []byte) (*Record, error) {
+	now := time.Now()
+	var reserved bool
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO idempotency_keys (scope, key, fingerprint, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (scope, key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint, status = NULL, content_type = NULL, body = NULL,
+			created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at < $4
+			OR (idempotency_keys.status IS NULL AND idempotency_keys.created_at < $6)
+		RETURNING true
+	`, scope, key, fingerprint, now, now.Add(s.ttl), now.Add(-s.lockTimeout)).Scan(&reserved)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	// Someone else holds the key; report what they stored
+	var rec Record
+	var status *int
+	var contentType *string
+	err = s.pool.QueryRow(ctx, `
+		SELECT fingerprint, status, content_type, body
+		FROM idempotency_keys WHERE scope = $1 AND key = $2
+	`, scope, key).Scan(&rec.Fingerprint, &status, &contentType, &rec.Body)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Released between the two statements; let the caller retry
+		return s.Reserve(ctx, scope, key, fingerprint)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if status != nil {
+		rec.Completed = true
+		rec.Status = *status
+	}
+	if contentType != nil {
+		rec.ContentType = *contentType
+	}
+	return &rec, nil
+}
+
+// Complete implements Store.
+func (s *PostgresStore) Complete(ctx context.Context, scope, key string, rec Record) error {
+	_, err := s.pool.Exec(ctx, `
+		UPDATE idempotency_keys SET status = $3, content_type = $4, body = $5
+		WHERE scope = $1 AND key = $2
+	`, scope, key, rec.Status, rec.ContentType, rec.Body)
+	return err
+}
+
+// Release implements Store.
+func (s *PostgresStore) Release(ctx context.Context, scope, key string) error {
+	_, err := s.pool.Exec(ctx, `
+		DELETE FROM idempotency_keys WHERE scope = $1 AND key = $2 AND status IS NULL
+	`, scope, key)
+	return err
+}
+
+// Run purges expired keys every interval until ctx is cancelled.
+func (s *PostgresStore) Run(ctx context.Context) error {
+	interval := s.ttl / 24
+	if interval < time.Minute {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			tag, err := s.pool.Exec(ctx, `DELETE FROM idempotency_keys WHERE expires_at < $1`, time.Now())
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				slog.WarnContext(ctx, "failed to purge expired idempotency keys", "error", err)
+				continue
+			}
+			if n := tag.RowsAffected(); n > 0 {
+				slog.DebugContext(ctx, "purged expired idempotency keys", "count", n)
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// Worker is a background process that runs until its context is cancelled.
+type Worker interface {
+	Name() string
+	Run(ctx context.Context) error
+}
+
+// WorkerFunc adapts a function to the Worker interface.
+func WorkerFunc(name string, run func(ctx context.Context) error) Worker {
+	return workerFunc{name: name, run: run}
+}
+
+type workerFunc struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+func (w workerFunc) Name() string                  { return w.name }
+func (w workerFunc) Run(ctx context.Context) error { return w.run(ctx) }
+
+// running is a started worker and the means to stop it.
+type running struct {
+	name   string
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Manager starts background workers and stops them in reverse start order,
+// so a worker can rely on anything started before it until it has exited.
+type Manager struct {
+	mu      sync.Mutex
+	workers []*running
+}
+
+// NewManager creates an empty manager.
+func NewManager() *Manager {
+	return &Manager{}
+}
+
+// Go starts w in its own goroutine. The worker is cancelled by Stop, not by
+// ctx being done; ctx only supplies values such as loggers.
+func (m *Manager) Go(ctx context.Context, w Worker) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	r := &running{name: w.Name(), cancel: cancel, done: make(chan struct{})}
+
+	m.mu.Lock()
+	m.workers = append(m.workers, r)
+	m.mu.Unlock()
+
+	go func() {
+		defer close(r.done)
+		slog.Info("worker started", "worker", r.name)
+		if err := w.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			slog.Error("worker failed", "worker", r.name, "error", err)
+		}
+	}()
+}
+
+// Stop cancels workers one at a time, newest first, waiting for each to
+// exit. It gives up when ctx expires and reports which workers were still
+// running.
+func (m *Manager) Stop(ctx context.Context) error {
+	m.mu.Lock()
+	workers := m.workers
+	m.workers = nil
+	m.mu.Unlock()
+
+	var errs []error
+	for i := len(workers) - 1; i >= 0; i-- {
+		w := workers[i]
+		w.cancel()
+		select {
+		case <-w.done:
+			slog.Info("worker stopped", "worker", w.name)
+		case <-ctx.Done():
+			errs = append(errs, fmt.Errorf("worker %s did not stop: %w", w.name, ctx.Err()))
+		}
+	}
+	return errors.Join(errs...)
+}
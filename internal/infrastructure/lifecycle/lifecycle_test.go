@@ -0,0 +1,57 @@
+package lifecycle
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestManager_StopsWorkersInReverseOrder(t *testing.T) {
+	m := NewManager()
+	var mu sync.Mutex
+	var stopped []string
+
+	for _, name := range []string{"first", "second", "third"} {
+		m.Go(context.Background(), WorkerFunc(name, func(ctx context.Context) error {
+			<-ctx.Done()
+			mu.Lock()
+			stopped = append(stopped, name)
+			mu.Unlock()
+			return ctx.Err()
+		}))
+	}
+
+	if err := m.Stop(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []string{"third", "second", "first"}
+	if len(stopped) != len(want) {
+		t.Fatalf("expected %v, got %v", want, stopped)
+	}
+	for i := range want {
+		if stopped[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, stopped)
+			break
+		}
+	}
+}
+
+func TestManager_StopTimesOutOnStuckWorker(t *testing.T) {
+	m := NewManager()
+	release := make(chan struct{})
+	defer close(release)
+
+	m.Go(context.Background(), WorkerFunc("stuck", func(ctx context.Context) error {
+		<-release
+		return nil
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := m.Stop(ctx); err == nil {
+		t.Error("expected error for worker that ignores cancellation")
+	}
+}
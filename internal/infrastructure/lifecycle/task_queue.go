@@ -0,0 +1,61 @@
+package lifecycle
+
+import (
+	"context"
+	"sync"
+
+	"library-system/internal/application/ports"
+)
+
+// TaskQueue implements ports.TaskRunner with a bounded queue served by a
+// fixed number of goroutines. Run it as a Worker: when it is stopped,
+// running tasks see their ctx cancelled and tasks still queued are run
+// with a cancelled ctx, so each gets the chance to record that it was cut
+// short and release its resources.
+type TaskQueue struct {
+	tasks   chan func(ctx context.Context)
+	workers int
+}
+
+// NewTaskQueue creates a queue running up to workers tasks at once and
+// holding up to capacity more.
+func NewTaskQueue(workers, capacity int) *TaskQueue {
+	return &TaskQueue{tasks: make(chan func(ctx context.Context), capacity), workers: max(workers, 1)}
+}
+
+// Submit queues task, or returns ports.ErrTaskQueueFull without blocking.
+func (q *TaskQueue) Submit(task func(ctx context.Context)) error {
+	select {
+	case q.tasks <- task:
+		return nil
+	default:
+		return ports.ErrTaskQueueFull
+	}
+}
+
+// Run serves the queue until ctx is cancelled, then drains it.
+func (q *TaskQueue) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for range q.workers {
+		wg.Go(func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case task := <-q.tasks:
+					task(ctx)
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	for {
+		select {
+		case task := <-q.tasks:
+			task(ctx)
+		default:
+			return nil
+		}
+	}
+}
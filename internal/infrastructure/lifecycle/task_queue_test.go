@@ -0,0 +1,48 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"library-system/internal/application/ports"
+)
+
+func TestTaskQueue_RejectsWhenFull(t *testing.T) {
+	q := NewTaskQueue(1, 1)
+
+	first := q.Submit(func(context.Context) {})
+	second := q.Submit(func(context.Context) {})
+
+	if first != nil || !errors.Is(second, ports.ErrTaskQueueFull) {
+		t.Errorf("expected second task to be rejected, got %v and %v", first, second)
+	}
+}
+
+func TestTaskQueue_DrainsQueuedTasksWithCancelledContext(t *testing.T) {
+	q := NewTaskQueue(1, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results := make(chan error, 2)
+	for range 2 {
+		_ = q.Submit(func(ctx context.Context) { results <- ctx.Err() })
+	}
+
+	done := make(chan struct{})
+	go func() {
+		_ = q.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected Run to return after draining")
+	}
+	for range 2 {
+		if err := <-results; !errors.Is(err, context.Canceled) {
+			t.Errorf("expected queued task to see a cancelled ctx, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestContextHandler_AddsRequestAndTraceIDs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewContextHandler(slog.NewJSONHandler(&buf, nil))).With("component", "test")
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+	ctx = WithRequestID(ctx, "req-123")
+
+	logger.InfoContext(ctx, "book borrowed")
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatal(err)
+	}
+	if record["request_id"] != "req-123" {
+		t.Errorf("expected request_id req-123, got %v", record["request_id"])
+	}
+	if record["trace_id"] != traceID.String() {
+		t.Errorf("expected trace_id %s, got %v", traceID, record["trace_id"])
+	}
+	if record["component"] != "test" {
+		t.Errorf("expected attributes from With to be kept, got %v", record["component"])
+	}
+}
+
+func TestContextHandler_NoContextValues(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewContextHandler(slog.NewJSONHandler(&buf, nil)))
+
+	logger.InfoContext(context.Background(), "startup")
+
+	if bytes.Contains(buf.Bytes(), []byte("request_id")) || bytes.Contains(buf.Bytes(), []byte("trace_id")) {
+		t.Errorf("expected no correlation fields, got %s", buf.String())
+	}
+}
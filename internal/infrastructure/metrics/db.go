@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"library-system/internal/infrastructure/external"
+)
+
+// DBCollector exports pgxpool.Stat() for the primary and every replica,
+// plus replica health and lag from the cluster's probes.
+type DBCollector struct {
+	cluster *external.DBCluster
+
+	totalConns      *prometheus.Desc
+	idleConns       *prometheus.Desc
+	acquiredConns   *prometheus.Desc
+	constructing    *prometheus.Desc
+	maxConns        *prometheus.Desc
+	acquireCount    *prometheus.Desc
+	acquireDuration *prometheus.Desc
+	emptyAcquire    *prometheus.Desc
+	canceledAcquire *prometheus.Desc
+	replicaHealthy  *prometheus.Desc
+	replicaLag      *prometheus.Desc
+}
+
+// NewDBCollector creates a collector reading stats from cluster on each scrape.
+func NewDBCollector(cluster *external.DBCluster) *DBCollector {
+	poolLabels := []string{"pool", "role"}
+	desc := func(name, help string, labels []string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db", name), help, labels, nil)
+	}
+	return &DBCollector{
+		cluster:         cluster,
+		totalConns:      desc("pool_total_conns", "Connections currently open in the pool.", poolLabels),
+		idleConns:       desc("pool_idle_conns", "Idle connections in the pool.", poolLabels),
+		acquiredConns:   desc("pool_acquired_conns", "Connections currently checked out of the pool.", poolLabels),
+		constructing:    desc("pool_constructing_conns", "Connections being established.", poolLabels),
+		maxConns:        desc("pool_max_conns", "Maximum size of the pool.", poolLabels),
+		acquireCount:    desc("pool_acquires_total", "Successful connection acquisitions.", poolLabels),
+		acquireDuration: desc("pool_acquire_duration_seconds_total", "Total time spent acquiring connections.", poolLabels),
+		emptyAcquire:    desc("pool_empty_acquires_total", "Acquisitions that had to wait because the pool was empty.", poolLabels),
+		canceledAcquire: desc("pool_canceled_acquires_total", "Acquisitions cancelled by their context.", poolLabels),
+		replicaHealthy:  desc("replica_healthy", "1 if the replica is receiving reads, 0 if ejected.", []string{"pool"}),
+		replicaLag:      desc("replica_lag_seconds", "Replication lag measured by the last probe.", []string{"pool"}),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *DBCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		c.totalConns, c.idleConns, c.acquiredConns, c.constructing, c.maxConns,
+		c.acquireCount, c.acquireDuration, c.emptyAcquire, c.canceledAcquire,
+		c.replicaHealthy, c.replicaLag,
+	} {
+		ch <- d
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (c *DBCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, p := range c.cluster.Pools() {
+		s := p.Pool.Stat()
+		gauge := func(d *prometheus.Desc, v float64) {
+			ch <- prometheus.MustNewConstMetric(d, prometheus.GaugeValue, v, p.Name, p.Role)
+		}
+		counter := func(d *prometheus.Desc, v float64) {
+			ch <- prometheus.MustNewConstMetric(d, prometheus.CounterValue, v, p.Name, p.Role)
+		}
+		gauge(c.totalConns, float64(s.TotalConns()))
+		gauge(c.idleConns, float64(s.IdleConns()))
+		gauge(c.acquiredConns, float64(s.AcquiredConns()))
+		gauge(c.constructing, float64(s.ConstructingConns()))
+		gauge(c.maxConns, float64(s.MaxConns()))
+		counter(c.acquireCount, float64(s.AcquireCount()))
+		counter(c.acquireDuration, s.AcquireDuration().Seconds())
+		counter(c.emptyAcquire, float64(s.EmptyAcquireCount()))
+		counter(c.canceledAcquire, float64(s.CanceledAcquireCount()))
+	}
+
+	for _, r := range c.cluster.ReplicaStatuses() {
+		healthy := 0.0
+		if r.Healthy {
+			healthy = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.replicaHealthy, prometheus.GaugeValue, healthy, r.Name)
+		ch <- prometheus.MustNewConstMetric(c.replicaLag, prometheus.GaugeValue, r.Lag.Seconds(), r.Name)
+	}
+}
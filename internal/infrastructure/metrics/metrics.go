@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/cqrs"
+	"library-system/internal/application/exports"
+	"library-system/internal/application/imports"
+	"library-system/internal/domain/access"
+	"library-system/internal/domain/catalog"
+	"library-system/internal/domain/shared"
+)
+
+const namespace = "library"
+
+// Metrics owns the Prometheus registry and the application's instruments.
+type Metrics struct {
+	registry        *prometheus.Registry
+	httpDuration    *prometheus.HistogramVec
+	rpcDuration     *prometheus.HistogramVec
+	handlerCalls    *prometheus.CounterVec
+	handlerDuration *prometheus.HistogramVec
+}
+
+// New creates a registry with Go runtime and process collectors and the
+// application's HTTP, gRPC and handler instruments.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by route template and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "grpc_request_duration_seconds",
+			Help:      "gRPC call latency by method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+		handlerCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "handler_calls_total",
+			Help:      "Command and query handler calls by outcome (success or rejection reason).",
+		}, []string{"kind", "handler", "outcome"}),
+		handlerDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "handler_duration_seconds",
+			Help:      "Command and query handler latency.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"kind", "handler"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpDuration,
+		m.rpcDuration,
+		m.handlerCalls,
+		m.handlerDuration,
+	)
+	return m
+}
+
+// Register adds an extra collector, such as a DBCollector.
+func (m *Metrics) Register(c prometheus.Collector) {
+	m.registry.MustRegister(c)
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// ObserveHTTPRequest records one HTTP request. route must be the route
+// template (e.g. /api/v1/books/:id), never the raw path, to bound cardinality.
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, d time.Duration) {
+	m.httpDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(d.Seconds())
+}
+
+// ObserveRPC records one gRPC call. method is the full method name, e.g.
+// /library.v1.BookService/GetBook.
+func (m *Metrics) ObserveRPC(method, code string, d time.Duration) {
+	m.rpcDuration.WithLabelValues(method, code).Observe(d.Seconds())
+}
+
+// Interceptor counts and times every command and query handler call.
+func (m *Metrics) Interceptor() cqrs.Interceptor {
+	return func(ctx context.Context, info cqrs.Info, next func(ctx context.Context) error) error {
+		start := time.Now()
+		err := next(ctx)
+		m.handlerDuration.WithLabelValues(string(info.Kind), info.Name).Observe(time.Since(start).Seconds())
+		m.handlerCalls.WithLabelValues(string(info.Kind), info.Name, Outcome(err)).Inc()
+		return err
+	}
+}
+
+// Outcome maps a handler error to a low-cardinality label.
+func Outcome(err error) string {
+	switch {
+	case err == nil:
+		return "success"
+	case errors.Is(err, catalog.ErrBookNotFound),
+		errors.Is(err, access.ErrAPIKeyNotFound),
+		errors.Is(err, imports.ErrJobNotFound):
+		return "not_found"
+	case errors.Is(err, catalog.ErrBookAlreadyBorrowed):
+		return "already_borrowed"
+	case errors.Is(err, catalog.ErrBookNotBorrowed):
+		return "not_borrowed"
+	case errors.Is(err, catalog.ErrBookOnLoan):
+		return "on_loan"
+	case errors.Is(err, catalog.ErrBookVersionConflict):
+		return "version_conflict"
+	case errors.Is(err, catalog.ErrBookIDEmpty),
+		errors.Is(err, catalog.ErrBookIDInvalidFormat),
+		errors.Is(err, catalog.ErrBorrowerEmailRequired),
+		errors.Is(err, access.ErrAPIKeyIDInvalid),
+		errors.Is(err, access.ErrAPIKeyScopesNeeded),
+		errors.Is(err, imports.ErrUnsupportedFormat),
+		errors.Is(err, exports.ErrUnsupportedFormat),
+		errors.Is(err, shared.ErrValidation):
+		return "invalid"
+	case errors.Is(err, access.ErrAPIKeyRevoked),
+		errors.Is(err, access.ErrAPIKeyExpired):
+		return "revoked"
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return "unauthenticated"
+	case errors.Is(err, auth.ErrForbidden):
+		return "forbidden"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"library-system/internal/application/auth"
+	"library-system/internal/application/cqrs"
+	"library-system/internal/domain/catalog"
+	"library-system/internal/domain/shared"
+)
+
+func TestOutcome(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, "success"},
+		{catalog.ErrBookAlreadyBorrowed, "already_borrowed"},
+		{fmt.Errorf("wrapped: %w", catalog.ErrBookNotFound), "not_found"},
+		{catalog.ErrBookVersionConflict, "version_conflict"},
+		{shared.ValidationError{Field: "Title", Message: "Title cannot be empty"}, "invalid"},
+		{auth.ErrUnauthenticated, "unauthenticated"},
+		{fmt.Errorf("%w: add_book", auth.ErrForbidden), "forbidden"},
+		{context.DeadlineExceeded, "timeout"},
+		{fmt.Errorf("connection refused"), "error"},
+	}
+	for _, tt := range tests {
+		if got := Outcome(tt.err); got != tt.want {
+			t.Errorf("Outcome(%v) = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestInterceptor_CountsByOutcome(t *testing.T) {
+	m := New()
+	intercept := m.Interceptor()
+	info := cqrs.Info{Kind: cqrs.KindCommand, Name: "borrow_book"}
+
+	_ = intercept(context.Background(), info, func(ctx context.Context) error { return nil })
+	_ = intercept(context.Background(), info, func(ctx context.Context) error { return catalog.ErrBookAlreadyBorrowed })
+	_ = intercept(context.Background(), info, func(ctx context.Context) error { return catalog.ErrBookAlreadyBorrowed })
+
+	if got := testutil.ToFloat64(m.handlerCalls.WithLabelValues("command", "borrow_book", "success")); got != 1 {
+		t.Errorf("expected 1 success, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.handlerCalls.WithLabelValues("command", "borrow_book", "already_borrowed")); got != 2 {
+		t.Errorf("expected 2 already_borrowed, got %v", got)
+	}
+}
+
+func TestObserveRPC_LabelsByMethodAndCode(t *testing.T) {
+	m := New()
+
+	m.ObserveRPC("/library.v1.BookService/GetBook", "OK", time.Millisecond)
+	m.ObserveRPC("/library.v1.BookService/GetBook", "NotFound", time.Millisecond)
+
+	if got := testutil.CollectAndCount(m.rpcDuration); got != 2 {
+		t.Errorf("expected a series per method and code, got %d", got)
+	}
+}
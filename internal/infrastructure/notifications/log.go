@@ -0,0 +1,24 @@
+// Package notifications implements ports.Notifier: by email over SMTP, or
+// into the log for development.
+package notifications
+
+import (
+	"context"
+	"log/slog"
+
+	"library-system/internal/application/ports"
+)
+
+// LogNotifier writes notifications to the log instead of sending them
+type LogNotifier struct{}
+
+// NewLogNotifier creates a notifier that only logs
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+// Notify implements ports.Notifier.
+func (LogNotifier) Notify(ctx context.Context, n ports.Notification) error {
+	slog.InfoContext(ctx, "notification", "to", n.To, "subject", n.Subject, "body", n.Body)
+	return nil
+}
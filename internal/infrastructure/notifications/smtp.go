@@ -0,0 +1,139 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"library-system/internal/application/ports"
+)
+
+// SMTPNotifier sends notifications as plain-text email. It upgrades to TLS
+// when the server offers STARTTLS, and only authenticates over TLS or to
+// localhost, so a local mail sink needs neither.
+type SMTPNotifier struct {
+	addr     string
+	host     string
+	from     *mail.Address
+	username string
+	password string
+	timeout  time.Duration
+}
+
+// NewSMTPNotifier creates a notifier sending through the server at addr
+// (host:port) from the given address, e.g. "Library <library@example.com>".
+// Leave username empty for servers that don't need authentication.
+func NewSMTPNotifier(addr, from, username, password string, timeout time.Duration) (*SMTPNotifier, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP address %q: %w", addr, err)
+	}
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender %q: %w", from, err)
+	}
+	return &SMTPNotifier{addr: addr, host: host, from: sender, username: username, password: password, timeout: timeout}, nil
+}
+
+// Notify implements ports.Notifier.
+func (s *SMTPNotifier) Notify(ctx context.Context, n ports.Notification) error {
+	to, err := mail.ParseAddress(n.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient: %w", err)
+	}
+	message, err := s.message(to, n, time.Now())
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if s.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(s.from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(message); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// message formats n as a MIME message with a quoted-printable UTF-8 body
+func (s *SMTPNotifier) message(to *mail.Address, n ports.Notification, at time.Time) ([]byte, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	// Header values must stay on one line
+	subject := strings.Join(strings.Fields(n.Subject), " ")
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.from.String())
+	fmt.Fprintf(&msg, "To: %s\r\n", to.String())
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", at.Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "Message-ID: <%s@%s>\r\n", hex.EncodeToString(id), s.fromDomain())
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	// The writer turns line breaks into CRLF
+	body := quotedprintable.NewWriter(&msg)
+	if _, err := body.Write([]byte(n.Body)); err != nil {
+		return nil, err
+	}
+	if err := body.Close(); err != nil {
+		return nil, err
+	}
+	return msg.Bytes(), nil
+}
+
+func (s *SMTPNotifier) fromDomain() string {
+	_, domain, _ := strings.Cut(s.from.Address, "@")
+	return domain
+}
@@ -0,0 +1,121 @@
+package notifications
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"library-system/internal/application/ports"
+)
+
+// mailSink is a minimal SMTP server that accepts one message
+type mailSink struct {
+	listener net.Listener
+	rcpt     chan string
+	data     chan string
+}
+
+func newMailSink(t *testing.T) *mailSink {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink := &mailSink{listener: l, rcpt: make(chan string, 1), data: make(chan string, 1)}
+	go sink.serve()
+	t.Cleanup(func() { l.Close() })
+	return sink
+}
+
+func (s *mailSink) serve() {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	_ = tp.PrintfLine("220 sink ready")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO", "MAIL":
+			_ = tp.PrintfLine("250 ok")
+		case "RCPT":
+			s.rcpt <- arg
+			_ = tp.PrintfLine("250 ok")
+		case "DATA":
+			_ = tp.PrintfLine("354 go ahead")
+			data, _ := io.ReadAll(tp.DotReader())
+			s.data <- string(data)
+			_ = tp.PrintfLine("250 queued")
+		case "QUIT":
+			_ = tp.PrintfLine("221 bye")
+			return
+		default:
+			_ = tp.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func TestSMTPNotifier_SendsPlainTextMail(t *testing.T) {
+	sink := newMailSink(t)
+	notifier, err := NewSMTPNotifier(sink.listener.Addr().String(), "Library <library@example.com>", "", "", 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = notifier.Notify(context.Background(), ports.Notification{
+		To:      "john@example.com",
+		Subject: "“Dune” is overdue\r\nBcc: everyone@example.com",
+		Body:    "Hello,\n\nPlease return it.\n",
+	})
+	if err != nil {
+		t.Fatalf("expected the mail to be sent, got %v", err)
+	}
+
+	if rcpt := <-sink.rcpt; rcpt != "TO:<john@example.com>" {
+		t.Errorf("unexpected recipient %q", rcpt)
+	}
+	msg, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(<-sink.data)))
+	if err != nil {
+		t.Fatalf("expected a valid message, got %v", err)
+	}
+	if msg.Header.Get("Bcc") != "" {
+		t.Error("expected the subject not to inject headers")
+	}
+	subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if subject != "“Dune” is overdue Bcc: everyone@example.com" {
+		t.Errorf("unexpected subject %q", subject)
+	}
+	body, _ := io.ReadAll(msg.Body)
+	if !strings.Contains(string(body), "Please return it.") {
+		t.Errorf("unexpected body %q", body)
+	}
+}
+
+func TestSMTPNotifier_RejectsInvalidRecipient(t *testing.T) {
+	notifier, _ := NewSMTPNotifier("127.0.0.1:1", "library@example.com", "", "", time.Second)
+
+	err := notifier.Notify(context.Background(), ports.Notification{To: "john@example.com\r\nRCPT TO:<x@y>"})
+
+	if err == nil || !strings.Contains(err.Error(), "invalid recipient") {
+		t.Errorf("expected an invalid recipient error, got %v", err)
+	}
+}
+
+func TestNewSMTPNotifier_ValidatesSender(t *testing.T) {
+	if _, err := NewSMTPNotifier("localhost:1025", "not an address", "", "", time.Second); err == nil {
+		t.Error("expected an invalid sender to be rejected")
+	}
+}
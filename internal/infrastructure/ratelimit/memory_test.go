@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func newTestStore() (*MemoryStore, *time.Time) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func TestMemoryStore_AllowsBurstThenDenies(t *testing.T) {
+	s, _ := newTestStore()
+	limit := Limit{Rate: 1, Burst: 3}
+
+	for i := 0; i < 3; i++ {
+		r, _ := s.Take(context.Background(), "k", limit)
+		if !r.Allowed || r.Remaining != 2-i {
+			t.Fatalf("request %d: expected allowed with %d remaining, got %+v", i, 2-i, r)
+		}
+	}
+	r, _ := s.Take(context.Background(), "k", limit)
+
+	if r.Allowed {
+		t.Error("expected request over the burst to be denied")
+	}
+	if r.RetryAfter != time.Second {
+		t.Errorf("expected retry after 1s, got %s", r.RetryAfter)
+	}
+	if r.ResetAfter != 3*time.Second {
+		t.Errorf("expected reset after 3s, got %s", r.ResetAfter)
+	}
+}
+
+func TestMemoryStore_Refills(t *testing.T) {
+	s, now := newTestStore()
+	limit := Limit{Rate: 2, Burst: 1}
+
+	_, _ = s.Take(context.Background(), "k", limit)
+	*now = now.Add(500 * time.Millisecond)
+	r, _ := s.Take(context.Background(), "k", limit)
+
+	if !r.Allowed {
+		t.Error("expected a token after refill")
+	}
+}
+
+func TestMemoryStore_KeysAreIndependent(t *testing.T) {
+	s, _ := newTestStore()
+	limit := Limit{Rate: 1, Burst: 1}
+
+	_, _ = s.Take(context.Background(), "a", limit)
+	r, _ := s.Take(context.Background(), "b", limit)
+
+	if !r.Allowed {
+		t.Error("expected separate buckets per key")
+	}
+}
+
+func TestMemoryStore_SweepDropsFullBuckets(t *testing.T) {
+	s, now := newTestStore()
+	limit := Limit{Rate: 1, Burst: 2}
+	_, _ = s.Take(context.Background(), "k", limit)
+
+	*now = now.Add(time.Second)
+	s.sweep()
+	if len(s.buckets) != 1 {
+		t.Fatal("expected a refilling bucket to be kept")
+	}
+	*now = now.Add(2 * time.Second)
+	s.sweep()
+	if len(s.buckets) != 0 {
+		t.Error("expected a full bucket to be dropped")
+	}
+}
@@ -0,0 +1,68 @@
+// Package ratelimit implements token-bucket rate limiting over a pluggable
+// store, so limits can be kept per process or shared through Redis.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit is a token bucket: Burst requests may be made at once, refilled at
+// Rate requests per second.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Window is how long an empty bucket takes to refill completely.
+func (l Limit) Window() time.Duration {
+	if l.Rate <= 0 {
+		return 0
+	}
+	return time.Duration(float64(l.Burst) / l.Rate * float64(time.Second))
+}
+
+// Result is the outcome of taking a token.
+type Result struct {
+	Allowed    bool
+	Limit      int           // Bucket capacity
+	Remaining  int           // Whole tokens left after this request
+	RetryAfter time.Duration // Wait before the next token, if denied
+	ResetAfter time.Duration // Wait until the bucket is full again
+}
+
+// Store takes one token from the bucket named key.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// refill returns the tokens in a bucket that held tokens at last, after
+// elapsed time.
+func refill(tokens float64, elapsed time.Duration, limit Limit) float64 {
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	return math.Min(float64(limit.Burst), tokens+elapsed.Seconds()*limit.Rate)
+}
+
+// result describes a bucket holding tokens after a take attempt.
+func result(allowed bool, tokens float64, limit Limit) Result {
+	r := Result{
+		Allowed:    allowed,
+		Limit:      limit.Burst,
+		Remaining:  int(math.Floor(tokens)),
+		ResetAfter: secondsToDuration((float64(limit.Burst) - tokens) / limit.Rate),
+	}
+	if !allowed {
+		r.RetryAfter = secondsToDuration((1 - tokens) / limit.Rate)
+	}
+	return r
+}
+
+func secondsToDuration(s float64) time.Duration {
+	if s <= 0 || math.IsInf(s, 0) || math.IsNaN(s) {
+		return 0
+	}
+	return time.Duration(s * float64(time.Second))
+}
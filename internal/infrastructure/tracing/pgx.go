@@ -0,0 +1,62 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// QueryTracer implements pgx.QueryTracer, creating a span per statement
+// tagged with the pool it ran on, so a slow replica read stands out.
+type QueryTracer struct {
+	attrs []attribute.KeyValue
+}
+
+// NewQueryTracer creates a tracer for the named pool; role is "primary" or "replica".
+func NewQueryTracer(pool, role string) *QueryTracer {
+	return &QueryTracer{attrs: []attribute.KeyValue{
+		semconv.DBSystemNamePostgreSQL,
+		attribute.String("db.pool.name", pool),
+		attribute.String("db.pool.role", role),
+	}}
+}
+
+// TraceQueryStart implements pgx.QueryTracer.
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	op := operation(data.SQL)
+	ctx, _ = tracer().Start(ctx, "postgres "+op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(t.attrs...),
+		trace.WithAttributes(
+			semconv.DBOperationName(op),
+			semconv.DBQueryText(strings.TrimSpace(data.SQL)),
+		),
+	)
+	return ctx
+}
+
+// TraceQueryEnd implements pgx.QueryTracer.
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+	} else {
+		span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	}
+	span.End()
+}
+
+// operation returns the leading SQL keyword (SELECT, INSERT, ...).
+func operation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "QUERY"
+	}
+	return strings.ToUpper(fields[0])
+}
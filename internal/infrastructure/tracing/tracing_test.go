@@ -0,0 +1,50 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"library-system/internal/application/cqrs"
+	"library-system/internal/domain/catalog"
+)
+
+func TestInterceptor_RecordsSpanWithError(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	intercept := Interceptor()
+	err := intercept(context.Background(), cqrs.Info{Kind: cqrs.KindCommand, Name: "borrow_book"},
+		func(ctx context.Context) error { return catalog.ErrBookAlreadyBorrowed })
+
+	if err != catalog.ErrBookAlreadyBorrowed {
+		t.Errorf("expected handler error to propagate, got %v", err)
+	}
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Name() != "command borrow_book" {
+		t.Errorf("expected span 'command borrow_book', got %s", spans[0].Name())
+	}
+	if spans[0].Status().Code != codes.Error {
+		t.Errorf("expected error status, got %v", spans[0].Status().Code)
+	}
+}
+
+func TestOperation(t *testing.T) {
+	tests := map[string]string{
+		"\n\t\tSELECT id FROM books": "SELECT",
+		"insert into books":          "INSERT",
+		"":                           "QUERY",
+	}
+	for sql, want := range tests {
+		if got := operation(sql); got != want {
+			t.Errorf("operation(%q) = %s, want %s", sql, got, want)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"library-system/internal/domain/webhooks"
+)
+
+// Headers sent with every delivery. The ID is the delivery's and stays the
+// same across retries, so receivers can drop duplicates.
+const (
+	HeaderID        = "X-Webhook-ID"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+const (
+	// maxErrorBody is how much of a failed response is kept in the log
+	maxErrorBody = 512
+	// purgeInterval is how often finished deliveries past their retention
+	// are deleted
+	purgeInterval = time.Hour
+)
+
+// Queue is what the dispatcher needs from storage
+type Queue interface {
+	// FanOut creates pending deliveries for up to limit new events and
+	// returns how many events it read
+	FanOut(ctx context.Context, limit int, now time.Time) (int, error)
+	Claim(ctx context.Context, limit int, now time.Time, lease time.Duration) ([]Job, error)
+	Record(ctx context.Context, job Job, outcome Outcome) error
+	// Purge deletes finished deliveries created before the given time
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Config tunes a Dispatcher
+type Config struct {
+	PollInterval time.Duration
+	Timeout      time.Duration // per attempt
+	MaxAttempts  int
+	BackoffBase  time.Duration // delay after the first failure, doubling after each one
+	BackoffMax   time.Duration
+	BatchSize    int // deliveries attempted at once
+	Retention    time.Duration
+}
+
+// Envelope is the JSON body of a delivery, in the shape /events streams
+type Envelope struct {
+	ID         int64
+	Name       string
+	BookID     string
+	OccurredAt time.Time
+	Data       json.RawMessage
+}
+
+// Dispatcher polls the queue and delivers due webhooks
+type Dispatcher struct {
+	queue  Queue
+	cfg    Config
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewDispatcher creates a dispatcher. Redirects are not followed: a
+// receiver that moved must be re-registered.
+func NewDispatcher(queue Queue, cfg Config) *Dispatcher {
+	return &Dispatcher{
+		queue: queue,
+		cfg:   cfg,
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+// Run fans out and delivers every PollInterval until ctx is cancelled.
+// Attempts in flight at shutdown are finished and recorded.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+	lastPurge := d.now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			d.poll(ctx)
+			if d.now().Sub(lastPurge) >= purgeInterval {
+				d.purge(ctx)
+				lastPurge = d.now()
+			}
+		}
+	}
+}
+
+// poll fans out every committed event, then works through due deliveries
+// a batch at a time
+func (d *Dispatcher) poll(ctx context.Context) {
+	for ctx.Err() == nil {
+		n, err := d.queue.FanOut(ctx, d.cfg.BatchSize, d.now())
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.WarnContext(ctx, "failed to fan out webhook events", "error", err)
+			}
+			break
+		}
+		if n < d.cfg.BatchSize {
+			break
+		}
+	}
+
+	for ctx.Err() == nil {
+		jobs, err := d.queue.Claim(ctx, d.cfg.BatchSize, d.now(), d.lease())
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.WarnContext(ctx, "failed to claim webhook deliveries", "error", err)
+			}
+			return
+		}
+		d.deliverAll(context.WithoutCancel(ctx), jobs)
+		if len(jobs) < d.cfg.BatchSize {
+			return
+		}
+	}
+}
+
+// lease covers a batch of attempts made at once, plus recording them
+func (d *Dispatcher) lease() time.Duration {
+	return 2*d.cfg.Timeout + time.Minute
+}
+
+func (d *Dispatcher) deliverAll(ctx context.Context, jobs []Job) {
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		wg.Go(func() {
+			outcome := d.deliver(ctx, job)
+			if err := d.queue.Record(ctx, job, outcome); err != nil {
+				slog.WarnContext(ctx, "failed to record webhook attempt", "delivery_id", job.DeliveryID, "error", err)
+				return
+			}
+			if outcome.Status == webhooks.DeliveryDead {
+				slog.WarnContext(ctx, "webhook delivery dead", "delivery_id", job.DeliveryID, "url", job.URL,
+					"attempts", job.Attempts+1, "error", outcome.Error)
+			}
+		})
+	}
+	wg.Wait()
+}
+
+// deliver makes one attempt and decides what happens next
+func (d *Dispatcher) deliver(ctx context.Context, job Job) Outcome {
+	start := d.now()
+	outcome := Outcome{At: start}
+
+	if job.Payload == nil {
+		outcome.Error = "event was purged from the log before it could be delivered"
+		outcome.Status = webhooks.DeliveryDead
+		return outcome
+	}
+	statusCode, err := d.send(ctx, job, start)
+	outcome.Duration = d.now().Sub(start)
+	outcome.StatusCode = statusCode
+	if err == nil {
+		outcome.Status = webhooks.DeliverySucceeded
+		return outcome
+	}
+
+	outcome.Error = err.Error()
+	if attempts := job.Attempts + 1; attempts < d.cfg.MaxAttempts {
+		outcome.Status = webhooks.DeliveryPending
+		outcome.NextAttemptAt = start.Add(Backoff(attempts, d.cfg.BackoffBase, d.cfg.BackoffMax))
+	} else {
+		outcome.Status = webhooks.DeliveryDead
+	}
+	return outcome
+}
+
+// send POSTs the signed envelope and returns the response status, with an
+// error unless it was 2xx
+func (d *Dispatcher) send(ctx context.Context, job Job, at time.Time) (int, error) {
+	body, err := json.Marshal(Envelope{
+		ID:         job.EventID,
+		Name:       job.EventName,
+		BookID:     job.BookID,
+		OccurredAt: job.OccurredAt,
+		Data:       job.Payload,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, job.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "library-system-webhooks")
+	req.Header.Set(HeaderID, strconv.FormatInt(job.DeliveryID, 10))
+	req.Header.Set(HeaderEvent, job.EventName)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(job.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBody))
+		return resp.StatusCode, nil
+	}
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if text := strings.TrimSpace(string(snippet)); text != "" {
+		return resp.StatusCode, fmt.Errorf("receiver answered %d: %s", resp.StatusCode, text)
+	}
+	return resp.StatusCode, fmt.Errorf("receiver answered %d", resp.StatusCode)
+}
+
+func (d *Dispatcher) purge(ctx context.Context) {
+	n, err := d.queue.Purge(ctx, d.now().Add(-d.cfg.Retention))
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.WarnContext(ctx, "failed to purge old webhook deliveries", "error", err)
+		}
+		return
+	}
+	if n > 0 {
+		slog.DebugContext(ctx, "purged old webhook deliveries", "count", n)
+	}
+}
+
+// Backoff returns the delay before the attempt after the given number of
+// failed ones: base doubling with each failure, capped at ceiling, less up to
+// half of it at random so receivers recovering from an outage aren't hit
+// by every retry at once.
+func Backoff(failures int, base, ceiling time.Duration) time.Duration {
+	delay := ceiling
+	if shift := max(failures-1, 0); shift < 62 && base<<shift > 0 && base<<shift < ceiling {
+		delay = base << shift
+	}
+	half := delay / 2
+	return delay - time.Duration(rand.Int64N(int64(half)+1))
+}
+
+// Sign returns the X-Webhook-Signature of a body sent at timestamp: the
+// hex HMAC-SHA256 of "timestamp.body" keyed with the subscription's secret
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature in constant time, for receivers written in Go
+func Verify(secret, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
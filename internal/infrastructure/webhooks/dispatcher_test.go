@@ -0,0 +1,188 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"library-system/internal/domain/webhooks"
+)
+
+// memoryQueue hands out its jobs once and keeps what was recorded
+type memoryQueue struct {
+	mu       sync.Mutex
+	jobs     []Job
+	outcomes map[int64]Outcome
+}
+
+func (q *memoryQueue) FanOut(context.Context, int, time.Time) (int, error) {
+	return 0, nil
+}
+
+func (q *memoryQueue) Claim(_ context.Context, limit int, _ time.Time, _ time.Duration) ([]Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n := min(limit, len(q.jobs))
+	claimed := q.jobs[:n]
+	q.jobs = q.jobs[n:]
+	return claimed, nil
+}
+
+func (q *memoryQueue) Record(_ context.Context, job Job, outcome Outcome) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.outcomes == nil {
+		q.outcomes = make(map[int64]Outcome)
+	}
+	q.outcomes[job.DeliveryID] = outcome
+	return nil
+}
+
+func (q *memoryQueue) Purge(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func testConfig() Config {
+	return Config{
+		PollInterval: time.Second,
+		Timeout:      time.Second,
+		MaxAttempts:  3,
+		BackoffBase:  10 * time.Second,
+		BackoffMax:   time.Minute,
+		BatchSize:    10,
+		Retention:    time.Hour,
+	}
+}
+
+func testJob(url string) Job {
+	return Job{
+		DeliveryID: 7,
+		URL:        url,
+		Secret:     "whsec_test",
+		EventID:    42,
+		EventName:  "catalog.book_borrowed",
+		BookID:     "550e8400-e29b-41d4-a716-446655440000",
+		OccurredAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		Payload:    []byte(`{"BookID":"550e8400-e29b-41d4-a716-446655440000","BorrowerEmail":"john@example.com"}`),
+	}
+}
+
+func TestDispatcher_SendsSignedEnvelope(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer stub.Close()
+
+	queue := &memoryQueue{jobs: []Job{testJob(stub.URL)}}
+	NewDispatcher(queue, testConfig()).poll(context.Background())
+
+	if got == nil {
+		t.Fatal("expected the stub to be called")
+	}
+	if got.Header.Get(HeaderID) != "7" || got.Header.Get(HeaderEvent) != "catalog.book_borrowed" {
+		t.Errorf("unexpected headers %v", got.Header)
+	}
+	if !Verify("whsec_test", got.Header.Get(HeaderTimestamp), body, got.Header.Get(HeaderSignature)) {
+		t.Error("expected the signature to verify with the subscription's secret")
+	}
+	if Verify("whsec_other", got.Header.Get(HeaderTimestamp), body, got.Header.Get(HeaderSignature)) {
+		t.Error("expected the signature not to verify with another secret")
+	}
+
+	var envelope Envelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		t.Fatalf("expected a JSON body, got %v", err)
+	}
+	if envelope.ID != 42 || envelope.Name != "catalog.book_borrowed" || !strings.Contains(string(envelope.Data), "john@example.com") {
+		t.Errorf("unexpected envelope %+v", envelope)
+	}
+
+	outcome := queue.outcomes[7]
+	if outcome.Status != webhooks.DeliverySucceeded || outcome.StatusCode != http.StatusNoContent {
+		t.Errorf("expected a successful delivery, got %+v", outcome)
+	}
+}
+
+func TestDispatcher_RetriesFailuresWithBackoff(t *testing.T) {
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "maintenance", http.StatusServiceUnavailable)
+	}))
+	defer stub.Close()
+
+	queue := &memoryQueue{jobs: []Job{testJob(stub.URL)}}
+	NewDispatcher(queue, testConfig()).poll(context.Background())
+
+	outcome := queue.outcomes[7]
+	if outcome.Status != webhooks.DeliveryPending {
+		t.Fatalf("expected the delivery to stay pending, got %s", outcome.Status)
+	}
+	if outcome.StatusCode != http.StatusServiceUnavailable || !strings.Contains(outcome.Error, "maintenance") {
+		t.Errorf("expected the response to be logged, got %+v", outcome)
+	}
+	if wait := outcome.NextAttemptAt.Sub(outcome.At); wait < 5*time.Second || wait > 10*time.Second {
+		t.Errorf("expected the first retry within the base backoff, got %v", wait)
+	}
+}
+
+func TestDispatcher_DeadLettersAfterMaxAttempts(t *testing.T) {
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer stub.Close()
+
+	job := testJob(stub.URL)
+	job.Attempts = 2
+	queue := &memoryQueue{jobs: []Job{job}}
+	NewDispatcher(queue, testConfig()).poll(context.Background())
+
+	if outcome := queue.outcomes[7]; outcome.Status != webhooks.DeliveryDead {
+		t.Errorf("expected the last attempt to dead-letter the delivery, got %+v", outcome)
+	}
+}
+
+func TestDispatcher_DoesNotFollowRedirects(t *testing.T) {
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/elsewhere", http.StatusFound)
+	}))
+	defer stub.Close()
+
+	queue := &memoryQueue{jobs: []Job{testJob(stub.URL)}}
+	NewDispatcher(queue, testConfig()).poll(context.Background())
+
+	if outcome := queue.outcomes[7]; outcome.Status != webhooks.DeliveryPending || outcome.StatusCode != http.StatusFound {
+		t.Errorf("expected a redirect to count as a failure, got %+v", outcome)
+	}
+}
+
+func TestDispatcher_PurgedEventIsDead(t *testing.T) {
+	job := testJob("http://127.0.0.1:0")
+	job.Payload = nil
+	queue := &memoryQueue{jobs: []Job{job}}
+	NewDispatcher(queue, testConfig()).poll(context.Background())
+
+	if outcome := queue.outcomes[7]; outcome.Status != webhooks.DeliveryDead {
+		t.Errorf("expected a purged event to be dead, got %+v", outcome)
+	}
+}
+
+func TestBackoff_DoublesWithinCeiling(t *testing.T) {
+	base, ceiling := time.Second, 30*time.Second
+	for failures, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 4: 8 * time.Second, 6: ceiling, 100: ceiling} {
+		for range 20 {
+			got := Backoff(failures, base, ceiling)
+			if got < want/2 || got > want {
+				t.Fatalf("after %d failures expected between %v and %v, got %v", failures, want/2, want, got)
+			}
+		}
+	}
+}
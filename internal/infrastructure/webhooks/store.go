@@ -0,0 +1,211 @@
+// Package webhooks fans catalog events out to webhook subscriptions and
+// POSTs them to partner endpoints, retrying with backoff until they are
+// delivered or dead.
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"library-system/internal/domain/webhooks"
+)
+
+// Job is a delivery claimed for an attempt, with what is needed to make it
+type Job struct {
+	DeliveryID int64
+	URL        string
+	Secret     string
+	Attempts   int // made before this one
+	EventID    int64
+	EventName  string
+	BookID     string
+	OccurredAt time.Time
+	Payload    []byte // nil once the event has been purged from the log
+}
+
+// Outcome is the result of one attempt and what happens next
+type Outcome struct {
+	At            time.Time
+	StatusCode    int // 0 when no response came back
+	Error         string
+	Duration      time.Duration
+	Status        webhooks.DeliveryStatus
+	NextAttemptAt time.Time // only while pending
+}
+
+// Store implements Queue on the primary, where events are appended.
+//
+// Event IDs come from a sequence, so a transaction can commit after a later
+// one. Instead of waiting at gaps like the live feed, fan-out orders events
+// by the transaction that wrote them and only reads transactions older
+// than every one still running, so none is ever skipped.
+type Store struct {
+	pool *pgxpool.Pool
+}
+
+// NewStore creates a store on the primary pool
+func NewStore(pool *pgxpool.Pool) *Store {
+	return &Store{pool: pool}
+}
+
+// FanOut implements Queue. The cursor row is locked for the whole batch, so
+// only one instance fans out at a time; the others skip the round.
+func (s *Store) FanOut(ctx context.Context, limit int, now time.Time) (int, error) {
+	var fanned int
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		var cursorTx string
+		var cursorID int64
+		err := tx.QueryRow(ctx, `SELECT tx_id::text, event_id FROM webhook_cursor WHERE id = 1 FOR UPDATE SKIP LOCKED`).
+			Scan(&cursorTx, &cursorID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, `
+			SELECT id, tx_id::text FROM events
+			WHERE (tx_id, id) > ($1::text::xid8, $2) AND tx_id < pg_snapshot_xmin(pg_current_snapshot())
+			ORDER BY tx_id, id LIMIT $3
+		`, cursorTx, cursorID, limit)
+		if err != nil {
+			return err
+		}
+		var ids []int64
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id, &cursorTx); err != nil {
+				rows.Close()
+				return err
+			}
+			ids = append(ids, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		cursorID = ids[len(ids)-1]
+
+		// A subscription is sent events from when it was created onwards
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO webhook_deliveries (subscription_id, event_id, event_name, status, next_attempt_at, created_at)
+			SELECT s.id, e.id, e.name, 'pending', $2, $2
+			FROM events e JOIN webhook_subscriptions s ON e.name = ANY(s.events) AND s.created_at <= e.occurred_at
+			WHERE e.id = ANY($1)
+			ON CONFLICT (subscription_id, event_id) DO NOTHING
+		`, ids, now); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `UPDATE webhook_cursor SET tx_id = $1::text::xid8, event_id = $2 WHERE id = 1`,
+			cursorTx, cursorID); err != nil {
+			return err
+		}
+		fanned = len(ids)
+		return nil
+	})
+	return fanned, err
+}
+
+// Claim implements Queue. Claimed deliveries are leased until now+lease, so
+// an instance that dies mid-attempt only delays them.
+func (s *Store) Claim(ctx context.Context, limit int, now time.Time, lease time.Duration) ([]Job, error) {
+	rows, err := s.pool.Query(ctx, `
+		WITH due AS (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1 AND (locked_until IS NULL OR locked_until < $1)
+			ORDER BY next_attempt_at LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		), claimed AS (
+			UPDATE webhook_deliveries d SET locked_until = $2
+			FROM due WHERE d.id = due.id
+			RETURNING d.id, d.subscription_id, d.event_id, d.event_name, d.attempts
+		)
+		SELECT c.id, s.url, s.secret, c.attempts, c.event_id, c.event_name, e.book_id, e.occurred_at, e.payload
+		FROM claimed c
+		JOIN webhook_subscriptions s ON s.id = c.subscription_id
+		LEFT JOIN events e ON e.id = c.event_id
+		ORDER BY c.id
+	`, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []Job
+	for rows.Next() {
+		var (
+			job        Job
+			bookID     *string
+			occurredAt *time.Time
+		)
+		if err := rows.Scan(&job.DeliveryID, &job.URL, &job.Secret, &job.Attempts, &job.EventID,
+			&job.EventName, &bookID, &occurredAt, &job.Payload); err != nil {
+			return nil, err
+		}
+		if bookID != nil {
+			job.BookID = *bookID
+		}
+		if occurredAt != nil {
+			job.OccurredAt = *occurredAt
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// Record implements Queue. An attempt at a delivery that was deleted with
+// its subscription meanwhile is dropped.
+func (s *Store) Record(ctx context.Context, job Job, outcome Outcome) error {
+	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		var deliveredAt *time.Time
+		if outcome.Status == webhooks.DeliverySucceeded {
+			deliveredAt = &outcome.At
+		}
+		nextAttemptAt := outcome.NextAttemptAt
+		if nextAttemptAt.IsZero() {
+			nextAttemptAt = outcome.At
+		}
+		tag, err := tx.Exec(ctx, `
+			UPDATE webhook_deliveries
+			SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5, delivered_at = $6, locked_until = NULL
+			WHERE id = $1 AND status = 'pending'
+		`, job.DeliveryID, string(outcome.Status), job.Attempts+1, nextAttemptAt, nullableString(outcome.Error), deliveredAt)
+		if err != nil || tag.RowsAffected() == 0 {
+			return err
+		}
+
+		var statusCode *int
+		if outcome.StatusCode != 0 {
+			statusCode = &outcome.StatusCode
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO webhook_attempts (delivery_id, attempted_at, status_code, error, duration_ms)
+			VALUES ($1, $2, $3, $4, $5)
+		`, job.DeliveryID, outcome.At, statusCode, nullableString(outcome.Error), outcome.Duration.Milliseconds())
+		return err
+	})
+}
+
+// Purge implements Queue, keeping pending deliveries however old they are
+func (s *Store) Purge(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM webhook_deliveries WHERE status <> 'pending' AND created_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func nullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_books_borrower_email;
+ALTER TABLE books DROP COLUMN IF EXISTS borrower_email;
@@ -0,0 +1,5 @@
+-- Record who borrowed a book so returns and loan views can be authorized
+ALTER TABLE books ADD COLUMN borrower_email VARCHAR(254);
+
+-- Index for listing a borrower's loans
+CREATE INDEX idx_books_borrower_email ON books(borrower_email) WHERE borrower_email IS NOT NULL;
@@ -0,0 +1,14 @@
+-- API keys for non-interactive clients. Only a SHA-256 of the secret is
+-- stored; prefix is the public, unique part of the key used for lookup.
+CREATE TABLE IF NOT EXISTS api_keys (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) NOT NULL UNIQUE,
+    secret_hash BYTEA NOT NULL,
+    scopes TEXT[] NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    last_used_at TIMESTAMP,
+    rotated_to VARCHAR(36) REFERENCES api_keys(id)
+);
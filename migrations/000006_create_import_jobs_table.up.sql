@@ -0,0 +1,12 @@
+-- Background book imports and their progress
+CREATE TABLE IF NOT EXISTS import_jobs (
+    id UUID PRIMARY KEY,
+    format VARCHAR(16) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    submitted_by VARCHAR(255) NOT NULL,
+    report JSONB NOT NULL,
+    error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP
+);
@@ -0,0 +1,14 @@
+-- Domain events, for streaming and resuming catalog changes. ids follow
+-- insert order, not commit order: a transaction that inserts first may
+-- commit after one that inserts later, so readers must not treat the
+-- highest id seen as a watermark for everything below it.
+CREATE TABLE IF NOT EXISTS events (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL,
+    book_id VARCHAR(36) NOT NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_events_book_id ON events (book_id, id);
+CREATE INDEX IF NOT EXISTS idx_events_occurred_at ON events (occurred_at);
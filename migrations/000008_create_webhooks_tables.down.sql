@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS webhook_attempts;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_cursor;
+DROP TABLE IF EXISTS webhook_subscriptions;
+DROP INDEX IF EXISTS idx_events_tx_id;
+ALTER TABLE events DROP COLUMN IF EXISTS tx_id;
@@ -0,0 +1,54 @@
+-- The transaction that wrote each event, so readers can wait until every
+-- transaction that might still add an earlier event has finished
+ALTER TABLE events ADD COLUMN IF NOT EXISTS tx_id XID8 NOT NULL DEFAULT pg_current_xact_id();
+CREATE INDEX IF NOT EXISTS idx_events_tx_id ON events (tx_id, id);
+
+-- Partner endpoints told about catalog events. The secret signs payloads,
+-- so unlike API keys it is kept as is.
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id VARCHAR(36) PRIMARY KEY,
+    url TEXT NOT NULL,
+    events TEXT[] NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    secret VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- How far the event log has been fanned out to subscriptions
+CREATE TABLE IF NOT EXISTS webhook_cursor (
+    id INT PRIMARY KEY CHECK (id = 1),
+    tx_id XID8 NOT NULL,
+    event_id BIGINT NOT NULL
+);
+INSERT INTO webhook_cursor (id, tx_id, event_id)
+SELECT 1, COALESCE(MAX(tx_id), '0'::xid8), COALESCE(MAX(id), 0) FROM events
+ON CONFLICT DO NOTHING;
+
+-- One event to one subscription, retried until it succeeds or is dead
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    subscription_id VARCHAR(36) NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    event_id BIGINT NOT NULL,
+    event_name VARCHAR(64) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    locked_until TIMESTAMP,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP,
+    UNIQUE (subscription_id, event_id)
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription ON webhook_deliveries (subscription_id, id DESC);
+
+-- Every attempt at a delivery, for troubleshooting partner endpoints
+CREATE TABLE IF NOT EXISTS webhook_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    delivery_id BIGINT NOT NULL REFERENCES webhook_deliveries(id) ON DELETE CASCADE,
+    attempted_at TIMESTAMP NOT NULL,
+    status_code INT,
+    error TEXT,
+    duration_ms INT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_attempts_delivery ON webhook_attempts (delivery_id, id);
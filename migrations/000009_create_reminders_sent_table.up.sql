@@ -0,0 +1,11 @@
+-- Reminders already sent, one per kind per loan. A loan is a book and
+-- when it was borrowed, so borrowing the same book again starts afresh.
+CREATE TABLE IF NOT EXISTS reminders_sent (
+    book_id VARCHAR(36) NOT NULL,
+    borrowed_at TIMESTAMP NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    borrower_email VARCHAR(255) NOT NULL,
+    sent_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (book_id, borrowed_at, kind)
+);
+CREATE INDEX IF NOT EXISTS idx_reminders_sent_sent_at ON reminders_sent (sent_at);
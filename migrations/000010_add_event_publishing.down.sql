@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS event_publisher_cursor;
+ALTER TABLE events DROP COLUMN IF EXISTS aggregate_version;
@@ -0,0 +1,15 @@
+-- The version of the book after the change that raised each event, so
+-- consumers can spot gaps and stale updates. Events recorded before
+-- versions were tracked get -1, since 0 is the version a new book's
+-- BookAdded event really has.
+ALTER TABLE events ADD COLUMN IF NOT EXISTS aggregate_version INT NOT NULL DEFAULT -1;
+
+-- How far the event log has been published to the message broker
+CREATE TABLE IF NOT EXISTS event_publisher_cursor (
+    id INT PRIMARY KEY CHECK (id = 1),
+    tx_id XID8 NOT NULL,
+    event_id BIGINT NOT NULL
+);
+INSERT INTO event_publisher_cursor (id, tx_id, event_id)
+SELECT 1, COALESCE(MAX(tx_id), '0'::xid8), COALESCE(MAX(id), 0) FROM events
+ON CONFLICT DO NOTHING;
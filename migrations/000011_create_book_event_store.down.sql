@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS book_snapshots;
+DROP TABLE IF EXISTS book_events;
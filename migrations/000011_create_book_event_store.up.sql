@@ -0,0 +1,21 @@
+-- Every event of every book, kept for good. When books are event-sourced
+-- (CATALOG_REPOSITORY=event_sourced) this is their source of truth and the
+-- books table is a projection of it.
+CREATE TABLE IF NOT EXISTS book_events (
+    book_id VARCHAR(36) NOT NULL,
+    version INT NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- Two writers appending the same version means one worked on stale state
+    PRIMARY KEY (book_id, version)
+);
+
+-- The latest snapshot of each book, so loading it only replays the events
+-- after the snapshot
+CREATE TABLE IF NOT EXISTS book_snapshots (
+    book_id VARCHAR(36) PRIMARY KEY,
+    version INT NOT NULL,
+    state JSONB NOT NULL,
+    taken_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
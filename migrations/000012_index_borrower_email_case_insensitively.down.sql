@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_books_borrower_email_lower;
+CREATE INDEX IF NOT EXISTS idx_books_borrower_email ON books(borrower_email) WHERE borrower_email IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Loans are listed by borrower email ignoring case, as ownership is checked
+DROP INDEX IF EXISTS idx_books_borrower_email;
+CREATE INDEX IF NOT EXISTS idx_books_borrower_email_lower ON books (lower(borrower_email)) WHERE is_borrowed;
@@ -0,0 +1,40 @@
+// Package migrations embeds the SQL migrations so the running binary knows
+// which schema version it expects.
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// FS returns the embedded migration files.
+func FS() fs.FS {
+	return files
+}
+
+// LatestVersion returns the highest migration version, parsed from the
+// golang-migrate file name prefix (e.g. 000002_add_created_at_index.up.sql).
+func LatestVersion() uint {
+	entries, _ := fs.ReadDir(files, ".")
+
+	var latest uint
+	for _, e := range entries {
+		prefix, _, ok := strings.Cut(e.Name(), "_")
+		if !ok || !strings.HasSuffix(e.Name(), ".up.sql") {
+			continue
+		}
+		v, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+		if uint(v) > latest {
+			latest = uint(v)
+		}
+	}
+	return latest
+}
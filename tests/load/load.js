@@ -12,7 +12,7 @@
  *   5. Cool down: 5000 → 0 users
  *
  * Usage:
- *   k6 run tests/load/load.js
+ *   TOKEN=$(make -s token roles=librarian,patron) k6 run tests/load/load.js
  *
  * With web dashboard:
  *   K6_WEB_DASHBOARD=true k6 run tests/load/load.js
@@ -47,9 +47,15 @@ export const options = {
 
 const BASE_URL = __ENV.BASE_URL || 'http://localhost:8080';
 
+// Bearer token from `make token`, e.g. TOKEN=$(make -s token roles=librarian,patron) k6 run ...
+const HEADERS = {
+  'Content-Type': 'application/json',
+  Authorization: `Bearer ${__ENV.TOKEN}`,
+};
+
 export function setup() {
   // Verify API is reachable before starting
-  const res = http.get(`${BASE_URL}/api/v1/books`);
+  const res = http.get(`${BASE_URL}/api/v1/books`, { headers: HEADERS });
   if (res.status !== 200) {
     throw new Error(`API not reachable: ${res.status}`);
   }
@@ -60,7 +66,7 @@ export default function() {
   let bookId;
 
   group('List Books', function() {
-    const res = http.get(`${BASE_URL}/api/v1/books`);
+    const res = http.get(`${BASE_URL}/api/v1/books`, { headers: HEADERS });
     listBooksTrend.add(res.timings.duration);
 
     const success = check(res, {
@@ -80,7 +86,7 @@ export default function() {
         title: `Load Test Book ${vuId}-${timestamp}`,
         author: `Author ${vuId}`,
       }),
-      { headers: { 'Content-Type': 'application/json' } }
+      { headers: HEADERS }
     );
     addBookTrend.add(res.timings.duration);
 
@@ -98,7 +104,7 @@ export default function() {
 
   if (bookId) {
     group('Get Book', function() {
-      const res = http.get(`${BASE_URL}/api/v1/books/${bookId}`);
+      const res = http.get(`${BASE_URL}/api/v1/books/${bookId}`, { headers: HEADERS });
       getBookTrend.add(res.timings.duration);
 
       const success = check(res, {
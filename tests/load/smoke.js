@@ -5,7 +5,7 @@
  * Runs with minimal load to catch obvious issues.
  *
  * Usage:
- *   k6 run tests/load/smoke.js
+ *   TOKEN=$(make -s token roles=librarian,patron) k6 run tests/load/smoke.js
  *
  * With web dashboard:
  *   K6_WEB_DASHBOARD=true k6 run tests/load/smoke.js
@@ -25,9 +25,15 @@ export const options = {
 
 const BASE_URL = __ENV.BASE_URL || 'http://localhost:8080';
 
+// Bearer token from `make token`, e.g. TOKEN=$(make -s token roles=librarian,patron) k6 run ...
+const HEADERS = {
+  'Content-Type': 'application/json',
+  Authorization: `Bearer ${__ENV.TOKEN}`,
+};
+
 export default function() {
   // List books
-  const listRes = http.get(`${BASE_URL}/api/v1/books`);
+  const listRes = http.get(`${BASE_URL}/api/v1/books`, { headers: HEADERS });
   check(listRes, {
     'list: status 200': (r) => r.status === 200,
     'list: is array': (r) => JSON.parse(r.body).Books !== undefined,
@@ -43,7 +49,7 @@ export default function() {
       title: `Smoke Test Book ${timestamp}`,
       author: 'Test Author',
     }),
-    { headers: { 'Content-Type': 'application/json' } }
+    { headers: HEADERS }
   );
   check(addRes, {
     'add: status 201': (r) => r.status === 201,
@@ -6,7 +6,7 @@
  * Ramps up to 10,000 concurrent users.
  *
  * Usage:
- *   k6 run tests/load/stress.js
+ *   TOKEN=$(make -s token roles=librarian,patron) k6 run tests/load/stress.js
  *
  * With web dashboard:
  *   K6_WEB_DASHBOARD=true k6 run tests/load/stress.js
@@ -46,8 +46,14 @@ export const options = {
 
 const BASE_URL = __ENV.BASE_URL || 'http://localhost:8080';
 
+// Bearer token from `make token`, e.g. TOKEN=$(make -s token roles=librarian,patron) k6 run ...
+const HEADERS = {
+  'Content-Type': 'application/json',
+  Authorization: `Bearer ${__ENV.TOKEN}`,
+};
+
 export function setup() {
-  const res = http.get(`${BASE_URL}/api/v1/books`);
+  const res = http.get(`${BASE_URL}/api/v1/books`, { headers: HEADERS });
   if (res.status !== 200) {
     throw new Error(`API not reachable: ${res.status}`);
   }
@@ -60,7 +66,7 @@ export default function () {
 
   // List books
   group('List Books', function () {
-    const res = http.get(`${BASE_URL}/api/v1/books`);
+    const res = http.get(`${BASE_URL}/api/v1/books`, { headers: HEADERS });
     listBooksTrend.add(res.timings.duration);
 
     const success = check(res, {
@@ -83,7 +89,7 @@ export default function () {
         title: `Stress Book ${vuId}-${iter}-${timestamp}`,
         author: `Author ${vuId}`,
       }),
-      { headers: { 'Content-Type': 'application/json' } }
+      { headers: HEADERS }
     );
     addBookTrend.add(res.timings.duration);
 
@@ -102,7 +108,7 @@ export default function () {
   // Get book
   if (bookId) {
     group('Get Book', function () {
-      const res = http.get(`${BASE_URL}/api/v1/books/${bookId}`);
+      const res = http.get(`${BASE_URL}/api/v1/books/${bookId}`, { headers: HEADERS });
       getBookTrend.add(res.timings.duration);
 
       const success = check(res, {
@@ -118,10 +124,8 @@ export default function () {
     group('Borrow Book', function () {
       const res = http.post(
         `${BASE_URL}/api/v1/books/${bookId}/borrow`,
-        JSON.stringify({
-          borrower_email: `user${__VU}@example.com`,
-        }),
-        { headers: { 'Content-Type': 'application/json' } }
+        null,
+        { headers: HEADERS }
       );
       borrowBookTrend.add(res.timings.duration);
 
@@ -138,7 +142,7 @@ export default function () {
       const res = http.post(
         `${BASE_URL}/api/v1/books/${bookId}/return`,
         null,
-        { headers: { 'Content-Type': 'application/json' } }
+        { headers: HEADERS }
       );
       returnBookTrend.add(res.timings.duration);
 